@@ -126,6 +126,12 @@ func init() {
 	rootCmd.AddCommand(gracefulExitInitCmd)
 	rootCmd.AddCommand(gracefulExitStatusCmd)
 	rootCmd.AddCommand(issueAPITokenCmd)
+	rootCmd.AddCommand(reputationCmd)
+	reputationCmd.AddCommand(reputationExportCmd)
+	reputationCmd.AddCommand(reputationCompareCmd)
+	reputationCmd.AddCommand(reputationImportCmd)
+	reputationCmd.AddCommand(reputationResetCmd)
+	reputationCmd.AddCommand(reputationRenameCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
 	process.Bind(configCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
@@ -134,6 +140,11 @@ func init() {
 	process.Bind(gracefulExitInitCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
 	process.Bind(gracefulExitStatusCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
 	process.Bind(issueAPITokenCmd, &diagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(reputationExportCmd, &reputationExportCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(reputationCompareCmd, &reputationCompareCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(reputationImportCmd, &reputationImportCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(reputationResetCmd, &reputationResetCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(reputationRenameCmd, &reputationRenameCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
@@ -200,6 +211,11 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		return errs.New("Error checking version for storagenode database: %+v", err)
 	}
 
+	err = peer.Reputation.Service.CheckSchemaVersion(ctx)
+	if err != nil {
+		return errs.New("Error checking reputation database schema: %+v", err)
+	}
+
 	preflightEnabled, err := cmd.Flags().GetBool("preflight.database-check")
 	if err != nil {
 		return errs.New("Cannot retrieve preflight.database-check flag: %+v", err)
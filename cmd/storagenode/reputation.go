@@ -0,0 +1,296 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/private/process"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+var (
+	reputationCmd = &cobra.Command{
+		Use:   "reputation",
+		Short: "Reputation related commands",
+	}
+	reputationExportCmd = &cobra.Command{
+		Use:         "export",
+		Short:       "Export reputation stats of all satellites",
+		RunE:        cmdReputationExport,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	reputationImportCmd = &cobra.Command{
+		Use:         "import <file>",
+		Short:       "Replace reputation stats with stats exported as json",
+		Long:        "Replace locally cached reputation stats of all satellites with stats exported as json by another node, e.g. when moving the node to another host.",
+		Args:        cobra.ExactArgs(1),
+		RunE:        cmdReputationImport,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	reputationResetCmd = &cobra.Command{
+		Use:         "reset <satellite-id>",
+		Short:       "Delete locally cached reputation stats of a satellite",
+		Long:        "Delete locally cached reputation stats of a satellite, they are stored again on the next sync with the satellite.",
+		Args:        cobra.ExactArgs(1),
+		RunE:        cmdReputationReset,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	reputationRenameCmd = &cobra.Command{
+		Use:         "rename <old-satellite-id> <new-satellite-id>",
+		Short:       "Move locally cached reputation stats of a satellite to its new ID",
+		Long:        "Move locally cached reputation stats and history of a satellite to its new ID, e.g. when the satellite was re-keyed, so the history isn't started over.",
+		Args:        cobra.ExactArgs(2),
+		RunE:        cmdReputationRename,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	reputationCompareCmd = &cobra.Command{
+		Use:         "compare <database-dir>",
+		Short:       "Compare reputation stats with another node",
+		Long:        "Compare reputation stats of this node (a) with the node which keeps its databases in database-dir (b).",
+		Args:        cobra.ExactArgs(1),
+		RunE:        cmdReputationCompare,
+		Annotations: map[string]string{"type": "helper"},
+	}
+
+	reputationExportCfg struct {
+		storagenode.Config
+
+		Format   string `help:"export format, csv or json" default:"csv"`
+		Location string `help:"time zone of exported timestamps, e.g. Local or Europe/Berlin" default:"UTC"`
+	}
+
+	reputationCompareCfg struct {
+		storagenode.Config
+	}
+
+	reputationImportCfg struct {
+		storagenode.Config
+
+		Confirm bool `help:"confirm replacing the reputation stats" default:"false"`
+	}
+
+	reputationResetCfg struct {
+		storagenode.Config
+
+		Confirm bool `help:"confirm deleting the reputation stats" default:"false"`
+	}
+
+	reputationRenameCfg struct {
+		storagenode.Config
+
+		Confirm bool `help:"confirm moving the reputation stats" default:"false"`
+	}
+)
+
+func cmdReputationExport(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	format := reputationExportCfg.Format
+	if format != "csv" && format != "json" {
+		return errs.New("unsupported export format %q", format)
+	}
+
+	loc, err := time.LoadLocation(reputationExportCfg.Location)
+	if err != nil {
+		return errs.New("invalid time zone %q: %v", reputationExportCfg.Location, err)
+	}
+
+	db, err := storagenodedb.OpenExisting(ctx, zap.L().Named("db"), reputationExportCfg.DatabaseConfig())
+	if err != nil {
+		return errs.New("Error starting master database on storage node: %v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	if format == "json" {
+		return reputation.ExportJSON(ctx, os.Stdout, db.Reputation(), reputation.WithLocation(loc))
+	}
+
+	// stats are written as they are read, so they aren't held in memory at once.
+	writer, err := reputation.NewCSVWriter(os.Stdout, reputation.WithLocation(loc))
+	if err != nil {
+		return err
+	}
+	if err := db.Reputation().ForEach(ctx, writer.Write); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+func cmdReputationCompare(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	other := reputationCompareCfg.Config
+	other.Storage2.DatabaseDir = args[0]
+
+	statsA, err := allReputationStats(ctx, reputationCompareCfg.DatabaseConfig())
+	if err != nil {
+		return err
+	}
+	statsB, err := allReputationStats(ctx, other.DatabaseConfig())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "Satellite ID\tAudit Score Delta\tOnline Score Delta\tWorse\t")
+	var unmatched []reputation.NodeComparison
+	for _, comparison := range reputation.CompareNodes(statsA, statsB) {
+		if !comparison.Matched() {
+			unmatched = append(unmatched, comparison)
+			continue
+		}
+		worse := string(comparison.Worse)
+		if worse == "" {
+			worse = "-"
+		}
+		fmt.Fprintf(w, "%s\t%+.4f\t%+.4f\t%s\t\n", comparison.SatelliteID, comparison.AuditScoreDelta, comparison.OnlineScoreDelta, worse)
+	}
+
+	if len(unmatched) > 0 {
+		fmt.Fprintln(w, "\nUnmatched Satellite ID\tOnly On\t")
+		for _, comparison := range unmatched {
+			fmt.Fprintf(w, "%s\t%s\t\n", comparison.SatelliteID, comparison.OnlyOn)
+		}
+	}
+
+	return errs.Wrap(w.Flush())
+}
+
+// allReputationStats reads all reputation stats from the reputation database of a node.
+// The database is opened read-only, so comparing never migrates or writes it.
+func allReputationStats(ctx context.Context, config storagenodedb.Config) (_ []reputation.Stats, err error) {
+	db, closeDB, err := storagenodedb.OpenReputationReadOnly(ctx, zap.L().Named("db"), storagenodedb.ReputationDBPath(config))
+	if err != nil {
+		return nil, errs.New("Error opening reputation database on storage node: %v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, closeDB())
+	}()
+
+	return db.All(ctx)
+}
+
+func cmdReputationImport(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		err = errs.Combine(err, file.Close())
+	}()
+
+	stats, err := reputation.ReadJSON(file)
+	if err != nil {
+		return err
+	}
+
+	if !reputationImportCfg.Confirm {
+		return errs.New("replacing reputation stats with %d imported stats requires --confirm", len(stats))
+	}
+
+	db, err := storagenodedb.OpenExisting(ctx, zap.L().Named("db"), reputationImportCfg.DatabaseConfig())
+	if err != nil {
+		return errs.New("Error starting master database on storage node: %v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	if err := db.Reputation().ReplaceAll(ctx, stats); err != nil {
+		if errors.Is(err, reputation.ErrEmptyReplace) {
+			return errs.New("%s doesn't contain any reputation stats", args[0])
+		}
+		return err
+	}
+
+	fmt.Printf("Imported reputation stats of %d satellites.\n", len(stats))
+	return nil
+}
+
+func cmdReputationReset(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	satelliteID, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return errs.New("invalid satellite id %q: %v", args[0], err)
+	}
+
+	if !reputationResetCfg.Confirm {
+		return errs.New("resetting reputation stats of %s requires --confirm", satelliteID)
+	}
+
+	db, err := storagenodedb.OpenExisting(ctx, zap.L().Named("db"), reputationResetCfg.DatabaseConfig())
+	if err != nil {
+		return errs.New("Error starting master database on storage node: %v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	if err := db.Reputation().Reset(ctx, satelliteID); err != nil {
+		if errors.Is(err, reputation.ErrNoStats) {
+			return errs.New("no reputation stats stored for %s", satelliteID)
+		}
+		return err
+	}
+
+	fmt.Printf("Reputation stats of %s were reset.\n", satelliteID)
+	return nil
+}
+
+func cmdReputationRename(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	oldID, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return errs.New("invalid satellite id %q: %v", args[0], err)
+	}
+	newID, err := storj.NodeIDFromString(args[1])
+	if err != nil {
+		return errs.New("invalid satellite id %q: %v", args[1], err)
+	}
+
+	if !reputationRenameCfg.Confirm {
+		return errs.New("moving reputation stats of %s to %s requires --confirm", oldID, newID)
+	}
+
+	db, err := storagenodedb.OpenExisting(ctx, zap.L().Named("db"), reputationRenameCfg.DatabaseConfig())
+	if err != nil {
+		return errs.New("Error starting master database on storage node: %v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	if err := db.Reputation().RenameSatellite(ctx, oldID, newID); err != nil {
+		switch {
+		case errors.Is(err, reputation.ErrNoStats):
+			return errs.New("no reputation stats stored for %s", oldID)
+		case errors.Is(err, reputation.ErrSatelliteExists):
+			return errs.New("reputation stats are already stored for %s", newID)
+		}
+		return err
+	}
+
+	fmt.Printf("Reputation stats of %s were moved to %s.\n", oldID, newID)
+	return nil
+}
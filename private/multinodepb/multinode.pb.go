@@ -692,6 +692,303 @@ func (m *TrustedSatellitesResponse_NodeURL) GetAddress() string {
 	return ""
 }
 
+type ReputationStatsRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ReputationStatsRequest) Reset()         { *m = ReputationStatsRequest{} }
+func (m *ReputationStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReputationStatsRequest) ProtoMessage()    {}
+func (*ReputationStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{13}
+}
+func (m *ReputationStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReputationStatsRequest.Unmarshal(m, b)
+}
+func (m *ReputationStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReputationStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReputationStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReputationStatsRequest.Merge(m, src)
+}
+func (m *ReputationStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReputationStatsRequest.Size(m)
+}
+func (m *ReputationStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReputationStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReputationStatsRequest proto.InternalMessageInfo
+
+func (m *ReputationStatsRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type ReputationStatsResponse struct {
+	Stats                []*ReputationStatsResponse_Stats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *ReputationStatsResponse) Reset()         { *m = ReputationStatsResponse{} }
+func (m *ReputationStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*ReputationStatsResponse) ProtoMessage()    {}
+func (*ReputationStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{14}
+}
+func (m *ReputationStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReputationStatsResponse.Unmarshal(m, b)
+}
+func (m *ReputationStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReputationStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *ReputationStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReputationStatsResponse.Merge(m, src)
+}
+func (m *ReputationStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_ReputationStatsResponse.Size(m)
+}
+func (m *ReputationStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReputationStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReputationStatsResponse proto.InternalMessageInfo
+
+func (m *ReputationStatsResponse) GetStats() []*ReputationStatsResponse_Stats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type ReputationStatsResponse_Metric struct {
+	TotalCount           int64    `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	SuccessCount         int64    `protobuf:"varint,2,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	Alpha                float64  `protobuf:"fixed64,3,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta                 float64  `protobuf:"fixed64,4,opt,name=beta,proto3" json:"beta,omitempty"`
+	UnknownAlpha         float64  `protobuf:"fixed64,5,opt,name=unknown_alpha,json=unknownAlpha,proto3" json:"unknown_alpha,omitempty"`
+	UnknownBeta          float64  `protobuf:"fixed64,6,opt,name=unknown_beta,json=unknownBeta,proto3" json:"unknown_beta,omitempty"`
+	Score                float64  `protobuf:"fixed64,7,opt,name=score,proto3" json:"score,omitempty"`
+	UnknownScore         float64  `protobuf:"fixed64,8,opt,name=unknown_score,json=unknownScore,proto3" json:"unknown_score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReputationStatsResponse_Metric) Reset()         { *m = ReputationStatsResponse_Metric{} }
+func (m *ReputationStatsResponse_Metric) String() string { return proto.CompactTextString(m) }
+func (*ReputationStatsResponse_Metric) ProtoMessage()    {}
+func (*ReputationStatsResponse_Metric) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{14, 0}
+}
+func (m *ReputationStatsResponse_Metric) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReputationStatsResponse_Metric.Unmarshal(m, b)
+}
+func (m *ReputationStatsResponse_Metric) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReputationStatsResponse_Metric.Marshal(b, m, deterministic)
+}
+func (m *ReputationStatsResponse_Metric) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReputationStatsResponse_Metric.Merge(m, src)
+}
+func (m *ReputationStatsResponse_Metric) XXX_Size() int {
+	return xxx_messageInfo_ReputationStatsResponse_Metric.Size(m)
+}
+func (m *ReputationStatsResponse_Metric) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReputationStatsResponse_Metric.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReputationStatsResponse_Metric proto.InternalMessageInfo
+
+func (m *ReputationStatsResponse_Metric) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ReputationStatsResponse_Metric) GetSuccessCount() int64 {
+	if m != nil {
+		return m.SuccessCount
+	}
+	return 0
+}
+
+func (m *ReputationStatsResponse_Metric) GetAlpha() float64 {
+	if m != nil {
+		return m.Alpha
+	}
+	return 0
+}
+
+func (m *ReputationStatsResponse_Metric) GetBeta() float64 {
+	if m != nil {
+		return m.Beta
+	}
+	return 0
+}
+
+func (m *ReputationStatsResponse_Metric) GetUnknownAlpha() float64 {
+	if m != nil {
+		return m.UnknownAlpha
+	}
+	return 0
+}
+
+func (m *ReputationStatsResponse_Metric) GetUnknownBeta() float64 {
+	if m != nil {
+		return m.UnknownBeta
+	}
+	return 0
+}
+
+func (m *ReputationStatsResponse_Metric) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *ReputationStatsResponse_Metric) GetUnknownScore() float64 {
+	if m != nil {
+		return m.UnknownScore
+	}
+	return 0
+}
+
+type ReputationStatsResponse_Stats struct {
+	SatelliteId            NodeID                          `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	SatelliteAddress       string                          `protobuf:"bytes,2,opt,name=satellite_address,json=satelliteAddress,proto3" json:"satellite_address,omitempty"`
+	Uptime                 *ReputationStatsResponse_Metric `protobuf:"bytes,3,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	Audit                  *ReputationStatsResponse_Metric `protobuf:"bytes,4,opt,name=audit,proto3" json:"audit,omitempty"`
+	OnlineScore            float64                         `protobuf:"fixed64,5,opt,name=online_score,json=onlineScore,proto3" json:"online_score,omitempty"`
+	DisqualifiedAt         *time.Time                      `protobuf:"bytes,6,opt,name=disqualified_at,json=disqualifiedAt,proto3,stdtime" json:"disqualified_at,omitempty"`
+	SuspendedAt            *time.Time                      `protobuf:"bytes,7,opt,name=suspended_at,json=suspendedAt,proto3,stdtime" json:"suspended_at,omitempty"`
+	OfflineSuspendedAt     *time.Time                      `protobuf:"bytes,8,opt,name=offline_suspended_at,json=offlineSuspendedAt,proto3,stdtime" json:"offline_suspended_at,omitempty"`
+	OfflineUnderReviewAt   *time.Time                      `protobuf:"bytes,9,opt,name=offline_under_review_at,json=offlineUnderReviewAt,proto3,stdtime" json:"offline_under_review_at,omitempty"`
+	DisqualifiedObservedAt *time.Time                      `protobuf:"bytes,10,opt,name=disqualified_observed_at,json=disqualifiedObservedAt,proto3,stdtime" json:"disqualified_observed_at,omitempty"`
+	Generation             int64                           `protobuf:"varint,11,opt,name=generation,proto3" json:"generation,omitempty"`
+	UpdatedAt              time.Time                       `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3,stdtime" json:"updated_at"`
+	JoinedAt               time.Time                       `protobuf:"bytes,13,opt,name=joined_at,json=joinedAt,proto3,stdtime" json:"joined_at"`
+	XXX_NoUnkeyedLiteral   struct{}                        `json:"-"`
+	XXX_unrecognized       []byte                          `json:"-"`
+	XXX_sizecache          int32                           `json:"-"`
+}
+
+func (m *ReputationStatsResponse_Stats) Reset()         { *m = ReputationStatsResponse_Stats{} }
+func (m *ReputationStatsResponse_Stats) String() string { return proto.CompactTextString(m) }
+func (*ReputationStatsResponse_Stats) ProtoMessage()    {}
+func (*ReputationStatsResponse_Stats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{14, 1}
+}
+func (m *ReputationStatsResponse_Stats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReputationStatsResponse_Stats.Unmarshal(m, b)
+}
+func (m *ReputationStatsResponse_Stats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReputationStatsResponse_Stats.Marshal(b, m, deterministic)
+}
+func (m *ReputationStatsResponse_Stats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReputationStatsResponse_Stats.Merge(m, src)
+}
+func (m *ReputationStatsResponse_Stats) XXX_Size() int {
+	return xxx_messageInfo_ReputationStatsResponse_Stats.Size(m)
+}
+func (m *ReputationStatsResponse_Stats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReputationStatsResponse_Stats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReputationStatsResponse_Stats proto.InternalMessageInfo
+
+func (m *ReputationStatsResponse_Stats) GetSatelliteAddress() string {
+	if m != nil {
+		return m.SatelliteAddress
+	}
+	return ""
+}
+
+func (m *ReputationStatsResponse_Stats) GetUptime() *ReputationStatsResponse_Metric {
+	if m != nil {
+		return m.Uptime
+	}
+	return nil
+}
+
+func (m *ReputationStatsResponse_Stats) GetAudit() *ReputationStatsResponse_Metric {
+	if m != nil {
+		return m.Audit
+	}
+	return nil
+}
+
+func (m *ReputationStatsResponse_Stats) GetOnlineScore() float64 {
+	if m != nil {
+		return m.OnlineScore
+	}
+	return 0
+}
+
+func (m *ReputationStatsResponse_Stats) GetDisqualifiedAt() *time.Time {
+	if m != nil {
+		return m.DisqualifiedAt
+	}
+	return nil
+}
+
+func (m *ReputationStatsResponse_Stats) GetSuspendedAt() *time.Time {
+	if m != nil {
+		return m.SuspendedAt
+	}
+	return nil
+}
+
+func (m *ReputationStatsResponse_Stats) GetOfflineSuspendedAt() *time.Time {
+	if m != nil {
+		return m.OfflineSuspendedAt
+	}
+	return nil
+}
+
+func (m *ReputationStatsResponse_Stats) GetOfflineUnderReviewAt() *time.Time {
+	if m != nil {
+		return m.OfflineUnderReviewAt
+	}
+	return nil
+}
+
+func (m *ReputationStatsResponse_Stats) GetDisqualifiedObservedAt() *time.Time {
+	if m != nil {
+		return m.DisqualifiedObservedAt
+	}
+	return nil
+}
+
+func (m *ReputationStatsResponse_Stats) GetGeneration() int64 {
+	if m != nil {
+		return m.Generation
+	}
+	return 0
+}
+
+func (m *ReputationStatsResponse_Stats) GetUpdatedAt() time.Time {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return time.Time{}
+}
+
+func (m *ReputationStatsResponse_Stats) GetJoinedAt() time.Time {
+	if m != nil {
+		return m.JoinedAt
+	}
+	return time.Time{}
+}
+
 type EarnedRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
@@ -703,7 +1000,7 @@ func (m *EarnedRequest) Reset()         { *m = EarnedRequest{} }
 func (m *EarnedRequest) String() string { return proto.CompactTextString(m) }
 func (*EarnedRequest) ProtoMessage()    {}
 func (*EarnedRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{13}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{15}
 }
 func (m *EarnedRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EarnedRequest.Unmarshal(m, b)
@@ -741,7 +1038,7 @@ func (m *EarnedResponse) Reset()         { *m = EarnedResponse{} }
 func (m *EarnedResponse) String() string { return proto.CompactTextString(m) }
 func (*EarnedResponse) ProtoMessage()    {}
 func (*EarnedResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{14}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{16}
 }
 func (m *EarnedResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EarnedResponse.Unmarshal(m, b)
@@ -785,6 +1082,10 @@ func init() {
 	proto.RegisterType((*TrustedSatellitesRequest)(nil), "multinode.TrustedSatellitesRequest")
 	proto.RegisterType((*TrustedSatellitesResponse)(nil), "multinode.TrustedSatellitesResponse")
 	proto.RegisterType((*TrustedSatellitesResponse_NodeURL)(nil), "multinode.TrustedSatellitesResponse.NodeURL")
+	proto.RegisterType((*ReputationStatsRequest)(nil), "multinode.ReputationStatsRequest")
+	proto.RegisterType((*ReputationStatsResponse)(nil), "multinode.ReputationStatsResponse")
+	proto.RegisterType((*ReputationStatsResponse_Metric)(nil), "multinode.ReputationStatsResponse.Metric")
+	proto.RegisterType((*ReputationStatsResponse_Stats)(nil), "multinode.ReputationStatsResponse.Stats")
 	proto.RegisterType((*EarnedRequest)(nil), "multinode.EarnedRequest")
 	proto.RegisterType((*EarnedResponse)(nil), "multinode.EarnedResponse")
 }
@@ -792,59 +1093,83 @@ func init() {
 func init() { proto.RegisterFile("multinode.proto", fileDescriptor_9a45fd79b06f3a1b) }
 
 var fileDescriptor_9a45fd79b06f3a1b = []byte{
-	// 825 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xd1, 0x6e, 0xeb, 0x44,
-	0x10, 0xc5, 0x6d, 0xe2, 0xdc, 0x4c, 0x72, 0xdb, 0x9b, 0xe5, 0x4a, 0xb8, 0x26, 0xb9, 0xa9, 0xdc,
-	0x8a, 0x06, 0x81, 0x1c, 0x48, 0x9f, 0x90, 0x40, 0xa2, 0xa1, 0xb4, 0x8d, 0x08, 0x50, 0x9c, 0xc2,
-	0x03, 0x48, 0x8d, 0x36, 0xf1, 0x36, 0x31, 0x75, 0xbc, 0xc6, 0xbb, 0x0e, 0xe4, 0x2f, 0xf8, 0x23,
-	0xc4, 0x0b, 0xe2, 0x1b, 0x78, 0x28, 0x9f, 0xc1, 0x2b, 0xf2, 0xee, 0xc6, 0x71, 0xda, 0x24, 0xa0,
-	0xdc, 0x37, 0xcf, 0xcc, 0x99, 0x73, 0xd6, 0xb3, 0xb3, 0x07, 0xf6, 0x27, 0xb1, 0xcf, 0xbd, 0x80,
-	0xba, 0xc4, 0x0e, 0x23, 0xca, 0x29, 0x2a, 0xa6, 0x09, 0x13, 0x46, 0x74, 0x44, 0x65, 0xda, 0xac,
-	0x8f, 0x28, 0x1d, 0xf9, 0xa4, 0x29, 0xa2, 0x41, 0x7c, 0xd7, 0xe4, 0xde, 0x84, 0x30, 0x8e, 0x27,
-	0xa1, 0x04, 0x58, 0x0d, 0x78, 0xee, 0x90, 0x9f, 0x62, 0xc2, 0xf8, 0x15, 0xc1, 0x2e, 0x89, 0xd0,
-	0x5b, 0x50, 0xc0, 0xa1, 0xd7, 0xbf, 0x27, 0x33, 0x43, 0x3b, 0xd4, 0x1a, 0x65, 0x47, 0xc7, 0xa1,
-	0xf7, 0x05, 0x99, 0x59, 0xe7, 0xf0, 0xe2, 0xdc, 0x63, 0xf7, 0xbd, 0x10, 0x0f, 0x89, 0x6a, 0x41,
-	0x1f, 0x80, 0x3e, 0x16, 0x6d, 0x02, 0x5b, 0x6a, 0x19, 0xf6, 0xe2, 0x5c, 0x4b, 0xb4, 0x8e, 0xc2,
-	0x59, 0xbf, 0x69, 0x50, 0xc9, 0xd0, 0xb0, 0x90, 0x06, 0x8c, 0xa0, 0x2a, 0x14, 0xb1, 0xef, 0xd3,
-	0x21, 0xe6, 0xc4, 0x15, 0x54, 0xbb, 0xce, 0x22, 0x81, 0xea, 0x50, 0x8a, 0x19, 0x71, 0xfb, 0xa1,
-	0x47, 0x86, 0x84, 0x19, 0x3b, 0xa2, 0x0e, 0x49, 0xea, 0x5a, 0x64, 0x50, 0x0d, 0x44, 0xd4, 0xe7,
-	0x11, 0x66, 0x63, 0x63, 0x57, 0xf6, 0x27, 0x99, 0x9b, 0x24, 0x81, 0x10, 0xe4, 0xee, 0x22, 0x42,
-	0x8c, 0x9c, 0x28, 0x88, 0x6f, 0xa1, 0x38, 0xc5, 0x9e, 0x8f, 0x07, 0x3e, 0x31, 0xf2, 0x4a, 0x71,
-	0x9e, 0x40, 0x26, 0x3c, 0xa3, 0x53, 0x12, 0x25, 0x14, 0x86, 0x2e, 0x8a, 0x69, 0x6c, 0x5d, 0x43,
-	0xb5, 0x8d, 0x03, 0xf7, 0x67, 0xcf, 0xe5, 0xe3, 0x2f, 0x69, 0xc0, 0xc7, 0xbd, 0x78, 0x32, 0xc1,
-	0xd1, 0x6c, 0xfb, 0x99, 0x9c, 0x42, 0x6d, 0x0d, 0xa3, 0x1a, 0x0f, 0x82, 0x9c, 0x38, 0x8a, 0x9c,
-	0x8c, 0xf8, 0xb6, 0xda, 0xb0, 0xf7, 0x1d, 0x89, 0x98, 0x47, 0x83, 0xed, 0x85, 0xdf, 0x83, 0xfd,
-	0x94, 0x43, 0x49, 0x19, 0x50, 0x98, 0xca, 0x94, 0x60, 0x29, 0x3a, 0xf3, 0xd0, 0xba, 0x00, 0xd4,
-	0xc5, 0x8c, 0x7f, 0x46, 0x03, 0x8e, 0x87, 0x7c, 0x7b, 0xd1, 0x5b, 0x78, 0x73, 0x89, 0x47, 0x09,
-	0x5f, 0x42, 0xd9, 0xc7, 0x8c, 0xf7, 0x87, 0x32, 0xaf, 0xe8, 0x4c, 0x5b, 0x2e, 0xb0, 0x3d, 0x5f,
-	0x60, 0xfb, 0x66, 0xbe, 0xc0, 0xed, 0x67, 0x7f, 0x3e, 0xd4, 0xdf, 0xf8, 0xf5, 0xef, 0xba, 0xe6,
-	0x94, 0xfc, 0x05, 0xa1, 0xf5, 0x0b, 0x54, 0x1c, 0x12, 0xc6, 0x1c, 0xf3, 0xd7, 0x99, 0x0d, 0xfa,
-	0x10, 0xca, 0x0c, 0x73, 0xe2, 0xfb, 0x1e, 0x27, 0x7d, 0xcf, 0x15, 0x5b, 0x57, 0x6e, 0xef, 0x25,
-	0x9a, 0x7f, 0x3d, 0xd4, 0xf5, 0xaf, 0xa8, 0x4b, 0x3a, 0xe7, 0x4e, 0x29, 0xc5, 0x74, 0x5c, 0xeb,
-	0x1f, 0x0d, 0x50, 0x56, 0x5a, 0xfd, 0xd9, 0xc7, 0xa0, 0xd3, 0xc0, 0xf7, 0x02, 0xa2, 0xb4, 0x8f,
-	0x97, 0xb4, 0x1f, 0xc3, 0xed, 0xaf, 0x05, 0xd6, 0x51, 0x3d, 0xe8, 0x23, 0xc8, 0xe3, 0xd8, 0xf5,
-	0xb8, 0x38, 0x40, 0xa9, 0x75, 0xb4, 0xb9, 0xf9, 0x2c, 0x81, 0x3a, 0xb2, 0xc3, 0x7c, 0x05, 0xba,
-	0x24, 0x43, 0x2f, 0x21, 0xcf, 0x86, 0x34, 0x92, 0x27, 0xd0, 0x1c, 0x19, 0x98, 0x57, 0x90, 0x17,
-	0xf8, 0xd5, 0x65, 0xf4, 0x2e, 0xbc, 0x60, 0x31, 0x0b, 0x49, 0x90, 0x5c, 0x7f, 0x5f, 0x02, 0x76,
-	0x04, 0x60, 0x7f, 0x91, 0xef, 0x25, 0x69, 0xab, 0x0b, 0xc6, 0x4d, 0x14, 0x33, 0x4e, 0xdc, 0xde,
-	0x7c, 0x1e, 0x6c, 0xfb, 0x0d, 0xf9, 0x43, 0x83, 0x83, 0x15, 0x74, 0x6a, 0x9c, 0x3f, 0x00, 0xe2,
-	0xb2, 0xd8, 0x4f, 0x87, 0xcf, 0x0c, 0xed, 0x70, 0xb7, 0x51, 0x6a, 0xbd, 0x9f, 0xe1, 0x5e, 0xcb,
-	0x60, 0x27, 0x77, 0xf7, 0xad, 0xd3, 0x75, 0x2a, 0xfc, 0x31, 0xc4, 0xec, 0x42, 0x41, 0x55, 0xd1,
-	0x09, 0x14, 0x12, 0x9e, 0xe4, 0xee, 0xb5, 0x95, 0x77, 0xaf, 0x27, 0xe5, 0x8e, 0x9b, 0x3c, 0x19,
-	0xec, 0xba, 0x11, 0x61, 0xd2, 0x9a, 0x8a, 0xce, 0x3c, 0xb4, 0xce, 0xe0, 0xf9, 0xe7, 0x38, 0x0a,
-	0x88, 0xbb, 0xfd, 0x2c, 0xde, 0x81, 0xbd, 0x39, 0x85, 0xfa, 0xff, 0x97, 0x90, 0xe7, 0x94, 0x63,
-	0x5f, 0xb9, 0x81, 0x0c, 0x5a, 0xdf, 0x40, 0xa1, 0xc7, 0x69, 0x84, 0x47, 0x04, 0x5d, 0x40, 0x31,
-	0x75, 0x58, 0xf4, 0x76, 0x46, 0xe1, 0xb1, 0x7d, 0x9b, 0xd5, 0xd5, 0x45, 0x29, 0xd4, 0x0a, 0xa0,
-	0x98, 0xda, 0x12, 0xc2, 0x50, 0xce, 0x5a, 0x13, 0x3a, 0xc9, 0xb4, 0x6e, 0xb2, 0x43, 0xb3, 0xf1,
-	0xdf, 0x40, 0xa5, 0xf7, 0xfb, 0x0e, 0xe4, 0x92, 0xd1, 0xa2, 0x4f, 0xa1, 0xa0, 0x6c, 0x09, 0x1d,
-	0x64, 0xba, 0x97, 0xed, 0xce, 0x34, 0x57, 0x95, 0xd4, 0x8c, 0xba, 0x50, 0xca, 0x78, 0x0c, 0xaa,
-	0x65, 0xa0, 0x4f, 0x3d, 0xcc, 0x7c, 0xb5, 0xae, 0xac, 0xd8, 0x3a, 0x00, 0x8b, 0xa7, 0x86, 0xaa,
-	0x6b, 0x5e, 0xa0, 0xe4, 0xaa, 0x6d, 0x7c, 0x9f, 0xe8, 0x16, 0x2a, 0x4f, 0xf6, 0x12, 0x1d, 0x6d,
-	0xde, 0x5a, 0x49, 0x7c, 0xfc, 0x7f, 0x56, 0xbb, 0x75, 0x09, 0xfa, 0x35, 0x9e, 0xd1, 0x98, 0xa3,
-	0x4f, 0x40, 0x97, 0x8b, 0x83, 0xb2, 0x4b, 0xb6, 0xb4, 0x8e, 0xe6, 0xc1, 0x8a, 0x8a, 0x24, 0x6a,
-	0x1f, 0x7f, 0x6f, 0x31, 0x4e, 0xa3, 0x1f, 0x6d, 0x8f, 0x36, 0xc5, 0x47, 0x33, 0x8c, 0xbc, 0x29,
-	0xe6, 0xa4, 0x99, 0xb6, 0x84, 0x83, 0x81, 0x2e, 0x6c, 0xf9, 0xf4, 0xdf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0xd9, 0x0f, 0xd3, 0x9c, 0x8f, 0x08, 0x00, 0x00,
+	// 1213 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5d, 0x53, 0xdb, 0x46,
+	0x17, 0x7e, 0x15, 0x6c, 0x19, 0x1f, 0x19, 0x08, 0xfb, 0x32, 0x89, 0xa2, 0xf2, 0x91, 0x08, 0xa6,
+	0x21, 0x93, 0x8e, 0x69, 0x9d, 0xab, 0xce, 0xf4, 0x4b, 0x86, 0x26, 0xa1, 0x25, 0x0d, 0x95, 0x49,
+	0xdb, 0x49, 0x67, 0xa2, 0x59, 0x5b, 0x8b, 0x51, 0x10, 0x5a, 0x45, 0xbb, 0x32, 0xe5, 0x5f, 0xf4,
+	0xbe, 0xbf, 0xa0, 0xbf, 0xa2, 0x77, 0x9d, 0xde, 0xf5, 0xbe, 0x17, 0xe9, 0xcf, 0xe8, 0x45, 0x6f,
+	0x3a, 0xfb, 0x61, 0x5b, 0x06, 0x03, 0x86, 0xdc, 0x79, 0x9f, 0x73, 0x9e, 0xe7, 0xac, 0xcf, 0x1e,
+	0x3d, 0xbb, 0x30, 0x77, 0x94, 0xc7, 0x3c, 0x4a, 0x68, 0x48, 0xea, 0x69, 0x46, 0x39, 0x45, 0xd5,
+	0x01, 0xe0, 0x40, 0x97, 0x76, 0xa9, 0x82, 0x9d, 0x95, 0x2e, 0xa5, 0xdd, 0x98, 0x6c, 0xc8, 0x55,
+	0x3b, 0xdf, 0xdf, 0xe0, 0xd1, 0x11, 0x61, 0x1c, 0x1f, 0xa5, 0x2a, 0xc1, 0x5d, 0x87, 0x19, 0x9f,
+	0xbc, 0xc9, 0x09, 0xe3, 0x4f, 0x09, 0x0e, 0x49, 0x86, 0x6e, 0x43, 0x05, 0xa7, 0x51, 0x70, 0x48,
+	0x4e, 0x6c, 0xe3, 0xae, 0xb1, 0x5e, 0xf3, 0x4d, 0x9c, 0x46, 0x5f, 0x93, 0x13, 0x77, 0x0b, 0x6e,
+	0x6e, 0x45, 0xec, 0xb0, 0x95, 0xe2, 0x0e, 0xd1, 0x14, 0xf4, 0x21, 0x98, 0x07, 0x92, 0x26, 0x73,
+	0xad, 0x86, 0x5d, 0x1f, 0xee, 0x6b, 0x44, 0xd6, 0xd7, 0x79, 0xee, 0x6f, 0x06, 0xcc, 0x17, 0x64,
+	0x58, 0x4a, 0x13, 0x46, 0xd0, 0x22, 0x54, 0x71, 0x1c, 0xd3, 0x0e, 0xe6, 0x24, 0x94, 0x52, 0x53,
+	0xfe, 0x10, 0x40, 0x2b, 0x60, 0xe5, 0x8c, 0x84, 0x41, 0x1a, 0x91, 0x0e, 0x61, 0xf6, 0x0d, 0x19,
+	0x07, 0x01, 0xed, 0x4a, 0x04, 0x2d, 0x81, 0x5c, 0x05, 0x3c, 0xc3, 0xec, 0xc0, 0x9e, 0x52, 0x7c,
+	0x81, 0xec, 0x09, 0x00, 0x21, 0x28, 0xed, 0x67, 0x84, 0xd8, 0x25, 0x19, 0x90, 0xbf, 0x65, 0xc5,
+	0x1e, 0x8e, 0x62, 0xdc, 0x8e, 0x89, 0x5d, 0xd6, 0x15, 0xfb, 0x00, 0x72, 0x60, 0x9a, 0xf6, 0x48,
+	0x26, 0x24, 0x6c, 0x53, 0x06, 0x07, 0x6b, 0x77, 0x17, 0x16, 0x9b, 0x38, 0x09, 0x8f, 0xa3, 0x90,
+	0x1f, 0x3c, 0xa3, 0x09, 0x3f, 0x68, 0xe5, 0x47, 0x47, 0x38, 0x3b, 0xb9, 0x7e, 0x4f, 0x1e, 0xc1,
+	0xd2, 0x39, 0x8a, 0xba, 0x3d, 0x08, 0x4a, 0x72, 0x2b, 0xaa, 0x33, 0xf2, 0xb7, 0xdb, 0x84, 0xd9,
+	0xef, 0x48, 0xc6, 0x22, 0x9a, 0x5c, 0xbf, 0xf0, 0x43, 0x98, 0x1b, 0x68, 0xe8, 0x52, 0x36, 0x54,
+	0x7a, 0x0a, 0x92, 0x2a, 0x55, 0xbf, 0xbf, 0x74, 0x1f, 0x03, 0xda, 0xc1, 0x8c, 0x6f, 0xd2, 0x84,
+	0xe3, 0x0e, 0xbf, 0x7e, 0xd1, 0x57, 0xf0, 0xff, 0x11, 0x1d, 0x5d, 0xf8, 0x09, 0xd4, 0x62, 0xcc,
+	0x78, 0xd0, 0x51, 0xb8, 0x96, 0x73, 0xea, 0x6a, 0x80, 0xeb, 0xfd, 0x01, 0xae, 0xef, 0xf5, 0x07,
+	0xb8, 0x39, 0xfd, 0xc7, 0xdb, 0x95, 0xff, 0xfd, 0xfc, 0xf7, 0x8a, 0xe1, 0x5b, 0xf1, 0x50, 0xd0,
+	0xfd, 0x09, 0xe6, 0x7d, 0x92, 0xe6, 0x1c, 0xf3, 0x77, 0xe9, 0x0d, 0xfa, 0x08, 0x6a, 0x0c, 0x73,
+	0x12, 0xc7, 0x11, 0x27, 0x41, 0x14, 0xca, 0xa9, 0xab, 0x35, 0x67, 0x45, 0xcd, 0xbf, 0xde, 0xae,
+	0x98, 0xdf, 0xd0, 0x90, 0x6c, 0x6f, 0xf9, 0xd6, 0x20, 0x67, 0x3b, 0x74, 0xff, 0x31, 0x00, 0x15,
+	0x4b, 0xeb, 0x7f, 0xf6, 0x09, 0x98, 0x34, 0x89, 0xa3, 0x84, 0xe8, 0xda, 0x6b, 0x23, 0xb5, 0x4f,
+	0xa7, 0xd7, 0x9f, 0xcb, 0x5c, 0x5f, 0x73, 0xd0, 0xc7, 0x50, 0xc6, 0x79, 0x18, 0x71, 0xb9, 0x01,
+	0xab, 0xb1, 0x7a, 0x31, 0xd9, 0x13, 0xa9, 0xbe, 0x62, 0x38, 0xcb, 0x60, 0x2a, 0x31, 0xb4, 0x00,
+	0x65, 0xd6, 0xa1, 0x99, 0xda, 0x81, 0xe1, 0xab, 0x85, 0xf3, 0x14, 0xca, 0x32, 0x7f, 0x7c, 0x18,
+	0x3d, 0x80, 0x9b, 0x2c, 0x67, 0x29, 0x49, 0xc4, 0xf1, 0x07, 0x2a, 0xe1, 0x86, 0x4c, 0x98, 0x1b,
+	0xe2, 0x2d, 0x01, 0xbb, 0x3b, 0x60, 0xef, 0x65, 0x39, 0xe3, 0x24, 0x6c, 0xf5, 0xfb, 0xc1, 0xae,
+	0x3f, 0x21, 0xbf, 0x1b, 0x70, 0x67, 0x8c, 0x9c, 0x6e, 0xe7, 0x8f, 0x80, 0xb8, 0x0a, 0x06, 0x83,
+	0xe6, 0x33, 0xdb, 0xb8, 0x3b, 0xb5, 0x6e, 0x35, 0x3e, 0x28, 0x68, 0x9f, 0xab, 0x50, 0x17, 0x67,
+	0xf7, 0xc2, 0xdf, 0xf1, 0xe7, 0xf9, 0xe9, 0x14, 0x67, 0x07, 0x2a, 0x3a, 0x8a, 0xee, 0x43, 0x45,
+	0xe8, 0x88, 0xb3, 0x37, 0xc6, 0x9e, 0xbd, 0x29, 0xc2, 0xdb, 0xa1, 0xf8, 0x64, 0x70, 0x18, 0x66,
+	0x84, 0x29, 0x6b, 0xaa, 0xfa, 0xfd, 0xa5, 0xfb, 0x15, 0xdc, 0x1a, 0x9e, 0x51, 0x8b, 0x63, 0xfe,
+	0x0e, 0x4d, 0xf9, 0xb5, 0x0a, 0xb7, 0xcf, 0x88, 0xe9, 0x96, 0x7c, 0x06, 0x65, 0x26, 0x00, 0xdd,
+	0x85, 0xf5, 0xb1, 0x33, 0x32, 0x42, 0xa9, 0xab, 0x95, 0xa2, 0x39, 0xff, 0x1a, 0x60, 0x3e, 0x23,
+	0x3c, 0x8b, 0x3a, 0xc2, 0x6b, 0x39, 0xe5, 0x38, 0x0e, 0x3a, 0x34, 0x4f, 0xb8, 0x76, 0x1c, 0x90,
+	0xd0, 0xa6, 0x40, 0xd0, 0x2a, 0xcc, 0xb0, 0xbc, 0xd3, 0x21, 0x8c, 0xe9, 0x14, 0x65, 0xc7, 0x35,
+	0x0d, 0xaa, 0xa4, 0x05, 0x28, 0xe3, 0x38, 0x3d, 0xc0, 0xd2, 0x8b, 0x0d, 0x5f, 0x2d, 0x84, 0x8d,
+	0xb5, 0x09, 0xc7, 0xd2, 0x87, 0x0d, 0x5f, 0xfe, 0x16, 0x72, 0x79, 0x72, 0x98, 0xd0, 0xe3, 0x24,
+	0x50, 0x8c, 0xb2, 0x0c, 0xd6, 0x34, 0xe8, 0x49, 0xe2, 0x3d, 0xe8, 0xaf, 0x03, 0x29, 0x60, 0xca,
+	0x1c, 0x4b, 0x63, 0x4d, 0xa1, 0x33, 0x18, 0xe1, 0x4a, 0x71, 0x84, 0x0b, 0xea, 0x2a, 0x3a, 0x3d,
+	0xa2, 0x2e, 0x87, 0xd7, 0xf9, 0xd3, 0x84, 0xb2, 0x6c, 0xc7, 0x99, 0x6f, 0xde, 0xb8, 0xf4, 0x9b,
+	0x47, 0x0f, 0x61, 0x7e, 0x48, 0x19, 0x1d, 0x83, 0x9b, 0x83, 0x80, 0xa7, 0x70, 0xe4, 0x81, 0x99,
+	0xa7, 0xe2, 0x06, 0x96, 0x7d, 0xb1, 0x1a, 0x0f, 0x26, 0x38, 0x28, 0x75, 0x2e, 0xbe, 0x26, 0xa2,
+	0xcf, 0xfb, 0x76, 0x50, 0xba, 0xaa, 0x82, 0xe2, 0x89, 0x5e, 0x2a, 0x67, 0xd1, 0x1d, 0x51, 0xfd,
+	0xb6, 0x14, 0x26, 0x1b, 0x82, 0xb6, 0x61, 0x2e, 0x8c, 0xd8, 0x9b, 0x1c, 0xc7, 0xd1, 0x7e, 0x44,
+	0xc2, 0x00, 0x73, 0xdb, 0xbc, 0xd4, 0x8d, 0x4b, 0xd2, 0x89, 0x67, 0x8b, 0x44, 0x8f, 0xa3, 0x4d,
+	0xa8, 0x29, 0xaf, 0x08, 0x95, 0x4e, 0x65, 0x42, 0x1d, 0x6b, 0xc0, 0xf2, 0x38, 0xf2, 0x61, 0x81,
+	0xee, 0xef, 0xab, 0x3d, 0x17, 0xc5, 0xa6, 0x27, 0x14, 0x43, 0x9a, 0xdd, 0x2a, 0x68, 0x7e, 0x0f,
+	0xb7, 0xfb, 0x9a, 0x79, 0x12, 0x92, 0x2c, 0xc8, 0x48, 0x2f, 0x22, 0xc7, 0x42, 0xb6, 0x3a, 0xa1,
+	0x6c, 0x7f, 0x53, 0x2f, 0x04, 0xdf, 0x97, 0x74, 0x8f, 0xa3, 0x97, 0x60, 0x8f, 0x34, 0x8f, 0xb6,
+	0x19, 0xc9, 0x7a, 0x6a, 0xc3, 0x30, 0xa1, 0xf2, 0xad, 0xa2, 0xc2, 0x73, 0x2d, 0xe0, 0x71, 0xb4,
+	0x0c, 0xd0, 0x25, 0x09, 0xc9, 0xe4, 0x21, 0xdb, 0x96, 0xfa, 0x36, 0x87, 0x08, 0xda, 0x04, 0xc8,
+	0xd3, 0x10, 0x73, 0x55, 0xad, 0x76, 0x85, 0x1b, 0xb4, 0xaa, 0x79, 0x1e, 0x47, 0x1e, 0x54, 0x5f,
+	0xd3, 0x28, 0x51, 0x1a, 0x33, 0x57, 0xd0, 0x98, 0x56, 0x34, 0x8f, 0xbb, 0x1e, 0xcc, 0x7c, 0x89,
+	0xb3, 0x84, 0x84, 0xd7, 0xb7, 0xbb, 0xf7, 0x61, 0xb6, 0x2f, 0xa1, 0x4d, 0x6e, 0x01, 0xca, 0xd2,
+	0x86, 0xb4, 0x27, 0xa9, 0x45, 0xe3, 0x5b, 0xa8, 0xb4, 0x38, 0xcd, 0x70, 0x97, 0xa0, 0xc7, 0x50,
+	0x1d, 0xbc, 0x2c, 0xd1, 0x7b, 0x85, 0x0a, 0xa7, 0x9f, 0xad, 0xce, 0xe2, 0xf8, 0xa0, 0x2a, 0xd4,
+	0x48, 0xa0, 0x3a, 0x78, 0x8e, 0x21, 0x0c, 0xb5, 0xe2, 0x93, 0x0c, 0xdd, 0x2f, 0x50, 0x2f, 0x7a,
+	0x06, 0x3a, 0xeb, 0x97, 0x27, 0xea, 0x7a, 0xbf, 0x4c, 0x41, 0x49, 0x58, 0x0b, 0xfa, 0x02, 0x2a,
+	0xfa, 0x39, 0x86, 0xee, 0x14, 0xd8, 0xa3, 0xcf, 0x3c, 0xc7, 0x19, 0x17, 0xd2, 0x3d, 0xda, 0x01,
+	0xab, 0xf0, 0xb6, 0x42, 0x4b, 0x85, 0xd4, 0xb3, 0x6f, 0x37, 0x67, 0xf9, 0xbc, 0xb0, 0x56, 0xdb,
+	0x06, 0x18, 0x7a, 0x0a, 0x5a, 0x3c, 0xe7, 0xe5, 0xa1, 0xb4, 0x96, 0x2e, 0x7c, 0x97, 0xa0, 0x57,
+	0x30, 0x7f, 0xe6, 0x3e, 0x46, 0xab, 0x17, 0xdf, 0xd6, 0x4a, 0x78, 0x6d, 0x92, 0x2b, 0x1d, 0xfd,
+	0x00, 0x73, 0xa7, 0xec, 0x0f, 0xdd, 0xbb, 0xc8, 0x1a, 0x95, 0xb6, 0x7b, 0xb9, 0x7b, 0x36, 0x9e,
+	0x80, 0xb9, 0x8b, 0x4f, 0x68, 0xce, 0xd1, 0xa7, 0x60, 0xaa, 0x91, 0x44, 0xc5, 0xf1, 0x1d, 0x19,
+	0x74, 0xe7, 0xce, 0x98, 0x88, 0x12, 0x6a, 0xae, 0xbd, 0x74, 0x19, 0xa7, 0xd9, 0xeb, 0x7a, 0x44,
+	0x37, 0xe4, 0x8f, 0x8d, 0x34, 0x8b, 0x7a, 0x98, 0x93, 0x8d, 0x01, 0x25, 0x6d, 0xb7, 0x4d, 0xf9,
+	0x89, 0x3d, 0xfa, 0x6f, 0x00, 0x83, 0xb3, 0x9d, 0xb7, 0xe1, 0x0d, 0x00, 0x00,
 }
 
 // --- DRPC BEGIN ---
@@ -994,6 +1319,7 @@ type DRPCNodeClient interface {
 	LastContact(ctx context.Context, in *LastContactRequest) (*LastContactResponse, error)
 	Reputation(ctx context.Context, in *ReputationRequest) (*ReputationResponse, error)
 	TrustedSatellites(ctx context.Context, in *TrustedSatellitesRequest) (*TrustedSatellitesResponse, error)
+	ReputationStats(ctx context.Context, in *ReputationStatsRequest) (*ReputationStatsResponse, error)
 }
 
 type drpcNodeClient struct {
@@ -1042,16 +1368,26 @@ func (c *drpcNodeClient) TrustedSatellites(ctx context.Context, in *TrustedSatel
 	return out, nil
 }
 
+func (c *drpcNodeClient) ReputationStats(ctx context.Context, in *ReputationStatsRequest) (*ReputationStatsResponse, error) {
+	out := new(ReputationStatsResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Node/ReputationStats", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 type DRPCNodeServer interface {
 	Version(context.Context, *VersionRequest) (*VersionResponse, error)
 	LastContact(context.Context, *LastContactRequest) (*LastContactResponse, error)
 	Reputation(context.Context, *ReputationRequest) (*ReputationResponse, error)
 	TrustedSatellites(context.Context, *TrustedSatellitesRequest) (*TrustedSatellitesResponse, error)
+	ReputationStats(context.Context, *ReputationStatsRequest) (*ReputationStatsResponse, error)
 }
 
 type DRPCNodeDescription struct{}
 
-func (DRPCNodeDescription) NumMethods() int { return 4 }
+func (DRPCNodeDescription) NumMethods() int { return 5 }
 
 func (DRPCNodeDescription) Method(n int) (string, drpc.Receiver, interface{}, bool) {
 	switch n {
@@ -1091,6 +1427,15 @@ func (DRPCNodeDescription) Method(n int) (string, drpc.Receiver, interface{}, bo
 						in1.(*TrustedSatellitesRequest),
 					)
 			}, DRPCNodeServer.TrustedSatellites, true
+	case 4:
+		return "/multinode.Node/ReputationStats",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCNodeServer).
+					ReputationStats(
+						ctx,
+						in1.(*ReputationStatsRequest),
+					)
+			}, DRPCNodeServer.ReputationStats, true
 	default:
 		return "", nil, nil, false
 	}
@@ -1164,6 +1509,22 @@ func (x *drpcNodeTrustedSatellitesStream) SendAndClose(m *TrustedSatellitesRespo
 	return x.CloseSend()
 }
 
+type DRPCNode_ReputationStatsStream interface {
+	drpc.Stream
+	SendAndClose(*ReputationStatsResponse) error
+}
+
+type drpcNodeReputationStatsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcNodeReputationStatsStream) SendAndClose(m *ReputationStatsResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
 type DRPCPayoutClient interface {
 	DRPCConn() drpc.Conn
 
@@ -36,6 +36,7 @@ import (
 	"storj.io/storj/storagenode/pieces"
 	"storj.io/storj/storagenode/piecestore"
 	"storj.io/storj/storagenode/preflight"
+	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/retain"
 	"storj.io/storj/storagenode/storagenodedb"
 	"storj.io/storj/storagenode/trust"
@@ -187,6 +188,10 @@ func (planet *Planet) newStorageNode(ctx context.Context, prefix string, index,
 		Bandwidth: bandwidth.Config{
 			Interval: defaultInterval,
 		},
+		Reputation: reputation.Config{
+			MetricsInterval:     defaultInterval,
+			ScoreHistoryEpsilon: reputation.OnlineScoreHistoryEpsilon,
+		},
 		Contact: contact.Config{
 			Interval: defaultInterval,
 		},
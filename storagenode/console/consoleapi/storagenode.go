@@ -73,6 +73,26 @@ func (dashboard *StorageNode) Satellites(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// SatelliteStatuses handles API requests for classified reputation of all satellites.
+func (dashboard *StorageNode) SatelliteStatuses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	data, err := dashboard.service.GetSatelliteStatuses(ctx)
+	if err != nil {
+		dashboard.serveJSONError(w, http.StatusInternalServerError, ErrStorageNodeAPI.Wrap(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		dashboard.log.Error("failed to encode json response", zap.Error(ErrStorageNodeAPI.Wrap(err)))
+		return
+	}
+}
+
 // Satellite handles satellite API requests.
 func (dashboard *StorageNode) Satellite(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
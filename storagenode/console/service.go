@@ -5,7 +5,9 @@ package console
 
 import (
 	"context"
+	"errors"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -44,6 +46,7 @@ type Service struct {
 	usageCache     *pieces.BlobsUsageCache
 	bandwidthDB    bandwidth.DB
 	reputationDB   reputation.DB
+	classifier     *reputation.Classifier
 	storageUsageDB storageusage.DB
 	pricingDB      pricing.DB
 	satelliteDB    satellites.DB
@@ -64,7 +67,7 @@ type Service struct {
 // NewService returns new instance of Service.
 func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Store, version *checker.Service,
 	allocatedDiskSpace memory.Size, walletAddress string, versionInfo version.Info, trust *trust.Pool,
-	reputationDB reputation.DB, storageUsageDB storageusage.DB, pricingDB pricing.DB, satelliteDB satellites.DB,
+	reputationDB reputation.DB, classifier *reputation.Classifier, storageUsageDB storageusage.DB, pricingDB pricing.DB, satelliteDB satellites.DB,
 	pingStats *contact.PingStats, contact *contact.Service, estimation *estimatedpayouts.Service, usageCache *pieces.BlobsUsageCache) (*Service, error) {
 	if log == nil {
 		return nil, errs.New("log can't be nil")
@@ -98,12 +101,17 @@ func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Stor
 		return nil, errs.New("estimation service can't be nil")
 	}
 
+	if classifier == nil {
+		return nil, errs.New("classifier can't be nil")
+	}
+
 	return &Service{
 		log:                log,
 		trust:              trust,
 		usageCache:         usageCache,
 		bandwidthDB:        bandwidth,
 		reputationDB:       reputationDB,
+		classifier:         classifier,
 		storageUsageDB:     storageUsageDB,
 		pricingDB:          pricingDB,
 		satelliteDB:        satelliteDB,
@@ -121,11 +129,16 @@ func NewService(log *zap.Logger, bandwidth bandwidth.DB, pieceStore *pieces.Stor
 
 // SatelliteInfo encapsulates satellite ID and disqualification.
 type SatelliteInfo struct {
-	ID                 storj.NodeID `json:"id"`
-	URL                string       `json:"url"`
-	Disqualified       *time.Time   `json:"disqualified"`
-	Suspended          *time.Time   `json:"suspended"`
-	CurrentStorageUsed int64        `json:"currentStorageUsed"`
+	ID                     storj.NodeID                      `json:"id"`
+	URL                    string                            `json:"url"`
+	Disqualified           *time.Time                        `json:"disqualified"`
+	DisqualificationReason reputation.DisqualificationReason `json:"disqualificationReason"`
+	Suspended              *time.Time                        `json:"suspended"`
+	LastContact            *time.Time                        `json:"lastContact"`
+	LastAudit              *time.Time                        `json:"lastAudit"`
+	Muted                  bool                              `json:"muted"`
+	Generation             int64                             `json:"generation"`
+	CurrentStorageUsed     int64                             `json:"currentStorageUsed"`
 }
 
 // Dashboard encapsulates dashboard stale data.
@@ -181,11 +194,16 @@ func (s *Service) GetDashboardData(ctx context.Context) (_ *Dashboard, err error
 
 		data.Satellites = append(data.Satellites,
 			SatelliteInfo{
-				ID:                 rep.SatelliteID,
-				Disqualified:       rep.DisqualifiedAt,
-				Suspended:          rep.SuspendedAt,
-				URL:                url.Address,
-				CurrentStorageUsed: currentStorageUsed,
+				ID:                     rep.SatelliteID,
+				Disqualified:           rep.DisqualifiedAt,
+				DisqualificationReason: rep.DisqualificationReason,
+				Suspended:              rep.SuspendedAt,
+				LastContact:            rep.LastContactAt,
+				LastAudit:              rep.LastAuditAt,
+				Muted:                  rep.Muted,
+				Generation:             rep.Generation,
+				URL:                    url.Address,
+				CurrentStorageUsed:     currentStorageUsed,
 			},
 		)
 	}
@@ -245,6 +263,7 @@ type Satellite struct {
 	AuditHistory       reputation.AuditHistory `json:"auditHistory"`
 	PriceModel         PriceModel              `json:"priceModel"`
 	NodeJoinedAt       time.Time               `json:"nodeJoinedAt"`
+	Generation         int64                   `json:"generation"`
 }
 
 // GetSatelliteData returns satellite related data.
@@ -289,7 +308,10 @@ func (s *Service) GetSatelliteData(ctx context.Context, satelliteID storj.NodeID
 
 	rep, err := s.reputationDB.Get(ctx, satelliteID)
 	if err != nil {
-		return nil, SNOServiceErr.Wrap(err)
+		if !errors.Is(err, reputation.ErrNoStats) {
+			return nil, SNOServiceErr.Wrap(err)
+		}
+		rep = &reputation.Stats{SatelliteID: satelliteID}
 	}
 
 	pricingModel, err := s.pricingDB.Get(ctx, satelliteID)
@@ -320,14 +342,15 @@ func (s *Service) GetSatelliteData(ctx context.Context, satelliteID storj.NodeID
 		EgressSummary:      egressSummary.Total(),
 		IngressSummary:     ingressSummary.Total(),
 		Audits: Audits{
-			AuditScore:      rep.Audit.Score,
-			SuspensionScore: rep.Audit.UnknownScore,
+			AuditScore:      rep.Audit.ComputedScore(),
+			SuspensionScore: rep.Audit.Normalized().UnknownScore,
 			OnlineScore:     rep.OnlineScore,
 			SatelliteName:   url.Address,
 		},
 		AuditHistory: reputation.GetAuditHistoryFromPB(rep.AuditHistory),
 		PriceModel:   satellitePricing,
 		NodeJoinedAt: rep.JoinedAt,
+		Generation:   rep.Generation,
 	}, nil
 }
 
@@ -351,6 +374,42 @@ type Audits struct {
 	SatelliteName   string  `json:"satelliteName"`
 }
 
+// SatelliteStatus is the classified reputation of the node on a satellite.
+type SatelliteStatus struct {
+	SatelliteID   storj.NodeID `json:"id"`
+	Audit         string       `json:"audit"`
+	Online        string       `json:"online"`
+	Overall       string       `json:"overall"`
+	NotEnoughData bool         `json:"notEnoughData"`
+}
+
+// GetSatelliteStatuses returns classified reputation of all satellites ordered by satellite ID,
+// it's a lightweight alternative to full satellite data. Statuses are classified with hysteresis,
+// so a worse status is lifted only once the score rose enough.
+func (s *Service) GetSatelliteStatuses(ctx context.Context) (_ []SatelliteStatus, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	reports, err := s.classifier.Statuses(ctx, s.reputationDB)
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+
+	statuses := make([]SatelliteStatus, 0, len(reports))
+	for satelliteID, report := range reports {
+		statuses = append(statuses, SatelliteStatus{
+			SatelliteID:   satelliteID,
+			Audit:         report.Audit.String(),
+			Online:        report.Online.String(),
+			Overall:       report.Overall.String(),
+			NotEnoughData: report.NotEnoughData,
+		})
+	}
+	sort.Slice(statuses, func(i, k int) bool {
+		return statuses[i].SatelliteID.Less(statuses[k].SatelliteID)
+	})
+	return statuses, nil
+}
+
 // GetAllSatellitesData returns bandwidth and storage daily usage consolidate
 // among all satellites from the node's trust pool.
 func (s *Service) GetAllSatellitesData(ctx context.Context) (_ *Satellites, err error) {
@@ -392,11 +451,13 @@ func (s *Service) GetAllSatellitesData(ctx context.Context) (_ *Satellites, err
 	satellitesIDs := s.trust.GetSatellites(ctx)
 	joinedAt := time.Now().UTC()
 
+	reputations, err := s.reputationDB.GetBySatellites(ctx, satellitesIDs)
+	if err != nil {
+		return nil, SNOServiceErr.Wrap(err)
+	}
+
 	for i := 0; i < len(satellitesIDs); i++ {
-		stats, err := s.reputationDB.Get(ctx, satellitesIDs[i])
-		if err != nil {
-			return nil, SNOServiceErr.Wrap(err)
-		}
+		stats := reputations[satellitesIDs[i]]
 
 		url, err := s.trust.GetNodeURL(ctx, satellitesIDs[i])
 		if err != nil {
@@ -406,8 +467,8 @@ func (s *Service) GetAllSatellitesData(ctx context.Context) (_ *Satellites, err
 		}
 
 		audits = append(audits, Audits{
-			AuditScore:      stats.Audit.Score,
-			SuspensionScore: stats.Audit.UnknownScore,
+			AuditScore:      stats.Audit.ComputedScore(),
+			SuspensionScore: stats.Audit.Normalized().UnknownScore,
 			OnlineScore:     stats.OnlineScore,
 			SatelliteName:   url.Address,
 		})
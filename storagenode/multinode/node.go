@@ -5,6 +5,7 @@ package multinode
 
 import (
 	"context"
+	"errors"
 
 	"go.uber.org/zap"
 
@@ -79,7 +80,10 @@ func (node *NodeEndpoint) Reputation(ctx context.Context, req *multinodepb.Reput
 
 	rep, err := node.reputation.Get(ctx, req.SatelliteId)
 	if err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+		if !errors.Is(err, reputation.ErrNoStats) {
+			return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+		}
+		rep = &reputation.Stats{SatelliteID: req.SatelliteId}
 	}
 
 	return &multinodepb.ReputationResponse{
@@ -87,12 +91,33 @@ func (node *NodeEndpoint) Reputation(ctx context.Context, req *multinodepb.Reput
 			Score: rep.OnlineScore,
 		},
 		Audit: &multinodepb.ReputationResponse_Audit{
-			Score:           rep.Audit.Score,
-			SuspensionScore: rep.Audit.UnknownScore,
+			Score:           rep.Audit.ComputedScore(),
+			SuspensionScore: rep.Audit.Normalized().UnknownScore,
 		},
 	}, nil
 }
 
+// ReputationStats returns reputation stats of all satellites, audit history is not included.
+func (node *NodeEndpoint) ReputationStats(ctx context.Context, req *multinodepb.ReputationStatsRequest) (_ *multinodepb.ReputationStatsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, node.apiKeys, req.GetHeader()); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	statsList, err := node.reputation.All(ctx)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	response := new(multinodepb.ReputationStatsResponse)
+	for _, stats := range statsList {
+		response.Stats = append(response.Stats, StatsToPB(stats))
+	}
+
+	return response, nil
+}
+
 // TrustedSatellites returns list of trusted satellites node urls.
 func (node *NodeEndpoint) TrustedSatellites(ctx context.Context, req *multinodepb.TrustedSatellitesRequest) (_ *multinodepb.TrustedSatellitesResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -0,0 +1,99 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/drpc"
+	"storj.io/storj/private/multinodepb"
+	"storj.io/storj/storagenode/reputation"
+)
+
+// ReputationStats retrieves reputation stats of all satellites from the node using its api key.
+func ReputationStats(ctx context.Context, conn drpc.Conn, apiKey []byte) (_ []reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	resp, err := multinodepb.NewDRPCNodeClient(conn).ReputationStats(ctx, &multinodepb.ReputationStatsRequest{
+		Header: &multinodepb.RequestHeader{ApiKey: apiKey},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	statsList := make([]reputation.Stats, 0, len(resp.Stats))
+	for _, stats := range resp.Stats {
+		statsList = append(statsList, StatsFromPB(stats))
+	}
+	return statsList, nil
+}
+
+// StatsToPB converts reputation stats into their protobuf representation.
+func StatsToPB(stats reputation.Stats) *multinodepb.ReputationStatsResponse_Stats {
+	return &multinodepb.ReputationStatsResponse_Stats{
+		SatelliteId:            stats.SatelliteID,
+		SatelliteAddress:       stats.SatelliteAddress,
+		Uptime:                 metricToPB(stats.Uptime),
+		Audit:                  metricToPB(stats.Audit),
+		OnlineScore:            stats.OnlineScore,
+		DisqualifiedAt:         stats.DisqualifiedAt,
+		SuspendedAt:            stats.SuspendedAt,
+		OfflineSuspendedAt:     stats.OfflineSuspendedAt,
+		OfflineUnderReviewAt:   stats.OfflineUnderReviewAt,
+		DisqualifiedObservedAt: stats.DisqualifiedObservedAt,
+		Generation:             stats.Generation,
+		UpdatedAt:              stats.UpdatedAt,
+		JoinedAt:               stats.JoinedAt,
+	}
+}
+
+// StatsFromPB converts protobuf representation of reputation stats.
+func StatsFromPB(stats *multinodepb.ReputationStatsResponse_Stats) reputation.Stats {
+	return reputation.Stats{
+		SatelliteID:            stats.SatelliteId,
+		SatelliteAddress:       stats.SatelliteAddress,
+		Uptime:                 metricFromPB(stats.Uptime),
+		Audit:                  metricFromPB(stats.Audit),
+		OnlineScore:            stats.OnlineScore,
+		DisqualifiedAt:         stats.DisqualifiedAt,
+		SuspendedAt:            stats.SuspendedAt,
+		OfflineSuspendedAt:     stats.OfflineSuspendedAt,
+		OfflineUnderReviewAt:   stats.OfflineUnderReviewAt,
+		DisqualifiedObservedAt: stats.DisqualifiedObservedAt,
+		Generation:             stats.Generation,
+		UpdatedAt:              stats.UpdatedAt,
+		JoinedAt:               stats.JoinedAt,
+	}
+}
+
+func metricToPB(metric reputation.Metric) *multinodepb.ReputationStatsResponse_Metric {
+	return &multinodepb.ReputationStatsResponse_Metric{
+		TotalCount:   metric.TotalCount,
+		SuccessCount: metric.SuccessCount,
+		Alpha:        metric.Alpha,
+		Beta:         metric.Beta,
+		UnknownAlpha: metric.UnknownAlpha,
+		UnknownBeta:  metric.UnknownBeta,
+		Score:        metric.Score,
+		UnknownScore: metric.UnknownScore,
+	}
+}
+
+func metricFromPB(metric *multinodepb.ReputationStatsResponse_Metric) reputation.Metric {
+	if metric == nil {
+		return reputation.Metric{}
+	}
+	return reputation.Metric{
+		TotalCount:   metric.TotalCount,
+		SuccessCount: metric.SuccessCount,
+		Alpha:        metric.Alpha,
+		Beta:         metric.Beta,
+		UnknownAlpha: metric.UnknownAlpha,
+		UnknownBeta:  metric.UnknownBeta,
+		Score:        metric.Score,
+		UnknownScore: metric.UnknownScore,
+	}
+}
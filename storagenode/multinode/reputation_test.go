@@ -0,0 +1,44 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/multinodepb"
+	"storj.io/storj/storagenode/multinode"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestStatsPB(t *testing.T) {
+	now := time.Now().UTC()
+	stats := reputation.Stats{
+		SatelliteID:      testrand.NodeID(),
+		SatelliteAddress: "127.0.0.1:7777",
+		Uptime:           reputation.Metric{TotalCount: 2, SuccessCount: 1, Score: 0.5},
+		Audit: reputation.Metric{
+			TotalCount: 10, SuccessCount: 9,
+			Alpha: 9, Beta: 1, UnknownAlpha: 10, UnknownBeta: 0.5,
+			Score: 0.9, UnknownScore: 0.95,
+		},
+		OnlineScore:            0.99,
+		SuspendedAt:            &now,
+		DisqualifiedObservedAt: &now,
+		Generation:             3,
+		UpdatedAt:              now,
+		JoinedAt:               now.Add(-time.Hour),
+	}
+
+	data, err := pb.Marshal(multinode.StatsToPB(stats))
+	require.NoError(t, err)
+
+	var decoded multinodepb.ReputationStatsResponse_Stats
+	require.NoError(t, pb.Unmarshal(data, &decoded))
+	require.Equal(t, stats, multinode.StatsFromPB(&decoded))
+}
@@ -150,19 +150,29 @@ func (cache *Cache) Run(ctx context.Context) error {
 func (cache *Cache) CacheReputationStats(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	return cache.satelliteLoop(ctx, func(satellite storj.NodeID) error {
+	var statsList []reputation.Stats
+	loopErr := cache.satelliteLoop(ctx, func(satellite storj.NodeID) error {
 		stats, err := cache.service.GetReputationStats(ctx, satellite)
 		if err != nil {
 			return err
 		}
 
-		if err = cache.reputationService.Store(ctx, *stats, satellite); err != nil {
-			cache.log.Error("failed to store reputation", zap.Error(err))
-			return err
-		}
-
+		statsList = append(statsList, *stats)
 		return nil
 	})
+
+	if err = cache.reputationService.StoreAll(ctx, statsList); err != nil {
+		cache.log.Error("failed to store reputation", zap.Error(err))
+		return errs.Combine(loopErr, err)
+	}
+
+	// keep a history of stats, so past scores can be looked up when debugging.
+	if err = cache.db.Reputation.Snapshot(ctx); err != nil {
+		cache.log.Error("failed to snapshot reputation", zap.Error(err))
+		return errs.Combine(loopErr, err)
+	}
+
+	return loopErr
 }
 
 // CacheSpaceUsage queries disk space usage from all the satellites
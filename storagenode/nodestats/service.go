@@ -74,11 +74,19 @@ func (s *Service) GetReputationStats(ctx context.Context, satelliteID storj.Node
 		return nil, NodeStatsServiceErr.Wrap(err)
 	}
 
+	// the satellite was already resolved for dialing, so address lookup shouldn't fail.
+	var satelliteAddress string
+	if nodeurl, err := s.trust.GetNodeURL(ctx, satelliteID); err == nil {
+		satelliteAddress = nodeurl.Address
+	}
+
 	uptime := resp.GetUptimeCheck()
 	audit := resp.GetAuditCheck()
+	now := time.Now()
 
 	return &reputation.Stats{
-		SatelliteID: satelliteID,
+		SatelliteID:      satelliteID,
+		SatelliteAddress: satelliteAddress,
 		Uptime: reputation.Metric{
 			TotalCount:   uptime.GetTotalCount(),
 			SuccessCount: uptime.GetSuccessCount(),
@@ -99,8 +107,10 @@ func (s *Service) GetReputationStats(ctx context.Context, satelliteID storj.Node
 		OfflineSuspendedAt:   resp.GetOfflineSuspended(),
 		OfflineUnderReviewAt: resp.GetOfflineUnderReview(),
 		AuditHistory:         resp.GetAuditHistory(),
-		UpdatedAt:            time.Now(),
+		LastContactAt:        &now,
+		UpdatedAt:            now,
 		JoinedAt:             resp.JoinedAt,
+		// Generation, DisqualificationReason and LastAuditAt are left unset, satellites don't report them yet.
 	}, nil
 }
 
@@ -5,6 +5,7 @@ package estimatedpayouts
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/jinzhu/now"
@@ -120,7 +121,10 @@ func (s *Service) estimatedPayout(ctx context.Context, satelliteID storj.NodeID)
 
 	stats, err := s.reputationDB.Get(ctx, satelliteID)
 	if err != nil {
-		return PayoutMonthly{}, PayoutMonthly{}, EstimationServiceErr.Wrap(err)
+		if !errors.Is(err, reputation.ErrNoStats) {
+			return PayoutMonthly{}, PayoutMonthly{}, EstimationServiceErr.Wrap(err)
+		}
+		stats = &reputation.Stats{SatelliteID: satelliteID}
 	}
 
 	currentMonthPayout, err = s.estimationUsagePeriod(ctx, time.Now().UTC(), stats.JoinedAt, priceModel)
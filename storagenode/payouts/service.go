@@ -218,7 +218,10 @@ func (service *Service) AllHeldbackHistory(ctx context.Context) (result []Satell
 
 		stats, err := service.reputationDB.Get(ctx, satellitesIDs[i])
 		if err != nil {
-			return nil, ErrPayoutService.Wrap(err)
+			if !errors.Is(err, reputation.ErrNoStats) {
+				return nil, ErrPayoutService.Wrap(err)
+			}
+			stats = &reputation.Stats{SatelliteID: satellitesIDs[i]}
 		}
 
 		history.JoinedAt = stats.JoinedAt.Round(time.Minute)
@@ -254,7 +257,10 @@ func (service *Service) AllSatellitesPayoutPeriod(ctx context.Context, period st
 
 		stats, err := service.reputationDB.Get(ctx, satelliteIDs[i])
 		if err != nil {
-			return nil, ErrPayoutService.Wrap(err)
+			if !errors.Is(err, reputation.ErrNoStats) {
+				return nil, ErrPayoutService.Wrap(err)
+			}
+			stats = &reputation.Stats{SatelliteID: satelliteIDs[i]}
 		}
 
 		satellite, err := service.satellitesDB.GetSatellite(ctx, satelliteIDs[i])
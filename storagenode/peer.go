@@ -126,6 +126,8 @@ type Config struct {
 	Bandwidth bandwidth.Config
 
 	GracefulExit gracefulexit.Config
+
+	Reputation reputation.Config
 }
 
 // DatabaseConfig returns the storagenodedb.Config that should be used with this Config.
@@ -140,6 +142,12 @@ func (config *Config) DatabaseConfig() storagenodedb.Config {
 		Info2:     filepath.Join(dbdir, "info.db"),
 		Pieces:    config.Storage.Path,
 		Filestore: config.Filestore,
+
+		ReputationQueryTimeout: config.Reputation.QueryTimeout,
+		ReputationStrictDecode: config.Reputation.StrictDecode,
+
+		ReputationScoreHistoryEpsilon: &config.Reputation.ScoreHistoryEpsilon,
+		ReputationMaxAge:              config.Reputation.MaxAge,
 	}
 }
 
@@ -280,7 +288,17 @@ type Peer struct {
 
 	Bandwidth *bandwidth.Service
 
-	Reputation *reputation.Service
+	Reputation struct {
+		DB          reputation.DB
+		Thresholds  reputation.Thresholds
+		Classifier  *reputation.Classifier
+		Service     *reputation.Service
+		Metrics     *reputation.Metrics
+		Prune       *reputation.PruneChore
+		Transitions *reputation.TransitionLogChore
+		Alerts      *reputation.AlertChore
+		Webhook     *reputation.WebhookNotifier
+	}
 
 	Multinode struct {
 		Storage   *multinode.StorageEndpoint
@@ -562,11 +580,18 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			debug.Cycle("Orders Cleanup", peer.Storage2.Orders.Cleanup))
 	}
 
+	// reputation stats are read by multiple services, so they share a cache,
+	// concurrent reads which miss the cache share a query.
+	peer.Reputation.DB = reputation.NewCachedDB(
+		reputation.NewSingleflightDB(peer.DB.Reputation()),
+		config.Reputation.CacheTTL,
+	)
+
 	{ // setup payouts service.
 		service, err := payouts.NewService(
 			peer.Log.Named("payouts:service"),
 			peer.DB.Payout(),
-			peer.DB.Reputation(),
+			peer.Reputation.DB,
 			peer.DB.Satellites(),
 			peer.Storage2.Trust,
 		)
@@ -582,12 +607,84 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 	}
 
 	{ // setup reputation service.
-		peer.Reputation = reputation.NewService(
+		peer.Reputation.Thresholds, err = reputation.LoadThresholdsFile(config.Reputation.ThresholdsFile)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		peer.Reputation.Classifier = reputation.NewClassifier(
+			peer.Reputation.Thresholds,
+			reputation.WithHysteresis(config.Reputation.Hysteresis),
+		)
+
+		peer.Reputation.Service = reputation.NewService(
 			peer.Log.Named("reputation:service"),
-			peer.DB.Reputation(),
+			peer.Reputation.DB,
 			peer.Identity.ID,
 			peer.Notifications.Service,
 		)
+
+		peer.Reputation.Metrics = reputation.NewMetrics(
+			peer.Log.Named("reputation:metrics"),
+			peer.Reputation.DB,
+			config.Reputation,
+		)
+		mon.Chain(peer.Reputation.Metrics)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "reputation:metrics",
+			Run:   peer.Reputation.Metrics.Run,
+			Close: peer.Reputation.Metrics.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Reputation Metrics", peer.Reputation.Metrics.Loop))
+
+		peer.Reputation.Prune = reputation.NewPruneChore(
+			peer.Log.Named("reputation:prune"),
+			peer.Reputation.DB,
+			config.Reputation,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "reputation:prune",
+			Run:   peer.Reputation.Prune.Run,
+			Close: peer.Reputation.Prune.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Reputation Prune", peer.Reputation.Prune.Loop))
+
+		peer.Reputation.Transitions = reputation.NewTransitionLogChore(
+			peer.Log.Named("reputation:transitions"),
+			peer.Reputation.DB,
+			config.Reputation,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "reputation:transitions",
+			Run:   peer.Reputation.Transitions.Run,
+			Close: peer.Reputation.Transitions.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Reputation Transitions", peer.Reputation.Transitions.Loop))
+
+		if config.Reputation.Webhook.URL != "" {
+			peer.Reputation.Webhook = reputation.NewWebhookNotifier(
+				peer.Log.Named("reputation:webhook"),
+				peer.Identity.ID,
+				config.Reputation.Webhook,
+			)
+			peer.Reputation.Alerts = reputation.NewAlertChore(
+				peer.Log.Named("reputation:alerts"),
+				peer.Reputation.DB,
+				config.Reputation,
+				peer.Reputation.Thresholds,
+				peer.Reputation.Webhook.Notify,
+			)
+			peer.Services.Add(lifecycle.Item{
+				Name:  "reputation:alerts",
+				Run:   peer.Reputation.Alerts.Run,
+				Close: peer.Reputation.Alerts.Close,
+			})
+			peer.Debug.Server.Panel.Add(
+				debug.Cycle("Reputation Alerts", peer.Reputation.Alerts.Loop))
+		}
 	}
 
 	{ // setup node stats service
@@ -601,7 +698,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Log.Named("nodestats:cache"),
 			config.Nodestats,
 			nodestats.CacheStorage{
-				Reputation:   peer.DB.Reputation(),
+				Reputation:   peer.Reputation.DB,
 				StorageUsage: peer.DB.StorageUsage(),
 				Payout:       peer.DB.Payout(),
 				Pricing:      peer.DB.Pricing(),
@@ -609,7 +706,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			},
 			peer.NodeStats.Service,
 			peer.Payout.Endpoint,
-			peer.Reputation,
+			peer.Reputation.Service,
 			peer.Storage2.Trust,
 		)
 		peer.Services.Add(lifecycle.Item{
@@ -626,7 +723,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 	{ // setup estimation service
 		peer.Estimation.Service = estimatedpayouts.NewService(
 			peer.DB.Bandwidth(),
-			peer.DB.Reputation(),
+			peer.Reputation.DB,
 			peer.DB.StorageUsage(),
 			peer.DB.Pricing(),
 			peer.DB.Satellites(),
@@ -644,7 +741,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			config.Operator.Wallet,
 			versionInfo,
 			peer.Storage2.Trust,
-			peer.DB.Reputation(),
+			peer.Reputation.DB,
+			peer.Reputation.Classifier,
 			peer.DB.StorageUsage(),
 			peer.DB.Pricing(),
 			peer.DB.Satellites(),
@@ -796,7 +894,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			apiKeys,
 			peer.Version.Service.Info,
 			peer.Contact.PingStats,
-			peer.DB.Reputation(),
+			peer.Reputation.DB,
 			peer.Storage2.Trust)
 
 		if err = multinodepb.DRPCRegisterStorage(peer.Server.DRPC(), peer.Multinode.Storage); err != nil {
@@ -0,0 +1,271 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+)
+
+// AlertKind is the reason an alert was raised.
+type AlertKind int
+
+const (
+	// AlertSeverity is raised when the online score of a satellite enters a worse severity.
+	AlertSeverity AlertKind = iota
+	// AlertRapidDrop is raised when a score fell by more than the configured delta within the window.
+	AlertRapidDrop
+)
+
+// String returns a string representation of the alert kind.
+func (kind AlertKind) String() string {
+	switch kind {
+	case AlertSeverity:
+		return "severity"
+	case AlertRapidDrop:
+		return "rapidDrop"
+	default:
+		return "unknown"
+	}
+}
+
+// Alert is raised when the online score of a satellite enters a worse severity
+// or when the online or audit score of a satellite drops rapidly.
+type Alert struct {
+	Kind        AlertKind
+	SatelliteID storj.NodeID
+	// Previous and Current are severities of the online score, for rapid drops they are
+	// severities of the dropped score at the start and at the end of the drop.
+	Previous RiskLevel
+	Current  RiskLevel
+	Stats    Stats
+	// RapidDrop is only set for AlertRapidDrop.
+	RapidDrop *RapidDrop
+}
+
+// RapidDrop describes a rapid drop of a score.
+type RapidDrop struct {
+	Metric MetricKind
+	Before float64
+	After  float64
+	// Elapsed is the time between the scores.
+	Elapsed time.Duration
+}
+
+// RapidDropConfig defines which score drops raise rapid drop alerts.
+type RapidDropConfig struct {
+	Delta  float64       `help:"score drop within the window which raises a rapid drop alert, 0 disables rapid drop alerts" default:"0.1"`
+	Window time.Duration `help:"time window in which score drops larger than the delta raise a rapid drop alert" default:"1h"`
+}
+
+// rapidDropMetrics are the scores checked for rapid drops.
+var rapidDropMetrics = []MetricKind{MetricOnline, MetricAuditKnown}
+
+// scoreKey identifies a score of a satellite.
+type scoreKey struct {
+	satelliteID storj.NodeID
+	metric      MetricKind
+}
+
+// alertSample is a score seen by the alert chore.
+type alertSample struct {
+	at       time.Time
+	score    float64
+	severity RiskLevel
+}
+
+// AlertHandler is called for every raised alert.
+type AlertHandler func(ctx context.Context, alert Alert) error
+
+// AlertChore periodically classifies online scores and raises an alert when
+// a satellite enters a worse severity than in the previous check. An alert
+// isn't repeated until the severity improves and worsens again.
+//
+// The chore also keeps the online and audit scores seen within the rapid drop
+// window and raises a rapid drop alert when a score fell by more than the delta
+// from the highest score in the window. Later drops are measured from the score
+// which raised the alert, so a single drop is only alerted once.
+//
+// architecture: Chore
+type AlertChore struct {
+	log        *zap.Logger
+	db         DB
+	thresholds Thresholds
+	rapidDrop  RapidDropConfig
+	handler    AlertHandler
+	Loop       *sync2.Cycle
+
+	// severities and samples are only accessed by Check, which isn't run concurrently.
+	severities map[storj.NodeID]RiskLevel
+	samples    map[scoreKey][]alertSample
+}
+
+// NewAlertChore creates a new online score alert chore.
+func NewAlertChore(log *zap.Logger, db DB, config Config, thresholds Thresholds, handler AlertHandler) *AlertChore {
+	return &AlertChore{
+		log:        log,
+		db:         db,
+		thresholds: thresholds,
+		rapidDrop:  config.RapidDrop,
+		handler:    handler,
+		Loop:       sync2.NewCycle(config.AlertInterval),
+		severities: make(map[storj.NodeID]RiskLevel),
+		samples:    make(map[scoreKey][]alertSample),
+	}
+}
+
+// Run starts the background process which checks online scores.
+func (chore *AlertChore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, chore.Check)
+}
+
+// Check classifies online scores of all satellites and calls the handler for
+// satellites which entered a worse severity or whose scores dropped rapidly.
+// Satellites are assumed to start at RiskSafe. Scores are sampled at the time
+// the stats were updated. Muted satellites are tracked, but don't raise alerts.
+// Handler errors are logged and don't stop the chore.
+func (chore *AlertChore) Check(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	statsList, err := chore.db.All(ctx)
+	if err != nil {
+		chore.log.Error("Could not read reputation stats", zap.Error(err))
+		return nil
+	}
+	reports, err := chore.thresholds.classifyAll(ctx, chore.db, statsList)
+	if err != nil {
+		chore.log.Error("Could not classify reputation stats", zap.Error(err))
+		return nil
+	}
+
+	seen := make(map[storj.NodeID]struct{}, len(statsList))
+	for _, stats := range statsList {
+		seen[stats.SatelliteID] = struct{}{}
+
+		report := reports[stats.SatelliteID]
+		previous := chore.severities[stats.SatelliteID]
+		current := report.Online
+		chore.severities[stats.SatelliteID] = current
+
+		var alerts []Alert
+		if current > previous {
+			alerts = append(alerts, Alert{
+				Kind:        AlertSeverity,
+				SatelliteID: stats.SatelliteID,
+				Previous:    previous,
+				Current:     current,
+				Stats:       stats,
+			})
+		}
+		for _, metric := range rapidDropMetrics {
+			if alert, ok := chore.checkRapidDrop(stats, report, metric); ok {
+				alerts = append(alerts, alert)
+			}
+		}
+
+		if stats.Muted {
+			continue
+		}
+		for _, alert := range alerts {
+			chore.raise(ctx, alert)
+		}
+	}
+
+	for satelliteID := range chore.severities {
+		if _, ok := seen[satelliteID]; !ok {
+			delete(chore.severities, satelliteID)
+		}
+	}
+	for key := range chore.samples {
+		if _, ok := seen[key.satelliteID]; !ok {
+			delete(chore.samples, key)
+		}
+	}
+
+	return nil
+}
+
+// checkRapidDrop records the current score of the metric and returns an alert when
+// it's lower than the highest score within the window by more than the delta.
+func (chore *AlertChore) checkRapidDrop(stats Stats, report StatusReport, metric MetricKind) (Alert, bool) {
+	if chore.rapidDrop.Delta <= 0 || chore.rapidDrop.Window <= 0 {
+		return Alert{}, false
+	}
+
+	current := alertSample{
+		at:       stats.UpdatedAt,
+		score:    metric.Score(stats),
+		severity: report.Online,
+	}
+	if metric != MetricOnline {
+		current.severity = report.Audit
+	}
+	if current.at.IsZero() {
+		current.at = time.Now()
+	}
+
+	key := scoreKey{satelliteID: stats.SatelliteID, metric: metric}
+	samples := chore.samples[key]
+	// stats which weren't updated since the last check don't add a sample.
+	if len(samples) > 0 && !current.at.After(samples[len(samples)-1].at) {
+		return Alert{}, false
+	}
+
+	cutoff := current.at.Add(-chore.rapidDrop.Window)
+	retained := samples[:0]
+	highest := -1
+	for _, sample := range samples {
+		if sample.at.Before(cutoff) {
+			continue
+		}
+		retained = append(retained, sample)
+		if highest < 0 || sample.score > retained[highest].score {
+			highest = len(retained) - 1
+		}
+	}
+
+	if highest < 0 || retained[highest].score-current.score <= chore.rapidDrop.Delta {
+		chore.samples[key] = append(retained, current)
+		return Alert{}, false
+	}
+
+	before := retained[highest]
+	chore.samples[key] = []alertSample{current}
+	return Alert{
+		Kind:        AlertRapidDrop,
+		SatelliteID: stats.SatelliteID,
+		Previous:    before.severity,
+		Current:     current.severity,
+		Stats:       stats,
+		RapidDrop: &RapidDrop{
+			Metric:  metric,
+			Before:  before.score,
+			After:   current.score,
+			Elapsed: current.at.Sub(before.at),
+		},
+	}, true
+}
+
+// raise calls the handler with the alert and logs its failure.
+func (chore *AlertChore) raise(ctx context.Context, alert Alert) {
+	if err := chore.handler(ctx, alert); err != nil {
+		chore.log.Error("Reputation alert handler failed",
+			zap.Stringer("Satellite ID", alert.SatelliteID),
+			zap.Stringer("Kind", alert.Kind),
+			zap.Stringer("Severity", alert.Current),
+			zap.Error(err))
+	}
+}
+
+// Close stops the background process.
+func (chore *AlertChore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
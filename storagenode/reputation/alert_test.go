@@ -0,0 +1,192 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestAlertChore(t *testing.T) {
+	ctx := testcontext.New(t)
+	db := reputation.NewMemory()
+
+	var alerts []reputation.Alert
+	handler := func(ctx context.Context, alert reputation.Alert) error {
+		alerts = append(alerts, alert)
+		return errors.New("handler failure")
+	}
+
+	chore := reputation.NewAlertChore(zaptest.NewLogger(t), db, reputation.Config{AlertInterval: time.Hour}, reputation.DefaultThresholds(), handler)
+	defer ctx.Check(chore.Close)
+
+	stats := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 1}
+	check := func(onlineScore float64) {
+		stats.OnlineScore = onlineScore
+		require.NoError(t, db.Store(ctx, stats))
+		require.NoError(t, chore.Check(ctx))
+	}
+
+	check(1)
+	assert.Empty(t, alerts)
+
+	check(0.8)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, reputation.RiskSafe, alerts[0].Previous)
+	assert.Equal(t, reputation.RiskWarning, alerts[0].Current)
+	assert.Equal(t, stats.SatelliteID, alerts[0].SatelliteID)
+
+	// the severity didn't change, so the alert isn't repeated.
+	check(0.85)
+	require.Len(t, alerts, 1)
+
+	check(0.5)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, reputation.RiskWarning, alerts[1].Previous)
+	assert.Equal(t, reputation.RiskCritical, alerts[1].Current)
+
+	// improving resets the severity, so worsening alerts again.
+	check(0.95)
+	require.Len(t, alerts, 2)
+	check(0.8)
+	require.Len(t, alerts, 3)
+	assert.Equal(t, reputation.RiskSafe, alerts[2].Previous)
+
+	// muted satellites don't raise alerts.
+	require.NoError(t, db.Mute(ctx, stats.SatelliteID))
+	check(0.5)
+	require.Len(t, alerts, 3)
+}
+
+func TestAlertChoreRapidDrop(t *testing.T) {
+	ctx := testcontext.New(t)
+	db := reputation.NewMemory()
+
+	var alerts []reputation.Alert
+	handler := func(ctx context.Context, alert reputation.Alert) error {
+		if alert.Kind == reputation.AlertRapidDrop {
+			alerts = append(alerts, alert)
+		}
+		return nil
+	}
+
+	config := reputation.Config{
+		AlertInterval: time.Hour,
+		RapidDrop:     reputation.RapidDropConfig{Delta: 0.1, Window: time.Hour},
+	}
+	chore := reputation.NewAlertChore(zaptest.NewLogger(t), db, config, reputation.DefaultThresholds(), handler)
+	defer ctx.Check(chore.Close)
+
+	start := time.Now().UTC().Add(-24 * time.Hour).Truncate(time.Second)
+	steep := reputation.Stats{SatelliteID: testrand.NodeID(), Audit: reputation.Metric{Alpha: 1, Score: 1, UnknownScore: 1}}
+	gentle := reputation.Stats{SatelliteID: testrand.NodeID(), Audit: reputation.Metric{Alpha: 1, Score: 1, UnknownScore: 1}}
+	check := func(elapsed time.Duration, steepScore, gentleScore float64) {
+		steep.OnlineScore, steep.UpdatedAt = steepScore, start.Add(elapsed)
+		gentle.OnlineScore, gentle.UpdatedAt = gentleScore, start.Add(elapsed)
+		require.NoError(t, db.StoreAll(ctx, []reputation.Stats{steep, gentle}))
+		require.NoError(t, chore.Check(ctx))
+	}
+
+	// the gentle slope drops 0.05 per hour, which stays within the delta.
+	check(0, 1, 1)
+	check(20*time.Minute, 0.98, 0.985)
+	assert.Empty(t, alerts)
+
+	check(40*time.Minute, 0.87, 0.97)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, steep.SatelliteID, alerts[0].SatelliteID)
+	assert.Equal(t, reputation.RiskSafe, alerts[0].Previous)
+	assert.Equal(t, reputation.RiskWarning, alerts[0].Current)
+	require.NotNil(t, alerts[0].RapidDrop)
+	assert.Equal(t, reputation.MetricOnline, alerts[0].RapidDrop.Metric)
+	assert.Equal(t, float64(1), alerts[0].RapidDrop.Before)
+	assert.Equal(t, 0.87, alerts[0].RapidDrop.After)
+	assert.Equal(t, 40*time.Minute, alerts[0].RapidDrop.Elapsed)
+
+	// checks without updated stats don't add samples.
+	require.NoError(t, chore.Check(ctx))
+	require.Len(t, alerts, 1)
+
+	// the drop is alerted once, the next drop is measured from the alerted score.
+	check(60*time.Minute, 0.85, 0.955)
+	check(80*time.Minute, 0.83, 0.94)
+	require.Len(t, alerts, 1)
+	check(100*time.Minute, 0.76, 0.925)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, 0.87, alerts[1].RapidDrop.Before)
+	assert.Equal(t, time.Hour, alerts[1].RapidDrop.Elapsed)
+
+	// the gentle slope dropped by more than the delta in total, but not within the window.
+	for elapsed := 2 * time.Hour; elapsed <= 6*time.Hour; elapsed += 20 * time.Minute {
+		check(elapsed, 0.76, 0.925-0.05*(elapsed-100*time.Minute).Hours())
+	}
+	require.Len(t, alerts, 2)
+	assert.True(t, gentle.OnlineScore < 0.8)
+
+	// audit scores are checked too and muted satellites don't raise alerts.
+	steep.Audit.Alpha, steep.Audit.Beta, steep.Audit.Score = 0.8, 0.2, 0.8
+	check(7*time.Hour, 0.76, gentle.OnlineScore)
+	require.Len(t, alerts, 3)
+	assert.Equal(t, reputation.MetricAuditKnown, alerts[2].RapidDrop.Metric)
+	assert.Equal(t, float64(1), alerts[2].RapidDrop.Before)
+	assert.Equal(t, 0.8, alerts[2].RapidDrop.After)
+
+	require.NoError(t, db.Mute(ctx, steep.SatelliteID))
+	check(7*time.Hour+20*time.Minute, 0.5, gentle.OnlineScore)
+	require.Len(t, alerts, 3)
+}
+
+func TestAlertChoreMinWindows(t *testing.T) {
+	ctx := testcontext.New(t)
+	db := reputation.NewMemory()
+
+	var alerts []reputation.Alert
+	handler := func(ctx context.Context, alert reputation.Alert) error {
+		alerts = append(alerts, alert)
+		return nil
+	}
+
+	thresholds := reputation.DefaultThresholds()
+	thresholds.MinWindows = 1
+	chore := reputation.NewAlertChore(zaptest.NewLogger(t), db, reputation.Config{AlertInterval: time.Hour}, thresholds, handler)
+	defer ctx.Check(chore.Close)
+
+	// All doesn't include the audit history, the chore must still see the windows.
+	now := time.Now()
+	stats := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		OnlineScore: 0.5,
+		AuditHistory: &pb.AuditHistory{
+			Windows: []*pb.AuditWindow{
+				{WindowStart: now.Add(-time.Hour), OnlineCount: 1, TotalCount: 2},
+				{WindowStart: now, OnlineCount: 0, TotalCount: 2},
+			},
+		},
+	}
+	require.NoError(t, db.Store(ctx, stats))
+	require.NoError(t, chore.Check(ctx))
+	require.Len(t, alerts, 1)
+	assert.Equal(t, reputation.RiskCritical, alerts[0].Current)
+
+	// a single window isn't enough data.
+	other := reputation.Stats{
+		SatelliteID:  testrand.NodeID(),
+		OnlineScore:  0.5,
+		AuditHistory: &pb.AuditHistory{Windows: []*pb.AuditWindow{{WindowStart: now, TotalCount: 2}}},
+	}
+	require.NoError(t, db.Store(ctx, other))
+	require.NoError(t, chore.Check(ctx))
+	require.Len(t, alerts, 1)
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+)
+
+// ErrInvalidAuditHistory is returned when audit history windows are inconsistent.
+var ErrInvalidAuditHistory = errs.Class("invalid audit history")
+
+// AuditHistoryClockSkew is how far in the future a window may start,
+// to tolerate clocks of the node and the satellite being out of sync.
+const AuditHistoryClockSkew = time.Hour
+
+// DefaultAuditWindowSize is the length of an audit history window used by satellites.
+const DefaultAuditWindowSize = 12 * time.Hour
+
+// Gap is an interval without audit history windows, the node was likely unreachable during it.
+type Gap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// AggregateOnlineFraction returns the fraction of online audits across all windows.
+// Like satellites, it considers the node online when there were no audits at all.
+func AggregateOnlineFraction(h *pb.AuditHistory) float64 {
+	if h == nil {
+		return 1
+	}
+
+	var online, total int64
+	for _, window := range h.Windows {
+		online += int64(window.OnlineCount)
+		total += int64(window.TotalCount)
+	}
+	if total == 0 {
+		return 1
+	}
+
+	return float64(online) / float64(total)
+}
+
+// WindowsInRange returns windows which start in the [from, to) range.
+func WindowsInRange(h *pb.AuditHistory, from, to time.Time) []*pb.AuditWindow {
+	if h == nil {
+		return nil
+	}
+
+	var windows []*pb.AuditWindow
+	for _, window := range h.Windows {
+		if window.WindowStart.Before(from) || !window.WindowStart.Before(to) {
+			continue
+		}
+		windows = append(windows, window)
+	}
+
+	return windows
+}
+
+// DetectWindowGaps returns the intervals missing between consecutive windows which start more
+// than windowSize apart. A gap starts when the earlier window ends and ends when the later window
+// starts. DefaultAuditWindowSize is used when windowSize is zero.
+func DetectWindowGaps(h *pb.AuditHistory, windowSize time.Duration) []Gap {
+	if h == nil {
+		return nil
+	}
+	if windowSize == 0 {
+		windowSize = DefaultAuditWindowSize
+	}
+
+	var gaps []Gap
+	for i := 1; i < len(h.Windows); i++ {
+		previous, window := h.Windows[i-1], h.Windows[i]
+		if previous == nil || window == nil {
+			continue
+		}
+
+		end := previous.WindowStart.Add(windowSize)
+		if window.WindowStart.After(end) {
+			gaps = append(gaps, Gap{Start: end, End: window.WindowStart})
+		}
+	}
+
+	return gaps
+}
+
+// ValidateAuditHistory checks that windows are in chronological order without
+// duplicates, that online counts don't exceed total counts and that no window
+// starts further in the future than AuditHistoryClockSkew.
+func ValidateAuditHistory(h *pb.AuditHistory) error {
+	if h == nil {
+		return nil
+	}
+
+	latest := time.Now().Add(AuditHistoryClockSkew)
+	for i, window := range h.Windows {
+		if window == nil {
+			return ErrInvalidAuditHistory.New("window %d is missing", i)
+		}
+		if i > 0 && !window.WindowStart.After(h.Windows[i-1].WindowStart) {
+			return ErrInvalidAuditHistory.New("window %d starts at %v, not after the previous window", i, window.WindowStart)
+		}
+		if window.OnlineCount > window.TotalCount {
+			return ErrInvalidAuditHistory.New("window %d has online count %d larger than total count %d", i, window.OnlineCount, window.TotalCount)
+		}
+		if window.WindowStart.After(latest) {
+			return ErrInvalidAuditHistory.New("window %d starts in the future at %v", i, window.WindowStart)
+		}
+	}
+
+	return nil
+}
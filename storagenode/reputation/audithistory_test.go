@@ -0,0 +1,123 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestAggregateOnlineFraction(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, float64(1), reputation.AggregateOnlineFraction(nil))
+	assert.Equal(t, float64(1), reputation.AggregateOnlineFraction(&pb.AuditHistory{}))
+	assert.Equal(t, float64(1), reputation.AggregateOnlineFraction(&pb.AuditHistory{
+		Windows: []*pb.AuditWindow{{WindowStart: now}},
+	}))
+
+	history := &pb.AuditHistory{
+		Windows: []*pb.AuditWindow{
+			{WindowStart: now, OnlineCount: 1, TotalCount: 2},
+			{WindowStart: now.Add(time.Hour), OnlineCount: 0, TotalCount: 0},
+			{WindowStart: now.Add(2 * time.Hour), OnlineCount: 8, TotalCount: 8},
+		},
+	}
+	assert.Equal(t, 0.9, reputation.AggregateOnlineFraction(history))
+}
+
+func TestWindowsInRange(t *testing.T) {
+	now := time.Now()
+
+	require.Empty(t, reputation.WindowsInRange(nil, now, now.Add(time.Hour)))
+
+	history := &pb.AuditHistory{
+		Windows: []*pb.AuditWindow{
+			{WindowStart: now.Add(-time.Hour), TotalCount: 1},
+			{WindowStart: now, TotalCount: 2},
+			{WindowStart: now.Add(time.Hour), TotalCount: 3},
+			{WindowStart: now.Add(2 * time.Hour), TotalCount: 4},
+		},
+	}
+
+	windows := reputation.WindowsInRange(history, now, now.Add(2*time.Hour))
+	require.Len(t, windows, 2)
+	assert.EqualValues(t, 2, windows[0].TotalCount)
+	assert.EqualValues(t, 3, windows[1].TotalCount)
+
+	require.Empty(t, reputation.WindowsInRange(history, now.Add(3*time.Hour), now.Add(4*time.Hour)))
+}
+
+func TestDetectWindowGaps(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := func(offset time.Duration) *pb.AuditWindow {
+		return &pb.AuditWindow{WindowStart: start.Add(offset)}
+	}
+
+	assert.Empty(t, reputation.DetectWindowGaps(nil, 0))
+	assert.Empty(t, reputation.DetectWindowGaps(&pb.AuditHistory{}, 0))
+	assert.Empty(t, reputation.DetectWindowGaps(&pb.AuditHistory{Windows: []*pb.AuditWindow{window(0)}}, 0))
+
+	history := &pb.AuditHistory{
+		Windows: []*pb.AuditWindow{
+			window(0),
+			window(12 * time.Hour),
+			// one window is missing.
+			window(36 * time.Hour),
+			// two windows are missing.
+			window(72 * time.Hour),
+		},
+	}
+
+	// the standard window size is used by default.
+	assert.Equal(t, []reputation.Gap{
+		{Start: start.Add(24 * time.Hour), End: start.Add(36 * time.Hour)},
+		{Start: start.Add(48 * time.Hour), End: start.Add(72 * time.Hour)},
+	}, reputation.DetectWindowGaps(history, 0))
+
+	assert.Equal(t, []reputation.Gap{
+		{Start: start.Add(60 * time.Hour), End: start.Add(72 * time.Hour)},
+	}, reputation.DetectWindowGaps(history, 24*time.Hour))
+}
+
+func TestValidateAuditHistory(t *testing.T) {
+	now := time.Now()
+
+	require.NoError(t, reputation.ValidateAuditHistory(nil))
+	require.NoError(t, reputation.ValidateAuditHistory(&pb.AuditHistory{}))
+	require.NoError(t, reputation.ValidateAuditHistory(&pb.AuditHistory{
+		Windows: []*pb.AuditWindow{
+			{WindowStart: now.Add(-time.Hour), OnlineCount: 1, TotalCount: 2},
+			{WindowStart: now, OnlineCount: 2, TotalCount: 2},
+			{WindowStart: now.Add(time.Minute)},
+		},
+	}))
+
+	for name, windows := range map[string][]*pb.AuditWindow{
+		"duplicated": {
+			{WindowStart: now, OnlineCount: 1, TotalCount: 1},
+			{WindowStart: now, OnlineCount: 1, TotalCount: 1},
+		},
+		"reversed": {
+			{WindowStart: now, OnlineCount: 1, TotalCount: 1},
+			{WindowStart: now.Add(-time.Hour), OnlineCount: 1, TotalCount: 1},
+		},
+		"online above total": {
+			{WindowStart: now, OnlineCount: 2, TotalCount: 1},
+		},
+		"future": {
+			{WindowStart: now.Add(reputation.AuditHistoryClockSkew + time.Hour)},
+		},
+	} {
+		err := reputation.ValidateAuditHistory(&pb.AuditHistory{Windows: windows})
+		require.Error(t, err, name)
+		require.True(t, reputation.ErrInvalidAuditHistory.Has(err), name)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+// AuditStatus describes which penalty the audit metric is approaching.
+type AuditStatus int
+
+const (
+	// AuditHealthy indicates that both audit scores are fine.
+	AuditHealthy AuditStatus = iota
+	// AuditUnknownSuspensionRisk indicates that the unknown audit score is low,
+	// unknown audit errors lead to suspension.
+	AuditUnknownSuspensionRisk
+	// AuditDisqualificationRisk indicates that the audit score is low,
+	// failed audits lead to disqualification.
+	AuditDisqualificationRisk
+)
+
+// String returns a string representation of the audit status.
+func (status AuditStatus) String() string {
+	switch status {
+	case AuditHealthy:
+		return "healthy"
+	case AuditUnknownSuspensionRisk:
+		return "unknown suspension risk"
+	case AuditDisqualificationRisk:
+		return "disqualification risk"
+	default:
+		return "unknown"
+	}
+}
+
+// AuditStatus evaluates ComputedScore and UnknownScore against the AuditWarn of DefaultThresholds.
+// Disqualification is permanent, so it takes precedence over suspension.
+func (m Metric) AuditStatus() AuditStatus {
+	switch {
+	case m.Alpha+m.Beta > 0 && m.ComputedScore() < DefaultThresholds().AuditWarn:
+		return AuditDisqualificationRisk
+	case m.UnknownAlpha+m.UnknownBeta > 0 && m.UnknownScore < DefaultThresholds().AuditWarn:
+		return AuditUnknownSuspensionRisk
+	default:
+		return AuditHealthy
+	}
+}
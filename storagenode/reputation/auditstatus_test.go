@@ -0,0 +1,53 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestMetricAuditStatus(t *testing.T) {
+	warning := reputation.DefaultThresholds().AuditWarn
+
+	for _, tt := range []struct {
+		name   string
+		metric reputation.Metric
+		status reputation.AuditStatus
+	}{
+		{
+			name:   "no audits",
+			metric: reputation.Metric{},
+			status: reputation.AuditHealthy,
+		},
+		{
+			name:   "scores at threshold",
+			metric: reputation.Metric{Alpha: 9, Beta: 1, Score: warning, UnknownAlpha: 1, UnknownScore: warning},
+			status: reputation.AuditHealthy,
+		},
+		{
+			name:   "unknown score below threshold",
+			metric: reputation.Metric{Alpha: 1, Score: 1, UnknownAlpha: 1, UnknownScore: warning - 0.001},
+			status: reputation.AuditUnknownSuspensionRisk,
+		},
+		{
+			name:   "score below threshold",
+			metric: reputation.Metric{Alpha: warning - 0.001, Beta: 1.001 - warning, Score: warning - 0.001, UnknownAlpha: 1, UnknownScore: 1},
+			status: reputation.AuditDisqualificationRisk,
+		},
+		{
+			name:   "both below threshold",
+			metric: reputation.Metric{Alpha: 1, Beta: 1, Score: 0.5, UnknownAlpha: 1, UnknownScore: 0.5},
+			status: reputation.AuditDisqualificationRisk,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.metric.AuditStatus()
+			assert.Equal(t, tt.status, status, status.String())
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// ErrInvalidTimeRange is returned when the end of a time range isn't after its start.
+var ErrInvalidTimeRange = errs.Class("invalid time range")
+
+// StatusTransition records that the node became available or unavailable on a satellite.
+type StatusTransition struct {
+	Timestamp time.Time
+	Available bool
+}
+
+// Available returns whether the node is neither disqualified nor suspended for any reason.
+func (s Stats) Available() bool {
+	return s.DisqualifiedAt == nil && s.SuspendedAt == nil && s.OfflineSuspendedAt == nil
+}
+
+// NextStatusTransition returns the transition which has to be recorded after last, the
+// previously recorded transition or nil, when stats are stored. It returns false when the
+// status didn't change, the node is assumed to be available when nothing was recorded yet.
+//
+// The node became unavailable at the earliest disqualification or suspension time, the
+// satellite doesn't report when suspensions are lifted, so the node became available when
+// stats were updated. Transitions are never recorded before last, one at the same time
+// replaces last.
+func NextStatusTransition(last *StatusTransition, stats Stats) (StatusTransition, bool) {
+	wasAvailable := last == nil || last.Available
+	if stats.Available() == wasAvailable {
+		return StatusTransition{}, false
+	}
+
+	updatedAt := stats.UpdatedAt.UTC()
+	if updatedAt.IsZero() {
+		updatedAt = time.Now().UTC()
+	}
+
+	transition := StatusTransition{Timestamp: updatedAt, Available: stats.Available()}
+	if !transition.Available {
+		for _, at := range []*time.Time{stats.DisqualifiedAt, stats.SuspendedAt, stats.OfflineSuspendedAt} {
+			if at != nil && at.Before(transition.Timestamp) {
+				transition.Timestamp = at.UTC()
+			}
+		}
+	}
+	if last != nil && transition.Timestamp.Before(last.Timestamp) {
+		transition.Timestamp = last.Timestamp
+	}
+	return transition, true
+}
+
+// ComputeAvailability returns the time-weighted fraction of [from, to) the node was
+// available according to transitions ordered by timestamp. The node is assumed to be
+// available before the first transition.
+func ComputeAvailability(transitions []StatusTransition, from, to time.Time) (float64, error) {
+	if !to.After(from) {
+		return 0, ErrInvalidTimeRange.New("%s is not after %s", to, from)
+	}
+
+	available := true
+	since := from
+	var unavailable time.Duration
+	for _, transition := range transitions {
+		if !transition.Timestamp.After(from) {
+			available = transition.Available
+			continue
+		}
+		if !transition.Timestamp.Before(to) {
+			break
+		}
+
+		if !available {
+			unavailable += transition.Timestamp.Sub(since)
+		}
+		available = transition.Available
+		since = transition.Timestamp
+	}
+	if !available {
+		unavailable += to.Sub(since)
+	}
+
+	return 1 - float64(unavailable)/float64(to.Sub(from)), nil
+}
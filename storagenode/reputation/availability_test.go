@@ -0,0 +1,79 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestNextStatusTransition(t *testing.T) {
+	now := time.Now().UTC()
+	suspendedAt := now.Add(-2 * time.Hour)
+	disqualifiedAt := now.Add(-time.Hour)
+
+	// nodes are assumed to be available when nothing was recorded.
+	_, ok := reputation.NextStatusTransition(nil, reputation.Stats{UpdatedAt: now})
+	assert.False(t, ok)
+
+	// the earliest penalty is when the node became unavailable.
+	transition, ok := reputation.NextStatusTransition(nil, reputation.Stats{
+		SuspendedAt:    &suspendedAt,
+		DisqualifiedAt: &disqualifiedAt,
+		UpdatedAt:      now,
+	})
+	require.True(t, ok)
+	assert.Equal(t, reputation.StatusTransition{Timestamp: suspendedAt, Available: false}, transition)
+
+	_, ok = reputation.NextStatusTransition(&transition, reputation.Stats{OfflineSuspendedAt: &now, UpdatedAt: now})
+	assert.False(t, ok)
+
+	// lifted suspensions aren't reported, so it's when stats were updated.
+	available, ok := reputation.NextStatusTransition(&transition, reputation.Stats{UpdatedAt: now})
+	require.True(t, ok)
+	assert.Equal(t, reputation.StatusTransition{Timestamp: now, Available: true}, available)
+
+	// transitions aren't recorded before the last one.
+	again, ok := reputation.NextStatusTransition(&available, reputation.Stats{SuspendedAt: &suspendedAt, UpdatedAt: now})
+	require.True(t, ok)
+	assert.Equal(t, reputation.StatusTransition{Timestamp: now, Available: false}, again)
+}
+
+func TestComputeAvailability(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	transitions := []reputation.StatusTransition{
+		{Timestamp: start.Add(10 * time.Hour), Available: false},
+		{Timestamp: start.Add(20 * time.Hour), Available: true},
+		{Timestamp: start.Add(30 * time.Hour), Available: false},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		from, to time.Duration
+		expected float64
+	}{
+		{"before first transition", -10 * time.Hour, 0, 1},
+		{"whole range", 0, 40 * time.Hour, 0.5},
+		{"unavailable", 12 * time.Hour, 16 * time.Hour, 0},
+		{"partially unavailable", 15 * time.Hour, 25 * time.Hour, 0.5},
+		{"after last transition", 35 * time.Hour, 40 * time.Hour, 0},
+		{"starts at transition", 20 * time.Hour, 30 * time.Hour, 1},
+	} {
+		availability, err := reputation.ComputeAvailability(transitions, start.Add(tt.from), start.Add(tt.to))
+		require.NoError(t, err, tt.name)
+		assert.InDelta(t, tt.expected, availability, 1e-9, tt.name)
+	}
+
+	availability, err := reputation.ComputeAvailability(nil, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, availability)
+
+	_, err = reputation.ComputeAvailability(transitions, start, start)
+	require.True(t, reputation.ErrInvalidTimeRange.Has(err))
+}
@@ -0,0 +1,217 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+)
+
+// ErrBackup is returned when a backup can't be written or read.
+var ErrBackup = errs.Class("reputation backup")
+
+// ErrRestoreNotEmpty is returned by Restore when stats are already stored and ForceRestore isn't given.
+var ErrRestoreNotEmpty = errs.New("reputation stats are already stored")
+
+// BackupVersion is the version of the backup format written by BackupWriter.
+const BackupVersion = 1
+
+// backupMagic starts every backup, so other files aren't mistaken for backups.
+var backupMagic = []byte("SNREPBAK")
+
+// BackupRecord is the stored state of a single satellite in a backup.
+type BackupRecord struct {
+	// Stats.AuditHistory is ignored, the audit history is in AuditHistory.
+	Stats Stats
+	// AuditHistory is the marshaled audit history as it's stored, it's nil without audit history.
+	AuditHistory []byte
+}
+
+// backupRecord is how BackupRecord is encoded, it's separate from Stats,
+// so changes of Stats don't silently change the format.
+type backupRecord struct {
+	SatelliteID      storj.NodeID
+	SatelliteAddress string
+
+	Uptime      Metric
+	Audit       Metric
+	OnlineScore float64
+
+	DisqualifiedAt       *time.Time
+	SuspendedAt          *time.Time
+	OfflineSuspendedAt   *time.Time
+	OfflineUnderReviewAt *time.Time
+	AuditHistory         []byte
+	HistoryUpdatedAt     *time.Time
+
+	DisqualifiedObservedAt *time.Time
+	DisqualificationReason DisqualificationReason
+	Generation             int64
+	LastContactAt          *time.Time
+	LastAuditAt            *time.Time
+	Muted                  bool
+
+	UpdatedAt time.Time
+	JoinedAt  time.Time
+}
+
+// RestoreOption customizes how DB.Restore replaces stored stats.
+type RestoreOption func(opts *RestoreOptions)
+
+// RestoreOptions are the restore settings applied by RestoreOption.
+type RestoreOptions struct {
+	// Force replaces stored stats instead of refusing to restore with ErrRestoreNotEmpty.
+	Force bool
+}
+
+// ForceRestore makes DB.Restore replace already stored stats.
+func ForceRestore() RestoreOption {
+	return func(opts *RestoreOptions) { opts.Force = true }
+}
+
+// NewRestoreOptions applies opts on top of the defaults.
+func NewRestoreOptions(opts []RestoreOption) RestoreOptions {
+	var options RestoreOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// BackupWriter writes records of a backup, the backup is gzip compressed
+// and starts with the format version.
+type BackupWriter struct {
+	gz  *gzip.Writer
+	enc *gob.Encoder
+}
+
+// NewBackupWriter writes the header of a backup to w.
+func NewBackupWriter(w io.Writer) (*BackupWriter, error) {
+	gz := gzip.NewWriter(w)
+
+	var header bytes.Buffer
+	header.Write(backupMagic)
+	_ = binary.Write(&header, binary.BigEndian, uint32(BackupVersion))
+	if _, err := gz.Write(header.Bytes()); err != nil {
+		return nil, ErrBackup.Wrap(err)
+	}
+
+	return &BackupWriter{gz: gz, enc: gob.NewEncoder(gz)}, nil
+}
+
+// Write writes a single record to the backup.
+func (writer *BackupWriter) Write(record BackupRecord) error {
+	s := record.Stats
+	return ErrBackup.Wrap(writer.enc.Encode(backupRecord{
+		SatelliteID:            s.SatelliteID,
+		SatelliteAddress:       s.SatelliteAddress,
+		Uptime:                 s.Uptime,
+		Audit:                  s.Audit,
+		OnlineScore:            s.OnlineScore,
+		DisqualifiedAt:         s.DisqualifiedAt,
+		SuspendedAt:            s.SuspendedAt,
+		OfflineSuspendedAt:     s.OfflineSuspendedAt,
+		OfflineUnderReviewAt:   s.OfflineUnderReviewAt,
+		AuditHistory:           record.AuditHistory,
+		HistoryUpdatedAt:       s.HistoryUpdatedAt,
+		DisqualifiedObservedAt: s.DisqualifiedObservedAt,
+		DisqualificationReason: s.DisqualificationReason,
+		Generation:             s.Generation,
+		LastContactAt:          s.LastContactAt,
+		LastAuditAt:            s.LastAuditAt,
+		Muted:                  s.Muted,
+		UpdatedAt:              s.UpdatedAt,
+		JoinedAt:               s.JoinedAt,
+	}))
+}
+
+// Close flushes the backup, it doesn't close the underlying writer.
+func (writer *BackupWriter) Close() error {
+	return ErrBackup.Wrap(writer.gz.Close())
+}
+
+// ReadBackup reads all records of a backup written by BackupWriter. Backups of other versions,
+// records with malformed audit history and duplicate satellites are refused with ErrBackup.
+func ReadBackup(r io.Reader) (_ []BackupRecord, err error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrBackup.New("not a reputation backup: %v", err)
+	}
+	defer func() {
+		// Close repeats the error of a failed read, so it's only returned after successful reads.
+		if closeErr := gz.Close(); err == nil {
+			err = ErrBackup.Wrap(closeErr)
+		}
+	}()
+
+	header := make([]byte, len(backupMagic)+4)
+	if _, err := io.ReadFull(gz, header); err != nil {
+		return nil, ErrBackup.New("not a reputation backup: %v", err)
+	}
+	if !bytes.Equal(header[:len(backupMagic)], backupMagic) {
+		return nil, ErrBackup.New("not a reputation backup")
+	}
+	if version := binary.BigEndian.Uint32(header[len(backupMagic):]); version != BackupVersion {
+		return nil, ErrBackup.New("unsupported version %d, expected %d", version, BackupVersion)
+	}
+
+	var records []BackupRecord
+	seen := make(map[storj.NodeID]struct{})
+	dec := gob.NewDecoder(gz)
+	for {
+		var record backupRecord
+		if err := dec.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return nil, ErrBackup.Wrap(err)
+		}
+
+		if _, ok := seen[record.SatelliteID]; ok {
+			return nil, ErrBackup.New("duplicate satellite %s", record.SatelliteID)
+		}
+		seen[record.SatelliteID] = struct{}{}
+
+		// the audit history is restored as it is, so it's checked here instead of when it's read.
+		if record.AuditHistory != nil {
+			if err := pb.Unmarshal(record.AuditHistory, &pb.AuditHistory{}); err != nil {
+				return nil, ErrBackup.New("malformed audit history of satellite %s: %v", record.SatelliteID, err)
+			}
+		}
+
+		records = append(records, BackupRecord{
+			Stats: Stats{
+				SatelliteID:            record.SatelliteID,
+				SatelliteAddress:       record.SatelliteAddress,
+				Uptime:                 record.Uptime,
+				Audit:                  record.Audit,
+				OnlineScore:            record.OnlineScore,
+				DisqualifiedAt:         record.DisqualifiedAt,
+				SuspendedAt:            record.SuspendedAt,
+				OfflineSuspendedAt:     record.OfflineSuspendedAt,
+				OfflineUnderReviewAt:   record.OfflineUnderReviewAt,
+				HistoryUpdatedAt:       record.HistoryUpdatedAt,
+				DisqualifiedObservedAt: record.DisqualifiedObservedAt,
+				DisqualificationReason: record.DisqualificationReason,
+				Generation:             record.Generation,
+				LastContactAt:          record.LastContactAt,
+				LastAuditAt:            record.LastAuditAt,
+				Muted:                  record.Muted,
+				UpdatedAt:              record.UpdatedAt,
+				JoinedAt:               record.JoinedAt,
+			},
+			AuditHistory: record.AuditHistory,
+		})
+	}
+}
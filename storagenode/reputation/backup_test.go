@@ -0,0 +1,103 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestBackupFormat(t *testing.T) {
+	updatedAt := time.Now().UTC().Truncate(time.Second)
+	history, err := pb.Marshal(&pb.AuditHistory{Score: 0.5})
+	require.NoError(t, err)
+
+	records := []reputation.BackupRecord{
+		{
+			Stats: reputation.Stats{
+				SatelliteID: testrand.NodeID(),
+				OnlineScore: 0.5,
+				Muted:       true,
+				UpdatedAt:   updatedAt,
+			},
+			AuditHistory: history,
+		},
+		{
+			Stats: reputation.Stats{
+				SatelliteID: testrand.NodeID(),
+				OnlineScore: 1,
+				UpdatedAt:   updatedAt,
+			},
+		},
+	}
+
+	write := func(records ...reputation.BackupRecord) []byte {
+		var backup bytes.Buffer
+		writer, err := reputation.NewBackupWriter(&backup)
+		require.NoError(t, err)
+		for _, record := range records {
+			require.NoError(t, writer.Write(record))
+		}
+		require.NoError(t, writer.Close())
+		return backup.Bytes()
+	}
+
+	// compressed writes content compressed with gzip, like backups.
+	compressed := func(content []byte) []byte {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		return buf.Bytes()
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		read, err := reputation.ReadBackup(bytes.NewReader(write(records...)))
+		require.NoError(t, err)
+		require.Equal(t, records, read)
+
+		read, err = reputation.ReadBackup(bytes.NewReader(write()))
+		require.NoError(t, err)
+		require.Empty(t, read)
+	})
+
+	t.Run("not a backup", func(t *testing.T) {
+		_, err := reputation.ReadBackup(strings.NewReader("satellite_id,online_score"))
+		require.True(t, reputation.ErrBackup.Has(err), err)
+
+		_, err = reputation.ReadBackup(bytes.NewReader(compressed([]byte("satellite_id,online_score"))))
+		require.True(t, reputation.ErrBackup.Has(err), err)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		header := []byte("SNREPBAK")
+		header = append(header, make([]byte, 4)...)
+		binary.BigEndian.PutUint32(header[8:], reputation.BackupVersion+1)
+
+		_, err := reputation.ReadBackup(bytes.NewReader(compressed(header)))
+		require.True(t, reputation.ErrBackup.Has(err), err)
+		require.Contains(t, err.Error(), "unsupported version")
+	})
+
+	t.Run("malformed records", func(t *testing.T) {
+		_, err := reputation.ReadBackup(bytes.NewReader(write(records[0], records[0])))
+		require.True(t, reputation.ErrBackup.Has(err), err)
+
+		malformed := records[1]
+		malformed.AuditHistory = []byte{0xff, 0xff, 0xff}
+		_, err = reputation.ReadBackup(bytes.NewReader(write(malformed)))
+		require.True(t, reputation.ErrBackup.Has(err), err)
+	})
+}
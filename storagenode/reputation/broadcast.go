@@ -0,0 +1,84 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// subscriptionBuffer is how many stats are buffered for a subscriber
+// before the oldest ones are dropped.
+const subscriptionBuffer = 16
+
+// Broadcaster delivers stored stats to subscribers.
+// It's used by DB implementations to implement Subscribe.
+type Broadcaster struct {
+	log *zap.Logger
+
+	mu          sync.Mutex
+	subscribers map[chan Stats]struct{}
+}
+
+// NewBroadcaster creates a new broadcaster.
+func NewBroadcaster(log *zap.Logger) *Broadcaster {
+	return &Broadcaster{
+		log:         log,
+		subscribers: make(map[chan Stats]struct{}),
+	}
+}
+
+// Subscribe returns a channel which receives published stats until ctx is canceled.
+// When the subscriber falls behind the oldest stats are dropped.
+func (b *Broadcaster) Subscribe(ctx context.Context) (<-chan Stats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ch := make(chan Stats, subscriptionBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		delete(b.subscribers, ch)
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+// Publish sends stats to all subscribers without blocking.
+func (b *Broadcaster) Publish(stats ...Stats) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		for _, s := range stats {
+			select {
+			case ch <- s:
+				continue
+			default:
+			}
+
+			// only Publish sends and it holds the lock, so after
+			// dropping the oldest stats there is room for the new ones.
+			select {
+			case dropped := <-ch:
+				b.log.Warn("reputation subscriber is falling behind, dropping stats",
+					zap.Stringer("Satellite ID", dropped.SatelliteID))
+			default:
+			}
+			ch <- s
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestBroadcaster(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	core, logs := observer.New(zap.WarnLevel)
+	broadcast := reputation.NewBroadcaster(zap.New(core))
+
+	subctx, cancel := context.WithCancel(ctx)
+	ch, err := broadcast.Subscribe(subctx)
+	require.NoError(t, err)
+
+	var published []reputation.Stats
+	for i := 0; i < 20; i++ {
+		stats := reputation.Stats{SatelliteID: testrand.NodeID()}
+		published = append(published, stats)
+		broadcast.Publish(stats)
+	}
+
+	// the oldest stats are dropped for a slow subscriber.
+	assert.Equal(t, 4, logs.Len())
+	for _, expected := range published[4:] {
+		assert.Equal(t, expected, <-ch)
+	}
+
+	cancel()
+	for range ch {
+	}
+
+	// publishing after the subscriber is gone doesn't block.
+	broadcast.Publish(reputation.Stats{})
+
+	_, err = broadcast.Subscribe(subctx)
+	require.Error(t, err)
+}
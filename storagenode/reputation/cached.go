@@ -0,0 +1,244 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/pkg/cache"
+)
+
+// cachedDBCapacity is how many satellites CachedDB keeps stats for.
+const cachedDBCapacity = 128
+
+// CachedDB caches Get results of the wrapped DB for a short time.
+// Stats are invalidated whenever they are written through CachedDB,
+// writes which bypass it are visible after the cache expires.
+type CachedDB struct {
+	DB
+
+	opts cache.Options
+
+	mu    sync.Mutex
+	stats *cache.ExpiringLRU
+	// generation is increased by every invalidation, so Get can tell whether
+	// stats it loaded may have been written meanwhile.
+	generation uint64
+}
+
+// NewCachedDB creates a new DB which caches Get results of inner for ttl.
+func NewCachedDB(inner DB, ttl time.Duration) *CachedDB {
+	opts := cache.Options{
+		Expiration: ttl,
+		Capacity:   cachedDBCapacity,
+	}
+	return &CachedDB{
+		DB:    inner,
+		opts:  opts,
+		stats: cache.New(opts),
+	}
+}
+
+// Get retrieves stats for specific satellite, returns ErrNoStats when there are no stats for the satellite.
+// Stats with and without audit history are cached separately, so WithoutAuditHistory doesn't decode it.
+func (db *CachedDB) Get(ctx context.Context, satelliteID storj.NodeID, opts ...GetOption) (_ *Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	key := satelliteID.String()
+	if NewGetOptions(opts).WithoutAuditHistory {
+		key += withoutAuditHistoryKey
+	}
+
+	stats, generation := db.cache()
+	loaded := false
+	value, err := stats.Get(key, func() (interface{}, error) {
+		loaded = true
+		return db.DB.Get(ctx, satelliteID, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the stats may have been read before a write which invalidated them meanwhile.
+	if _, current := db.cache(); loaded && current != generation {
+		stats.Delete(key)
+	}
+
+	// return a deep copy, so callers can't modify cached stats.
+	return cloneStats(value.(*Stats)), nil
+}
+
+// Store inserts or updates reputation stats into the DB.
+func (db *CachedDB) Store(ctx context.Context, stats Stats, opts ...StoreOption) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.invalidate(stats.SatelliteID)
+
+	return db.DB.Store(ctx, stats, opts...)
+}
+
+// StoreAll inserts or updates all reputation stats into the DB in a single transaction.
+func (db *CachedDB) StoreAll(ctx context.Context, stats []Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer func() {
+		for _, s := range stats {
+			db.invalidate(s.SatelliteID)
+		}
+	}()
+
+	return db.DB.StoreAll(ctx, stats)
+}
+
+// ReplaceAll replaces all stored stats with provided stats in a single transaction.
+func (db *CachedDB) ReplaceAll(ctx context.Context, stats []Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.invalidateAll()
+
+	return db.DB.ReplaceAll(ctx, stats)
+}
+
+// Restore replaces all stored stats with stats of a backup.
+func (db *CachedDB) Restore(ctx context.Context, r io.Reader, opts ...RestoreOption) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.invalidateAll()
+
+	return db.DB.Restore(ctx, r, opts...)
+}
+
+// StoreIfNewer inserts stats or updates them when stats are more recent than the stored ones.
+func (db *CachedDB) StoreIfNewer(ctx context.Context, stats Stats) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.invalidate(stats.SatelliteID)
+
+	return db.DB.StoreIfNewer(ctx, stats)
+}
+
+// DeleteBefore deletes stats updated before provided time, stats of disqualified nodes are kept.
+func (db *CachedDB) DeleteBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.invalidateAll()
+
+	return db.DB.DeleteBefore(ctx, before)
+}
+
+// Mute marks stats of specific satellite as muted.
+func (db *CachedDB) Mute(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.invalidate(satelliteID)
+
+	return db.DB.Mute(ctx, satelliteID)
+}
+
+// Unmute clears the muted mark of specific satellite.
+func (db *CachedDB) Unmute(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.invalidate(satelliteID)
+
+	return db.DB.Unmute(ctx, satelliteID)
+}
+
+// TouchContact updates only the last contact time of specific satellite.
+func (db *CachedDB) TouchContact(ctx context.Context, satelliteID storj.NodeID, at time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.invalidate(satelliteID)
+
+	return db.DB.TouchContact(ctx, satelliteID, at)
+}
+
+// StoreAuditHistory replaces only the audit history of specific satellite.
+func (db *CachedDB) StoreAuditHistory(ctx context.Context, satelliteID storj.NodeID, history *pb.AuditHistory) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.invalidate(satelliteID)
+
+	return db.DB.StoreAuditHistory(ctx, satelliteID, history)
+}
+
+// Reset deletes stats of specific satellite.
+func (db *CachedDB) Reset(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.invalidate(satelliteID)
+
+	return db.DB.Reset(ctx, satelliteID)
+}
+
+// RenameSatellite moves stats and history of a satellite to its new ID.
+func (db *CachedDB) RenameSatellite(ctx context.Context, oldID, newID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.invalidate(oldID)
+	defer db.invalidate(newID)
+
+	return db.DB.RenameSatellite(ctx, oldID, newID)
+}
+
+// withoutAuditHistoryKey is appended to cache keys of stats without audit history.
+const withoutAuditHistoryKey = "/without-audit-history"
+
+// cache returns the current cache of stats and the generation of invalidations.
+func (db *CachedDB) cache() (*cache.ExpiringLRU, uint64) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.stats, db.generation
+}
+
+// invalidate removes cached stats of specific satellite.
+// It must be called after the write, Get drops stats loaded across it.
+func (db *CachedDB) invalidate(satelliteID storj.NodeID) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.generation++
+	db.stats.Delete(satelliteID.String())
+	db.stats.Delete(satelliteID.String() + withoutAuditHistoryKey)
+}
+
+// invalidateAll removes all cached stats.
+func (db *CachedDB) invalidateAll() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.generation++
+	db.stats = cache.New(db.opts)
+}
+
+// cloneStats returns a copy of stats which doesn't share the time pointers or audit history.
+func cloneStats(stats *Stats) *Stats {
+	clone := *stats
+	clone.DisqualifiedAt = cloneTime(stats.DisqualifiedAt)
+	clone.SuspendedAt = cloneTime(stats.SuspendedAt)
+	clone.OfflineSuspendedAt = cloneTime(stats.OfflineSuspendedAt)
+	clone.OfflineUnderReviewAt = cloneTime(stats.OfflineUnderReviewAt)
+	clone.DisqualifiedObservedAt = cloneTime(stats.DisqualifiedObservedAt)
+	clone.LastContactAt = cloneTime(stats.LastContactAt)
+	clone.LastAuditAt = cloneTime(stats.LastAuditAt)
+	clone.HistoryUpdatedAt = cloneTime(stats.HistoryUpdatedAt)
+
+	if stats.AuditHistory != nil {
+		clone.AuditHistory = &pb.AuditHistory{
+			Score:   stats.AuditHistory.Score,
+			Windows: make([]*pb.AuditWindow, len(stats.AuditHistory.Windows)),
+		}
+		for i, window := range stats.AuditHistory.Windows {
+			clone.AuditHistory.Windows[i] = &pb.AuditWindow{
+				WindowStart: window.WindowStart,
+				OnlineCount: window.OnlineCount,
+				TotalCount:  window.TotalCount,
+			}
+		}
+	}
+	return &clone
+}
+
+// cloneTime returns a copy of t, it returns nil when t is nil.
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	return &clone
+}
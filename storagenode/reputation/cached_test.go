@@ -0,0 +1,161 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+// countingDB counts Get calls which reach the DB, afterGet is called after the stats are read.
+type countingDB struct {
+	*reputation.MemoryDB
+	gets     int
+	afterGet func()
+}
+
+func (db *countingDB) Get(ctx context.Context, satelliteID storj.NodeID, opts ...reputation.GetOption) (*reputation.Stats, error) {
+	db.gets++
+	stats, err := db.MemoryDB.Get(ctx, satelliteID, opts...)
+	if db.afterGet != nil {
+		db.afterGet()
+	}
+	return stats, err
+}
+
+func TestCachedDB(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	inner := &countingDB{MemoryDB: reputation.NewMemory()}
+	db := reputation.NewCachedDB(inner, time.Hour)
+
+	stats := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		OnlineScore: 0.5,
+		UpdatedAt:   time.Now(),
+	}
+	require.NoError(t, db.Store(ctx, stats))
+
+	res, err := db.Get(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, res.OnlineScore)
+	require.Equal(t, 1, inner.gets)
+
+	t.Run("cached within ttl", func(t *testing.T) {
+		res.OnlineScore = 0
+
+		res, err := db.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, res.OnlineScore, "modifying returned stats must not modify the cache")
+		require.Equal(t, 1, inner.gets)
+	})
+
+	t.Run("invalidated on store", func(t *testing.T) {
+		updated := stats
+		updated.OnlineScore = 0.7
+		require.NoError(t, db.Store(ctx, updated))
+
+		res, err := db.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, 0.7, res.OnlineScore)
+		require.Equal(t, 2, inner.gets)
+
+		all, err := db.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, 0.7, all[0].OnlineScore)
+	})
+
+	t.Run("invalidated on reset", func(t *testing.T) {
+		require.NoError(t, db.Reset(ctx, stats.SatelliteID))
+
+		_, err := db.Get(ctx, stats.SatelliteID)
+		require.True(t, errors.Is(err, reputation.ErrNoStats))
+	})
+
+	t.Run("audit history", func(t *testing.T) {
+		inner := &countingDB{MemoryDB: reputation.NewMemory()}
+		db := reputation.NewCachedDB(inner, time.Hour)
+
+		withHistory := stats
+		withHistory.AuditHistory = &pb.AuditHistory{Windows: []*pb.AuditWindow{{WindowStart: stats.UpdatedAt, TotalCount: 1}}}
+		require.NoError(t, db.Store(ctx, withHistory))
+
+		res, err := db.Get(ctx, stats.SatelliteID, reputation.WithoutAuditHistory())
+		require.NoError(t, err)
+		require.Nil(t, res.AuditHistory)
+		require.Equal(t, 1, inner.gets)
+
+		// stats without audit history don't satisfy a Get with it.
+		res, err = db.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		require.NotNil(t, res.AuditHistory)
+		require.Equal(t, 2, inner.gets)
+
+		res.AuditHistory.Windows[0].TotalCount = 5
+		*res.HistoryUpdatedAt = res.HistoryUpdatedAt.Add(time.Hour)
+
+		cached, err := db.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		require.Equal(t, 2, inner.gets)
+		assert.Equal(t, int32(1), cached.AuditHistory.Windows[0].TotalCount, "modifying returned history must not modify the cache")
+		assert.NotEqual(t, *res.HistoryUpdatedAt, *cached.HistoryUpdatedAt)
+
+		// both variants are invalidated by writes.
+		require.NoError(t, db.Store(ctx, stats))
+		_, err = db.Get(ctx, stats.SatelliteID, reputation.WithoutAuditHistory())
+		require.NoError(t, err)
+		_, err = db.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		require.Equal(t, 4, inner.gets)
+	})
+
+	t.Run("written while loading", func(t *testing.T) {
+		inner := &countingDB{MemoryDB: reputation.NewMemory()}
+		db := reputation.NewCachedDB(inner, time.Hour)
+		require.NoError(t, db.Store(ctx, stats))
+
+		updated := stats
+		updated.OnlineScore = 0.9
+		inner.afterGet = func() {
+			inner.afterGet = nil
+			require.NoError(t, db.Store(ctx, updated))
+		}
+
+		// the stats read before the write are returned, but not cached.
+		res, err := db.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, stats.OnlineScore, res.OnlineScore)
+
+		res, err = db.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, updated.OnlineScore, res.OnlineScore)
+		require.Equal(t, 2, inner.gets)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		inner := &countingDB{MemoryDB: reputation.NewMemory()}
+		db := reputation.NewCachedDB(inner, time.Nanosecond)
+		require.NoError(t, db.Store(ctx, stats))
+
+		for i := 1; i <= 2; i++ {
+			time.Sleep(time.Millisecond)
+
+			_, err := db.Get(ctx, stats.SatelliteID)
+			require.NoError(t, err)
+			require.Equal(t, i, inner.gets)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"strconv"
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// ChangeField is a field of stats which is recorded in the changelog when it changes.
+type ChangeField string
+
+const (
+	// ChangeOnlineScore records changes of the online score.
+	ChangeOnlineScore ChangeField = "online_score"
+	// ChangeAuditScore records changes of the audit score reported by the satellite,
+	// Audit.Score rather than ComputedScore.
+	ChangeAuditScore ChangeField = "audit_score"
+	// ChangeUnknownAuditScore records changes of the unknown audit score.
+	ChangeUnknownAuditScore ChangeField = "unknown_audit_score"
+	// ChangeSuspended records whether the node is suspended for unknown audit errors.
+	ChangeSuspended ChangeField = "suspended"
+	// ChangeOfflineSuspended records whether the node is suspended for being offline.
+	ChangeOfflineSuspended ChangeField = "offline_suspended"
+	// ChangeOfflineUnderReview records whether the node is under review for being offline.
+	ChangeOfflineUnderReview ChangeField = "offline_under_review"
+	// ChangeDisqualified records whether the node is disqualified.
+	ChangeDisqualified ChangeField = "disqualified"
+)
+
+// changeFields are the recorded fields in the order they are recorded.
+var changeFields = []struct {
+	field ChangeField
+	value func(Stats) string
+}{
+	{ChangeOnlineScore, func(s Stats) string { return formatScore(s.OnlineScore) }},
+	{ChangeAuditScore, func(s Stats) string { return formatScore(s.Audit.Score) }},
+	{ChangeUnknownAuditScore, func(s Stats) string { return formatScore(s.Audit.UnknownScore) }},
+	{ChangeSuspended, func(s Stats) string { return strconv.FormatBool(s.SuspendedAt != nil) }},
+	{ChangeOfflineSuspended, func(s Stats) string { return strconv.FormatBool(s.OfflineSuspendedAt != nil) }},
+	{ChangeOfflineUnderReview, func(s Stats) string { return strconv.FormatBool(s.OfflineUnderReviewAt != nil) }},
+	{ChangeDisqualified, func(s Stats) string { return strconv.FormatBool(s.DisqualifiedAt != nil) }},
+}
+
+// ChangeRecord is a change of a single field of stats recorded in the changelog.
+type ChangeRecord struct {
+	// ID is assigned when the record is stored, IDs increase with every stored
+	// record and are never reused, so they can be used as a cursor of GetChanges.
+	ID          int64
+	SatelliteID storj.NodeID
+	Timestamp   time.Time
+	Field       ChangeField
+	// Before is the value before the change, it's empty when the stats were stored for the first time.
+	// Scores are formatted as decimal numbers and flags as "true" or "false".
+	Before string
+	After  string
+}
+
+// MaxChanges is the maximum number of records returned by a single GetChanges call.
+const MaxChanges = 1000
+
+// NewChangeRecords returns records of the fields which differ between previous and current stats
+// of a satellite at the time, previous is nil when the stats are stored for the first time.
+func NewChangeRecords(previous *Stats, current Stats, at time.Time) []ChangeRecord {
+	var records []ChangeRecord
+	for _, f := range changeFields {
+		var before string
+		if previous != nil {
+			before = f.value(*previous)
+		}
+		after := f.value(current)
+		if previous != nil && before == after {
+			continue
+		}
+		records = append(records, ChangeRecord{
+			SatelliteID: current.SatelliteID,
+			Timestamp:   at,
+			Field:       f.field,
+			Before:      before,
+			After:       after,
+		})
+	}
+	return records
+}
+
+// formatScore formats a score for the changelog.
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'g', -1, 64)
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestNewChangeRecords(t *testing.T) {
+	now := time.Now()
+	previous := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		OnlineScore: 0.9,
+		Audit:       reputation.Metric{Score: 1, UnknownScore: 0.95, TotalCount: 10},
+		SuspendedAt: &now,
+	}
+
+	initial := reputation.NewChangeRecords(nil, previous, now)
+	require.Len(t, initial, 7)
+	for _, record := range initial {
+		require.Empty(t, record.Before)
+		require.Equal(t, previous.SatelliteID, record.SatelliteID)
+		require.Equal(t, now, record.Timestamp)
+	}
+	require.Equal(t, reputation.ChangeSuspended, initial[3].Field)
+	require.Equal(t, "true", initial[3].After)
+
+	// fields which aren't recorded don't make changes.
+	current := previous
+	current.Audit.TotalCount = 20
+	current.UpdatedAt = now
+	require.Empty(t, reputation.NewChangeRecords(&previous, current, now))
+
+	current.Audit.UnknownScore = 0.9
+	current.SuspendedAt = nil
+	current.DisqualifiedAt = &now
+	require.Equal(t, []reputation.ChangeRecord{
+		{SatelliteID: previous.SatelliteID, Timestamp: now, Field: reputation.ChangeUnknownAuditScore, Before: "0.95", After: "0.9"},
+		{SatelliteID: previous.SatelliteID, Timestamp: now, Field: reputation.ChangeSuspended, Before: "true", After: "false"},
+		{SatelliteID: previous.SatelliteID, Timestamp: now, Field: reputation.ChangeDisqualified, Before: "false", After: "true"},
+	}, reputation.NewChangeRecords(&previous, current, now))
+}
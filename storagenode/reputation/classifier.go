@@ -0,0 +1,134 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"context"
+	"sync"
+
+	"storj.io/common/storj"
+)
+
+// ClassifierOption customizes how a Classifier classifies stats.
+type ClassifierOption func(classifier *Classifier)
+
+// WithHysteresis requires scores to rise margin above a threshold before a worse status
+// is lifted, so scores hovering around a threshold don't make the status oscillate.
+// Negative margins are treated as zero.
+func WithHysteresis(margin float64) ClassifierOption {
+	return func(classifier *Classifier) {
+		if margin > 0 {
+			classifier.hysteresis = margin
+		}
+	}
+}
+
+// Classifier classifies stats like Thresholds.Classify, but remembers the last reported
+// status of every satellite to apply hysteresis.
+type Classifier struct {
+	thresholds Thresholds
+	hysteresis float64
+
+	mu   sync.Mutex
+	last map[storj.NodeID]StatusReport
+}
+
+// NewClassifier creates a new classifier using thresholds.
+func NewClassifier(thresholds Thresholds, opts ...ClassifierOption) *Classifier {
+	classifier := &Classifier{
+		thresholds: thresholds,
+		last:       make(map[storj.NodeID]StatusReport),
+	}
+	for _, opt := range opts {
+		opt(classifier)
+	}
+	return classifier
+}
+
+// Classify evaluates the stats against the thresholds. A metric which was reported worse
+// before is only reported better once its score is above the raised thresholds.
+func (classifier *Classifier) Classify(stats Stats) StatusReport {
+	classifier.mu.Lock()
+	defer classifier.mu.Unlock()
+
+	return classifier.classify(stats, classifier.thresholds.Classify(stats))
+}
+
+// Statuses classifies stats of all satellites in db like DB.Statuses, but with hysteresis.
+// Satellites without stats in db are forgotten.
+func (classifier *Classifier) Statuses(ctx context.Context, db DB) (_ map[storj.NodeID]StatusReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	all, err := db.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := classifier.thresholds.classifyAll(ctx, db, all)
+	if err != nil {
+		return nil, err
+	}
+
+	classifier.mu.Lock()
+	defer classifier.mu.Unlock()
+
+	statuses := make(map[storj.NodeID]StatusReport, len(all))
+	for _, stats := range all {
+		statuses[stats.SatelliteID] = classifier.classify(stats, reports[stats.SatelliteID])
+	}
+	for satelliteID := range classifier.last {
+		if _, ok := statuses[satelliteID]; !ok {
+			delete(classifier.last, satelliteID)
+		}
+	}
+	return statuses, nil
+}
+
+// classify applies hysteresis to the report of stats classified against the thresholds
+// and remembers the result, classifier.mu must be held.
+func (classifier *Classifier) classify(stats Stats, report StatusReport) StatusReport {
+	if report.NotEnoughData {
+		return report
+	}
+
+	if last, ok := classifier.last[stats.SatelliteID]; ok && classifier.hysteresis > 0 && !last.NotEnoughData {
+		raised := classifier.thresholds
+		raised.AuditWarn += classifier.hysteresis
+		raised.AuditCritical += classifier.hysteresis
+		raised.OnlineWarn += classifier.hysteresis
+		raised.OnlineCritical += classifier.hysteresis
+		// report already has enough data, stats may not carry the audit history to tell.
+		raised.MinAudits, raised.MinWindows = 0, 0
+		raisedReport := raised.Classify(stats)
+
+		report.Audit = recovered(last.Audit, report.Audit, raisedReport.Audit)
+		report.Online = recovered(last.Online, report.Online, raisedReport.Online)
+		report.Overall = report.Audit
+		if report.Online > report.Overall {
+			report.Overall = report.Online
+		}
+	}
+
+	classifier.last[stats.SatelliteID] = report
+	return report
+}
+
+// Forget removes the last reported status of the satellite, e.g. after its stats were reset.
+func (classifier *Classifier) Forget(satelliteID storj.NodeID) {
+	classifier.mu.Lock()
+	defer classifier.mu.Unlock()
+
+	delete(classifier.last, satelliteID)
+}
+
+// recovered returns the level of a metric which was reported at last. Getting worse is
+// reported immediately, while improving is limited by the level against raised thresholds.
+func recovered(last, current, raised RiskLevel) RiskLevel {
+	if current >= last {
+		return current
+	}
+	if raised < last {
+		return raised
+	}
+	return last
+}
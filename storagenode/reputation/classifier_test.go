@@ -0,0 +1,112 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestClassifierHysteresis(t *testing.T) {
+	thresholds := reputation.DefaultThresholds()
+	stats := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{Alpha: 1, Beta: 0},
+	}
+
+	classifier := reputation.NewClassifier(thresholds, reputation.WithHysteresis(0.05))
+	withoutHysteresis := reputation.NewClassifier(thresholds)
+
+	// online warn is 0.9 and online critical is 0.7.
+	for i, step := range []struct {
+		score    float64
+		expected reputation.RiskLevel
+		plain    reputation.RiskLevel
+	}{
+		{score: 0.95, expected: reputation.RiskSafe, plain: reputation.RiskSafe},
+		{score: 0.89, expected: reputation.RiskWarning, plain: reputation.RiskWarning},
+		// hovering above the warning threshold keeps the warning.
+		{score: 0.91, expected: reputation.RiskWarning, plain: reputation.RiskSafe},
+		{score: 0.89, expected: reputation.RiskWarning, plain: reputation.RiskWarning},
+		{score: 0.93, expected: reputation.RiskWarning, plain: reputation.RiskSafe},
+		// getting worse is reported immediately.
+		{score: 0.69, expected: reputation.RiskCritical, plain: reputation.RiskCritical},
+		{score: 0.72, expected: reputation.RiskCritical, plain: reputation.RiskWarning},
+		// above the raised critical threshold, but below the raised warning one.
+		{score: 0.92, expected: reputation.RiskWarning, plain: reputation.RiskSafe},
+		{score: 0.96, expected: reputation.RiskSafe, plain: reputation.RiskSafe},
+		{score: 0.91, expected: reputation.RiskSafe, plain: reputation.RiskSafe},
+	} {
+		stats.OnlineScore = step.score
+
+		report := classifier.Classify(stats)
+		assert.Equal(t, step.expected, report.Online, "step %d", i)
+		assert.Equal(t, step.expected, report.Overall, "step %d", i)
+		assert.Equal(t, step.plain, withoutHysteresis.Classify(stats).Online, "step %d", i)
+	}
+
+	// forgetting the satellite drops the remembered warning.
+	stats.OnlineScore = 0.89
+	assert.Equal(t, reputation.RiskWarning, classifier.Classify(stats).Online)
+	classifier.Forget(stats.SatelliteID)
+	stats.OnlineScore = 0.91
+	assert.Equal(t, reputation.RiskSafe, classifier.Classify(stats).Online)
+
+	// other satellites are tracked separately.
+	other := stats
+	other.SatelliteID = testrand.NodeID()
+	assert.Equal(t, reputation.RiskSafe, classifier.Classify(other).Online)
+}
+
+func TestClassifierStatuses(t *testing.T) {
+	ctx := testcontext.New(t)
+	db := reputation.NewMemory()
+
+	thresholds := reputation.DefaultThresholds()
+	thresholds.MinWindows = 1
+	classifier := reputation.NewClassifier(thresholds, reputation.WithHysteresis(0.05))
+
+	now := time.Now()
+	stats := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{Alpha: 1, Beta: 0},
+		// the audit history isn't read by All, but Statuses must still see the windows.
+		AuditHistory: &pb.AuditHistory{Windows: []*pb.AuditWindow{
+			{WindowStart: now.Add(-time.Hour), TotalCount: 1},
+			{WindowStart: now, TotalCount: 1},
+		}},
+	}
+
+	check := func(onlineScore float64, expected reputation.RiskLevel) {
+		stats.OnlineScore = onlineScore
+		require.NoError(t, db.Store(ctx, stats))
+
+		statuses, err := classifier.Statuses(ctx, db)
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		assert.False(t, statuses[stats.SatelliteID].NotEnoughData)
+		assert.Equal(t, expected, statuses[stats.SatelliteID].Online, onlineScore)
+	}
+
+	check(0.89, reputation.RiskWarning)
+	// hovering above the warning threshold keeps the warning.
+	check(0.91, reputation.RiskWarning)
+	check(0.96, reputation.RiskSafe)
+	check(0.89, reputation.RiskWarning)
+
+	// satellites without stats are forgotten.
+	require.NoError(t, db.Reset(ctx, stats.SatelliteID))
+	statuses, err := classifier.Statuses(ctx, db)
+	require.NoError(t, err)
+	require.Empty(t, statuses)
+	check(0.91, reputation.RiskSafe)
+}
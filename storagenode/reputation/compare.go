@@ -0,0 +1,111 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"sort"
+
+	"storj.io/common/storj"
+)
+
+// ComparedNode identifies one of the nodes compared by CompareNodes.
+type ComparedNode string
+
+const (
+	// NodeA is the node of the first stats passed to CompareNodes.
+	NodeA ComparedNode = "a"
+	// NodeB is the node of the second stats passed to CompareNodes.
+	NodeB ComparedNode = "b"
+)
+
+// NodeComparison compares reputation of two nodes on a satellite.
+type NodeComparison struct {
+	SatelliteID storj.NodeID
+
+	// OnlyOn is the node which has stats for the satellite when the other one doesn't,
+	// it's empty when both have them. Deltas and Worse are only set when both have them.
+	OnlyOn ComparedNode
+
+	// AuditScoreDelta is the computed audit score of node b minus the one of node a.
+	AuditScoreDelta float64
+	// OnlineScoreDelta is the online score of node b minus the one of node a.
+	OnlineScoreDelta float64
+	// Worse is the node with worse reputation, it's empty when reputation is the same.
+	Worse ComparedNode
+}
+
+// Matched returns whether both nodes have stats for the satellite.
+func (comparison NodeComparison) Matched() bool {
+	return comparison.OnlyOn == ""
+}
+
+// CompareNodes compares stats of two nodes, e.g. as returned by DB.All, per satellite.
+// Satellites with stats on both nodes are listed first, followed by satellites with stats
+// on only one of them, both ordered by satellite ID.
+//
+// A disqualified node is worse, otherwise the node with lower audit score is worse and
+// when audit scores are the same, the node with lower online score.
+func CompareNodes(a, b []Stats) []NodeComparison {
+	satellitesB := make(map[storj.NodeID]Stats, len(b))
+	for _, stats := range b {
+		satellitesB[stats.SatelliteID] = stats
+	}
+
+	var matched, unmatched []NodeComparison
+	for _, statsA := range a {
+		statsB, ok := satellitesB[statsA.SatelliteID]
+		if !ok {
+			unmatched = append(unmatched, NodeComparison{SatelliteID: statsA.SatelliteID, OnlyOn: NodeA})
+			continue
+		}
+		matched = append(matched, compareStats(statsA, statsB))
+	}
+
+	satellitesA := make(map[storj.NodeID]struct{}, len(a))
+	for _, stats := range a {
+		satellitesA[stats.SatelliteID] = struct{}{}
+	}
+	for _, stats := range b {
+		if _, ok := satellitesA[stats.SatelliteID]; !ok {
+			unmatched = append(unmatched, NodeComparison{SatelliteID: stats.SatelliteID, OnlyOn: NodeB})
+		}
+	}
+
+	sortComparisons(matched)
+	sortComparisons(unmatched)
+	return append(matched, unmatched...)
+}
+
+// compareStats compares stats of two nodes on the same satellite.
+func compareStats(a, b Stats) NodeComparison {
+	comparison := NodeComparison{
+		SatelliteID:      a.SatelliteID,
+		AuditScoreDelta:  b.Audit.ComputedScore() - a.Audit.ComputedScore(),
+		OnlineScoreDelta: b.OnlineScore - a.OnlineScore,
+	}
+
+	switch {
+	case (a.DisqualifiedAt != nil) != (b.DisqualifiedAt != nil):
+		comparison.Worse = NodeB
+		if a.DisqualifiedAt != nil {
+			comparison.Worse = NodeA
+		}
+	case comparison.AuditScoreDelta < 0:
+		comparison.Worse = NodeB
+	case comparison.AuditScoreDelta > 0:
+		comparison.Worse = NodeA
+	case comparison.OnlineScoreDelta < 0:
+		comparison.Worse = NodeB
+	case comparison.OnlineScoreDelta > 0:
+		comparison.Worse = NodeA
+	}
+	return comparison
+}
+
+// sortComparisons sorts comparisons by satellite ID.
+func sortComparisons(comparisons []NodeComparison) {
+	sort.Slice(comparisons, func(i, k int) bool {
+		return comparisons[i].SatelliteID.Less(comparisons[k].SatelliteID)
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestCompareNodes(t *testing.T) {
+	now := time.Now()
+	ids := []storj.NodeID{testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()}
+	sort.Slice(ids, func(i, k int) bool { return ids[i].Less(ids[k]) })
+
+	good := reputation.Metric{Alpha: 1, Beta: 0}
+	bad := reputation.Metric{Alpha: 1, Beta: 1}
+
+	a := []reputation.Stats{
+		{SatelliteID: ids[3], Audit: good, OnlineScore: 1},
+		{SatelliteID: ids[0], Audit: bad, OnlineScore: 1},
+		{SatelliteID: ids[1], Audit: good, OnlineScore: 0.5},
+		{SatelliteID: ids[2], Audit: good, OnlineScore: 1, DisqualifiedAt: &now},
+		{SatelliteID: ids[5]},
+	}
+	b := []reputation.Stats{
+		{SatelliteID: ids[4]},
+		{SatelliteID: ids[0], Audit: good, OnlineScore: 0.5},
+		{SatelliteID: ids[1], Audit: good, OnlineScore: 1},
+		{SatelliteID: ids[2], Audit: bad, OnlineScore: 0.5},
+		{SatelliteID: ids[3], Audit: good, OnlineScore: 1},
+	}
+
+	comparisons := reputation.CompareNodes(a, b)
+	require.Len(t, comparisons, 6)
+
+	// audit score is compared before online score.
+	assert.Equal(t, reputation.NodeComparison{
+		SatelliteID:      ids[0],
+		AuditScoreDelta:  0.5,
+		OnlineScoreDelta: -0.5,
+		Worse:            reputation.NodeA,
+	}, comparisons[0])
+	assert.Equal(t, reputation.NodeComparison{
+		SatelliteID:      ids[1],
+		OnlineScoreDelta: 0.5,
+		Worse:            reputation.NodeA,
+	}, comparisons[1])
+	// disqualification is worse than any score.
+	assert.Equal(t, reputation.NodeComparison{
+		SatelliteID:      ids[2],
+		AuditScoreDelta:  -0.5,
+		OnlineScoreDelta: -0.5,
+		Worse:            reputation.NodeA,
+	}, comparisons[2])
+	assert.Equal(t, reputation.NodeComparison{SatelliteID: ids[3]}, comparisons[3])
+
+	for _, comparison := range comparisons[:4] {
+		assert.True(t, comparison.Matched())
+	}
+
+	// satellites present on only one node are listed last.
+	assert.Equal(t, reputation.NodeComparison{SatelliteID: ids[4], OnlyOn: reputation.NodeB}, comparisons[4])
+	assert.Equal(t, reputation.NodeComparison{SatelliteID: ids[5], OnlyOn: reputation.NodeA}, comparisons[5])
+	assert.False(t, comparisons[4].Matched())
+
+	assert.Empty(t, reputation.CompareNodes(nil, nil))
+}
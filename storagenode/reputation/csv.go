@@ -0,0 +1,87 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// csvHeaders are the column names written by CSVWriter.
+var csvHeaders = []string{
+	"satelliteID",
+	"auditScore",
+	"onlineScore",
+	"suspendedAt",
+	"offlineSuspendedAt",
+	"disqualifiedAt",
+	"joinedAt",
+}
+
+// WriteCSV writes a header row and a row for each of the stats to w.
+// Timestamps are formatted as RFC3339 in UTC unless WithLocation is given,
+// missing timestamps are left empty.
+func WriteCSV(w io.Writer, stats []Stats, opts ...ExportOption) error {
+	writer, err := NewCSVWriter(w, opts...)
+	if err != nil {
+		return err
+	}
+	for _, s := range stats {
+		if err := writer.Write(s); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// CSVWriter writes stats as they come in the format of WriteCSV, e.g. from DB.ForEach,
+// so they don't have to be held in memory at once.
+type CSVWriter struct {
+	options exportOptions
+	csv     *csv.Writer
+}
+
+// NewCSVWriter writes the header row to w.
+func NewCSVWriter(w io.Writer, opts ...ExportOption) (*CSVWriter, error) {
+	writer := &CSVWriter{
+		options: newExportOptions(opts),
+		csv:     csv.NewWriter(w),
+	}
+	if err := writer.csv.Write(csvHeaders); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return writer, nil
+}
+
+// Write writes a row for s.
+func (writer *CSVWriter) Write(s Stats) error {
+	return errs.Wrap(writer.csv.Write([]string{
+		s.SatelliteID.String(),
+		strconv.FormatFloat(s.Audit.ComputedScore(), 'f', -1, 64),
+		strconv.FormatFloat(s.OnlineScore, 'f', -1, 64),
+		writer.options.formatCSVTime(s.SuspendedAt),
+		writer.options.formatCSVTime(s.OfflineSuspendedAt),
+		writer.options.formatCSVTime(s.DisqualifiedAt),
+		writer.options.formatCSVTime(&s.JoinedAt),
+	}))
+}
+
+// Close flushes the written rows, it doesn't close the underlying writer.
+func (writer *CSVWriter) Close() error {
+	writer.csv.Flush()
+	return errs.Wrap(writer.csv.Error())
+}
+
+// formatCSVTime formats t as RFC3339 in the export location, nil and zero timestamps
+// are formatted as empty string.
+func (opts exportOptions) formatCSVTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return opts.in(*t).Format(time.RFC3339)
+}
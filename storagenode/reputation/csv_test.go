@@ -0,0 +1,59 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestWriteCSV(t *testing.T) {
+	joinedAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	suspendedAt := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	first := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{Alpha: 19, Beta: 1, Score: 0.95},
+		OnlineScore: 1,
+		SuspendedAt: &suspendedAt,
+		JoinedAt:    joinedAt,
+	}
+	second := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, reputation.WriteCSV(&buf, []reputation.Stats{first, second}))
+
+	assert.Equal(t, ""+
+		"satelliteID,auditScore,onlineScore,suspendedAt,offlineSuspendedAt,disqualifiedAt,joinedAt\n"+
+		first.SatelliteID.String()+",0.95,1,2021-02-03T04:05:06Z,,,2020-01-02T03:04:05Z\n"+
+		second.SatelliteID.String()+",0,0,,,,\n",
+		buf.String())
+}
+
+func TestWriteCSVLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	joinedAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	stats := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		JoinedAt:    joinedAt,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, reputation.WriteCSV(&buf, []reputation.Stats{stats}, reputation.WithLocation(loc)))
+
+	assert.Equal(t, ""+
+		"satelliteID,auditScore,onlineScore,suspendedAt,offlineSuspendedAt,disqualifiedAt,joinedAt\n"+
+		stats.SatelliteID.String()+",0,0,,,,2020-01-02T05:04:05+02:00\n",
+		buf.String())
+}
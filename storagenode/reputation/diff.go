@@ -0,0 +1,116 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"time"
+
+	"storj.io/common/pb"
+)
+
+// Change describes meaningful transitions between two reputation stats
+// of the same satellite.
+type Change struct {
+	BecameSuspended    bool
+	ClearedSuspension  bool
+	BecameDisqualified bool
+
+	OnlineScoreDropped bool
+	// OnlineScoreDelta is the difference between the current and previous online score.
+	OnlineScoreDelta float64
+
+	// AuditScoreDropped and AuditScoreDelta track the audit score reported by the satellite,
+	// Audit.Score rather than ComputedScore, since previous stats may not keep alpha and beta.
+	AuditScoreDropped bool
+	// AuditScoreDelta is the difference between the current and previous reported audit score.
+	AuditScoreDelta float64
+}
+
+// Changed returns true when any transition happened.
+func (change Change) Changed() bool {
+	return change.BecameSuspended || change.ClearedSuspension || change.BecameDisqualified ||
+		change.OnlineScoreDropped || change.AuditScoreDropped
+}
+
+// Diff compares stats with the previous stats of the same satellite.
+// A node is considered suspended when it's either suspended for unknown
+// audit errors or for being offline.
+func (s Stats) Diff(prev Stats) Change {
+	suspended := s.SuspendedAt != nil || s.OfflineSuspendedAt != nil
+	prevSuspended := prev.SuspendedAt != nil || prev.OfflineSuspendedAt != nil
+
+	change := Change{
+		BecameSuspended:    !prevSuspended && suspended,
+		ClearedSuspension:  prevSuspended && !suspended,
+		BecameDisqualified: prev.DisqualifiedAt == nil && s.DisqualifiedAt != nil,
+		OnlineScoreDelta:   s.OnlineScore - prev.OnlineScore,
+		AuditScoreDelta:    s.Audit.Score - prev.Audit.Score,
+	}
+	change.OnlineScoreDropped = change.OnlineScoreDelta < 0
+	change.AuditScoreDropped = change.AuditScoreDelta < 0
+
+	return change
+}
+
+// Transition is a change of the suspension or disqualification state of a satellite.
+type Transition string
+
+const (
+	// TransitionSuspended is logged when a satellite suspended the node.
+	TransitionSuspended Transition = "suspended"
+	// TransitionRecovered is logged when a satellite cleared the suspension of the node.
+	TransitionRecovered Transition = "recovered"
+	// TransitionDisqualified is logged when a satellite disqualified the node.
+	TransitionDisqualified Transition = "disqualified"
+)
+
+// Transitions returns the suspension and disqualification transitions of the change.
+func (change Change) Transitions() []Transition {
+	var transitions []Transition
+	if change.BecameSuspended {
+		transitions = append(transitions, TransitionSuspended)
+	}
+	if change.ClearedSuspension {
+		transitions = append(transitions, TransitionRecovered)
+	}
+	if change.BecameDisqualified {
+		transitions = append(transitions, TransitionDisqualified)
+	}
+	return transitions
+}
+
+// EqualIgnoringTimestamps returns whether stats have the same values as reported by the
+// satellite. UpdatedAt and LastContactAt, which change on every sync, as well as the
+// node local DisqualifiedObservedAt, LastAuditAt, HistoryUpdatedAt, Muted and Stale are ignored.
+func (s Stats) EqualIgnoringTimestamps(other Stats) bool {
+	return s.SatelliteID == other.SatelliteID &&
+		s.SatelliteAddress == other.SatelliteAddress &&
+		s.Uptime == other.Uptime &&
+		s.Audit == other.Audit &&
+		s.OnlineScore == other.OnlineScore &&
+		equalTime(s.DisqualifiedAt, other.DisqualifiedAt) &&
+		equalTime(s.SuspendedAt, other.SuspendedAt) &&
+		equalTime(s.OfflineSuspendedAt, other.OfflineSuspendedAt) &&
+		equalTime(s.OfflineUnderReviewAt, other.OfflineUnderReviewAt) &&
+		equalAuditHistory(s.AuditHistory, other.AuditHistory) &&
+		s.DisqualificationReason == other.DisqualificationReason &&
+		s.Generation == other.Generation &&
+		s.JoinedAt.Equal(other.JoinedAt)
+}
+
+// equalTime returns whether both times are nil or are the same instant.
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// equalAuditHistory returns whether both audit histories are nil or have the same content.
+func equalAuditHistory(a, b *pb.AuditHistory) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return pb.Equal(a, b)
+}
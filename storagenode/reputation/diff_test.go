@@ -0,0 +1,114 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/common/pb"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestStatsDiff(t *testing.T) {
+	now := time.Now()
+
+	t.Run("no change", func(t *testing.T) {
+		stats := reputation.Stats{OnlineScore: 0.9, SuspendedAt: &now}
+		change := stats.Diff(stats)
+		assert.False(t, change.Changed())
+		assert.Equal(t, reputation.Change{}, change)
+	})
+
+	t.Run("became suspended", func(t *testing.T) {
+		prev := reputation.Stats{}
+		for _, stats := range []reputation.Stats{
+			{SuspendedAt: &now},
+			{OfflineSuspendedAt: &now},
+		} {
+			change := stats.Diff(prev)
+			assert.True(t, change.BecameSuspended)
+			assert.False(t, change.ClearedSuspension)
+			assert.True(t, change.Changed())
+		}
+	})
+
+	t.Run("cleared suspension", func(t *testing.T) {
+		prev := reputation.Stats{SuspendedAt: &now, OfflineSuspendedAt: &now}
+		change := reputation.Stats{}.Diff(prev)
+		assert.True(t, change.ClearedSuspension)
+		assert.False(t, change.BecameSuspended)
+
+		// still offline suspended.
+		change = reputation.Stats{OfflineSuspendedAt: &now}.Diff(prev)
+		assert.False(t, change.ClearedSuspension)
+		assert.False(t, change.BecameSuspended)
+	})
+
+	t.Run("became disqualified", func(t *testing.T) {
+		change := reputation.Stats{DisqualifiedAt: &now}.Diff(reputation.Stats{})
+		assert.True(t, change.BecameDisqualified)
+
+		change = reputation.Stats{DisqualifiedAt: &now}.Diff(reputation.Stats{DisqualifiedAt: &now})
+		assert.False(t, change.BecameDisqualified)
+	})
+
+	t.Run("scores", func(t *testing.T) {
+		prev := reputation.Stats{OnlineScore: 0.9, Audit: reputation.Metric{Score: 0.5}}
+		stats := reputation.Stats{OnlineScore: 0.75, Audit: reputation.Metric{Score: 0.75}}
+
+		change := stats.Diff(prev)
+		assert.True(t, change.OnlineScoreDropped)
+		assert.InDelta(t, -0.15, change.OnlineScoreDelta, 1e-9)
+		assert.False(t, change.AuditScoreDropped)
+		assert.InDelta(t, 0.25, change.AuditScoreDelta, 1e-9)
+	})
+}
+
+func TestStatsEqualIgnoringTimestamps(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	stats := reputation.Stats{
+		SatelliteID:  testrand.NodeID(),
+		Audit:        reputation.Metric{Alpha: 20, Score: 1},
+		OnlineScore:  1,
+		SuspendedAt:  &now,
+		AuditHistory: &pb.AuditHistory{Score: 1},
+		JoinedAt:     now,
+		UpdatedAt:    now,
+	}
+
+	same := stats
+	same.UpdatedAt = later
+	same.LastContactAt = &later
+	same.DisqualifiedObservedAt = &later
+	same.Muted = true
+	suspendedAt := now.UTC()
+	same.SuspendedAt = &suspendedAt
+	same.AuditHistory = &pb.AuditHistory{Score: 1}
+	assert.True(t, stats.EqualIgnoringTimestamps(same))
+
+	for name, modify := range map[string]func(*reputation.Stats){
+		"satellite":     func(s *reputation.Stats) { s.SatelliteID = testrand.NodeID() },
+		"audit":         func(s *reputation.Stats) { s.Audit.Beta = 1 },
+		"online score":  func(s *reputation.Stats) { s.OnlineScore = 0.9 },
+		"suspension":    func(s *reputation.Stats) { s.SuspendedAt = nil },
+		"disqualified":  func(s *reputation.Stats) { s.DisqualifiedAt = &later },
+		"audit history": func(s *reputation.Stats) { s.AuditHistory = nil },
+		"generation":    func(s *reputation.Stats) { s.Generation = 1 },
+		"joined at":     func(s *reputation.Stats) { s.JoinedAt = later },
+		"address":       func(s *reputation.Stats) { s.SatelliteAddress = "us1.storj.io:7777" },
+		"dq reason":     func(s *reputation.Stats) { s.DisqualificationReason = reputation.DisqualificationReasonAuditFailure },
+		"under review":  func(s *reputation.Stats) { s.OfflineUnderReviewAt = &now },
+		"offline susp.": func(s *reputation.Stats) { s.OfflineSuspendedAt = &now },
+	} {
+		other := stats
+		modify(&other)
+		assert.False(t, stats.EqualIgnoringTimestamps(other), name)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import "time"
+
+// ExportOption customizes how stats are exported by WriteCSV and NewStatsJSON.
+type ExportOption func(opts *exportOptions)
+
+// exportOptions are the export settings applied by ExportOption.
+type exportOptions struct {
+	location *time.Location
+}
+
+// WithLocation exports timestamps in loc instead of UTC, a nil loc means UTC.
+func WithLocation(loc *time.Location) ExportOption {
+	return func(opts *exportOptions) { opts.location = loc }
+}
+
+// newExportOptions applies opts on top of the defaults.
+func newExportOptions(opts []ExportOption) exportOptions {
+	options := exportOptions{location: time.UTC}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.location == nil {
+		options.location = time.UTC
+	}
+	return options
+}
+
+// in converts t to the export location, zero timestamps are kept as they are.
+func (opts exportOptions) in(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.In(opts.location)
+}
+
+// inPtr converts t to the export location, nil stays nil.
+func (opts exportOptions) inPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	converted := opts.in(*t)
+	return &converted
+}
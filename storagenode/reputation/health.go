@@ -0,0 +1,174 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"math"
+	"sort"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+)
+
+// ErrInvalidHealthWeights is returned when health weights are negative or all zero.
+var ErrInvalidHealthWeights = errs.Class("invalid health weights")
+
+// Default weights of the scores in the health of a single satellite, they add up to 1.
+// The audit score has the largest weight, because disqualification is permanent.
+const (
+	HealthAuditWeight   = 0.5
+	HealthUnknownWeight = 0.25
+	HealthOnlineWeight  = 0.25
+)
+
+// HealthWeights defines how the scores of a satellite are combined into its health.
+// Weights are relative to each other, they are normalized to add up to 1.
+type HealthWeights struct {
+	Audit   float64
+	Unknown float64
+	Online  float64
+}
+
+// DefaultHealthWeights returns HealthAuditWeight, HealthUnknownWeight and HealthOnlineWeight.
+func DefaultHealthWeights() HealthWeights {
+	return HealthWeights{
+		Audit:   HealthAuditWeight,
+		Unknown: HealthUnknownWeight,
+		Online:  HealthOnlineWeight,
+	}
+}
+
+// NewHealthWeights creates health weights and checks that they are valid.
+func NewHealthWeights(audit, unknown, online float64) (HealthWeights, error) {
+	weights := HealthWeights{
+		Audit:   audit,
+		Unknown: unknown,
+		Online:  online,
+	}
+	return weights, weights.Validate()
+}
+
+// Validate checks that weights are non-negative and at least one of them is positive.
+func (w HealthWeights) Validate() error {
+	if w.Audit < 0 || w.Unknown < 0 || w.Online < 0 {
+		return ErrInvalidHealthWeights.New("weights must be non-negative: %+v", w)
+	}
+	if w.Audit+w.Unknown+w.Online <= 0 {
+		return ErrInvalidHealthWeights.New("at least one weight must be positive")
+	}
+	return nil
+}
+
+// Score returns the health of the satellite in [0, 1] as the normalized weighted
+// sum of the audit score, unknown audit score and online score.
+// It returns 0 for weights which don't pass Validate.
+func (w HealthWeights) Score(s Stats) float64 {
+	if w.Validate() != nil {
+		return 0
+	}
+	total := w.Audit + w.Unknown + w.Online
+	return (w.Audit*s.Audit.ComputedScore() + w.Unknown*s.Audit.UnknownScore + w.Online*s.OnlineScore) / total
+}
+
+// HealthScore computes the health of a single satellite in [0, 1].
+type HealthScore func(s Stats) float64
+
+// HealthOption customizes how OverallHealth and PrioritizeSatellites compute the health.
+type HealthOption func(score *HealthScore)
+
+// WithHealthWeights computes the health of satellites with weights instead of DefaultHealthWeights.
+func WithHealthWeights(weights HealthWeights) HealthOption {
+	return func(score *HealthScore) { *score = weights.Score }
+}
+
+// WithHealthScore computes the health of satellites with fn instead of DefaultHealthWeights.
+func WithHealthScore(fn HealthScore) HealthOption {
+	return func(score *HealthScore) { *score = fn }
+}
+
+// HealthSummary aggregates reputation across satellites.
+//
+// Satellites which disqualified the node are lost and are only counted in Lost,
+// all other fields describe the remaining, recoverable, satellites.
+type HealthSummary struct {
+	MinAuditScore  float64
+	MinOnlineScore float64
+	// Suspended is the number of satellites which suspended the node for unknown audit errors or for being offline.
+	Suspended int
+	// Lost is the number of satellites which disqualified the node.
+	Lost int
+	// Health is a percentage in [0, 100] of the least healthy satellite. By default the health of a
+	// satellite is the audit score, unknown audit score and online score weighted by DefaultHealthWeights.
+	Health float64
+}
+
+// OverallHealth summarizes the reputation of the node across all satellites.
+// Without any recoverable satellites the minimal scores are 1 and health is 100.
+func OverallHealth(stats []Stats, opts ...HealthOption) HealthSummary {
+	score := HealthScore(DefaultHealthWeights().Score)
+	for _, opt := range opts {
+		opt(&score)
+	}
+
+	summary := HealthSummary{
+		MinAuditScore:  1,
+		MinOnlineScore: 1,
+		Health:         100,
+	}
+
+	for _, s := range stats {
+		if s.DisqualifiedAt != nil {
+			summary.Lost++
+			continue
+		}
+		if s.SuspendedAt != nil || s.OfflineSuspendedAt != nil {
+			summary.Suspended++
+		}
+
+		summary.MinAuditScore = math.Min(summary.MinAuditScore, s.Audit.ComputedScore())
+		summary.MinOnlineScore = math.Min(summary.MinOnlineScore, s.OnlineScore)
+
+		summary.Health = math.Min(summary.Health, 100*score(s))
+	}
+
+	return summary
+}
+
+// PrioritizeSatellites returns IDs of the satellites ordered from the healthiest to the least healthy,
+// so work can be directed away from satellites where the node is at risk. Satellites which disqualified
+// the node are left out and satellites with equal health are ordered by ID. By default the health of a
+// satellite is the audit score, unknown audit score and online score weighted by DefaultHealthWeights.
+func PrioritizeSatellites(stats []Stats, opts ...HealthOption) []storj.NodeID {
+	score := HealthScore(DefaultHealthWeights().Score)
+	for _, opt := range opts {
+		opt(&score)
+	}
+
+	type prioritized struct {
+		satelliteID storj.NodeID
+		health      float64
+	}
+
+	satellites := make([]prioritized, 0, len(stats))
+	for _, s := range stats {
+		if s.DisqualifiedAt != nil {
+			continue
+		}
+		satellites = append(satellites, prioritized{satelliteID: s.SatelliteID, health: score(s)})
+	}
+
+	sort.Slice(satellites, func(i, k int) bool {
+		if satellites[i].health != satellites[k].health {
+			return satellites[i].health > satellites[k].health
+		}
+		return satellites[i].satelliteID.Less(satellites[k].satelliteID)
+	})
+
+	ids := make([]storj.NodeID, 0, len(satellites))
+	for _, satellite := range satellites {
+		ids = append(ids, satellite.satelliteID)
+	}
+	return ids
+}
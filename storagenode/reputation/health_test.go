@@ -0,0 +1,144 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestOverallHealth(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, reputation.HealthSummary{
+		MinAuditScore:  1,
+		MinOnlineScore: 1,
+		Health:         100,
+	}, reputation.OverallHealth(nil))
+
+	summary := reputation.OverallHealth([]reputation.Stats{
+		{
+			Audit:       reputation.Metric{Alpha: 1, Score: 1, UnknownScore: 1},
+			OnlineScore: 1,
+		},
+		{
+			Audit:       reputation.Metric{Alpha: 0.9, Beta: 0.1, Score: 0.9, UnknownScore: 0.8},
+			OnlineScore: 0.7,
+			SuspendedAt: &now,
+		},
+		{
+			Audit:              reputation.Metric{Alpha: 0.95, Beta: 0.05, Score: 0.95, UnknownScore: 1},
+			OnlineScore:        0.5,
+			OfflineSuspendedAt: &now,
+		},
+		{
+			// disqualified satellites don't affect the health.
+			Audit:          reputation.Metric{Alpha: 0.1, Beta: 0.9, Score: 0.1},
+			DisqualifiedAt: &now,
+		},
+	})
+
+	assert.Equal(t, 0.9, summary.MinAuditScore)
+	assert.Equal(t, 0.5, summary.MinOnlineScore)
+	assert.Equal(t, 2, summary.Suspended)
+	assert.Equal(t, 1, summary.Lost)
+	assert.InDelta(t, 82.5, summary.Health, 1e-9)
+
+	summary = reputation.OverallHealth([]reputation.Stats{{DisqualifiedAt: &now}})
+	assert.Equal(t, 1, summary.Lost)
+	assert.Equal(t, float64(100), summary.Health)
+}
+
+func TestOverallHealthWeights(t *testing.T) {
+	stats := []reputation.Stats{{
+		Audit:       reputation.Metric{Alpha: 1, Score: 1, UnknownScore: 1},
+		OnlineScore: 0.5,
+	}}
+
+	assert.InDelta(t, 87.5, reputation.OverallHealth(stats).Health, 1e-9)
+	assert.InDelta(t, 87.5, reputation.OverallHealth(stats, reputation.WithHealthWeights(reputation.DefaultHealthWeights())).Health, 1e-9)
+
+	// weights are normalized, so only the online score matters.
+	weights, err := reputation.NewHealthWeights(0, 0, 3)
+	require.NoError(t, err)
+	assert.InDelta(t, 50, reputation.OverallHealth(stats, reputation.WithHealthWeights(weights)).Health, 1e-9)
+
+	weights, err = reputation.NewHealthWeights(1, 1, 2)
+	require.NoError(t, err)
+	assert.InDelta(t, 75, reputation.OverallHealth(stats, reputation.WithHealthWeights(weights)).Health, 1e-9)
+
+	custom := reputation.WithHealthScore(func(s reputation.Stats) float64 { return s.OnlineScore * s.OnlineScore })
+	assert.InDelta(t, 25, reputation.OverallHealth(stats, custom).Health, 1e-9)
+
+	_, err = reputation.NewHealthWeights(-1, 1, 1)
+	require.True(t, reputation.ErrInvalidHealthWeights.Has(err))
+	_, err = reputation.NewHealthWeights(0, 0, 0)
+	require.True(t, reputation.ErrInvalidHealthWeights.Has(err))
+	assert.Equal(t, float64(0), reputation.HealthWeights{}.Score(stats[0]))
+}
+
+func TestPrioritizeSatellites(t *testing.T) {
+	now := time.Now()
+	healthy, unknown, offline, disqualified := testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+	tieA, tieB := testrand.NodeID(), testrand.NodeID()
+	if tieB.Less(tieA) {
+		tieA, tieB = tieB, tieA
+	}
+
+	assert.Empty(t, reputation.PrioritizeSatellites(nil))
+
+	stats := []reputation.Stats{
+		{
+			SatelliteID: offline,
+			Audit:       reputation.Metric{Alpha: 1, Score: 1, UnknownScore: 1},
+			OnlineScore: 0.5,
+		},
+		{
+			SatelliteID:    disqualified,
+			Audit:          reputation.Metric{Alpha: 1, Score: 1, UnknownScore: 1},
+			OnlineScore:    1,
+			DisqualifiedAt: &now,
+		},
+		{
+			SatelliteID: tieB,
+			Audit:       reputation.Metric{Alpha: 0.9, Beta: 0.1, Score: 0.9, UnknownScore: 1},
+			OnlineScore: 1,
+		},
+		{
+			SatelliteID: unknown,
+			Audit:       reputation.Metric{Alpha: 1, Score: 1, UnknownScore: 0.7},
+			OnlineScore: 1,
+		},
+		{
+			SatelliteID: healthy,
+			Audit:       reputation.Metric{Alpha: 1, Score: 1, UnknownScore: 1},
+			OnlineScore: 1,
+		},
+		{
+			SatelliteID: tieA,
+			Audit:       reputation.Metric{Alpha: 0.9, Beta: 0.1, Score: 0.9, UnknownScore: 1},
+			OnlineScore: 1,
+		},
+	}
+
+	// healths are 1, 0.95, 0.95, 0.925 and 0.875, the disqualified satellite is left out.
+	assert.Equal(t, []storj.NodeID{healthy, tieA, tieB, unknown, offline}, reputation.PrioritizeSatellites(stats))
+
+	// only the online score matters, so the satellites with equal online scores are ordered by ID.
+	weights, err := reputation.NewHealthWeights(0, 0, 1)
+	require.NoError(t, err)
+	prioritized := reputation.PrioritizeSatellites(stats, reputation.WithHealthWeights(weights))
+	require.Len(t, prioritized, 5)
+	assert.Equal(t, offline, prioritized[4])
+	for i := 1; i < 4; i++ {
+		assert.True(t, prioritized[i-1].Less(prioritized[i]))
+	}
+}
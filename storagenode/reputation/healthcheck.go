@@ -0,0 +1,122 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"storj.io/common/storj"
+)
+
+// DegradedPolicy defines on how many satellites the node has to be disqualified or
+// suspended to be reported as degraded by HealthHandler.
+type DegradedPolicy int
+
+const (
+	// DegradedMajority reports the node as degraded on more than half of the satellites, it's the default.
+	DegradedMajority DegradedPolicy = iota
+	// DegradedAny reports the node as degraded on any satellite.
+	DegradedAny
+	// DegradedAll reports the node as degraded on all satellites.
+	DegradedAll
+)
+
+// degraded returns whether problematic out of total satellites make the node degraded.
+// A node without stats of any satellite isn't degraded.
+func (policy DegradedPolicy) degraded(problematic, total int) bool {
+	switch policy {
+	case DegradedAny:
+		return problematic > 0
+	case DegradedAll:
+		return total > 0 && problematic == total
+	default:
+		return problematic*2 > total
+	}
+}
+
+// HealthHandlerOption customizes the response of HealthHandler.
+type HealthHandlerOption func(handler *healthHandler)
+
+// WithDegradedPolicy sets on how many satellites the node is degraded.
+func WithDegradedPolicy(policy DegradedPolicy) HealthHandlerOption {
+	return func(handler *healthHandler) { handler.policy = policy }
+}
+
+// HealthResponse is the JSON body returned by HealthHandler.
+type HealthResponse struct {
+	Healthy     bool                `json:"healthy"`
+	Satellites  int                 `json:"satellites"`
+	Problematic []ProblematicStatus `json:"problematic"`
+}
+
+// ProblematicStatus describes a satellite which disqualified or suspended the node.
+type ProblematicStatus struct {
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	// Status is the label of Stats.StatusLabel, e.g. "Suspended (offline)".
+	Status string `json:"status"`
+	// Severity is the overall severity of the scores classified against the thresholds.
+	Severity string `json:"severity"`
+}
+
+// healthHandler implements HealthHandler.
+type healthHandler struct {
+	db         DB
+	thresholds Thresholds
+	policy     DegradedPolicy
+}
+
+// HealthHandler creates a handler for liveness probes, it responds with 200 when the node is
+// healthy and with 503 when it's degraded according to the policy, DegradedMajority by default.
+// The JSON body lists the satellites which disqualified or suspended the node.
+func HealthHandler(db DB, thresholds Thresholds, opts ...HealthHandlerOption) http.Handler {
+	handler := &healthHandler{
+		db:         db,
+		thresholds: thresholds,
+	}
+	for _, opt := range opts {
+		opt(handler)
+	}
+	return handler
+}
+
+// ServeHTTP classifies stats of all satellites and responds with the health of the node.
+func (handler *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	all, err := handler.db.All(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	reports, err := handler.thresholds.classifyAll(ctx, handler.db, all)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := HealthResponse{
+		Satellites:  len(all),
+		Problematic: []ProblematicStatus{},
+	}
+	for _, stats := range all {
+		if stats.DisqualifiedAt == nil && stats.SuspendedAt == nil && stats.OfflineSuspendedAt == nil {
+			continue
+		}
+		response.Problematic = append(response.Problematic, ProblematicStatus{
+			SatelliteID: stats.SatelliteID,
+			Status:      stats.StatusLabel(),
+			Severity:    reports[stats.SatelliteID].Overall.String(),
+		})
+	}
+	response.Healthy = !handler.policy.degraded(len(response.Problematic), len(all))
+
+	w.Header().Set("Content-Type", "application/json")
+	if !response.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	err = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+// failingDB fails reading all stats.
+type failingDB struct {
+	*reputation.MemoryDB
+}
+
+func (db *failingDB) All(ctx context.Context) ([]reputation.Stats, error) {
+	return nil, errs.New("database is locked")
+}
+
+func TestHealthHandler(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	check := func(t *testing.T, handler http.Handler) (int, reputation.HealthResponse) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		var response reputation.HealthResponse
+		if recorder.Code != http.StatusInternalServerError {
+			require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		}
+		return recorder.Code, response
+	}
+
+	now := time.Now()
+	db := reputation.NewMemory()
+	thresholds := reputation.DefaultThresholds()
+
+	// a node without stats is healthy.
+	code, response := check(t, reputation.HealthHandler(db, thresholds))
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, response.Healthy)
+	require.Empty(t, response.Problematic)
+
+	suspended := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.5, OfflineSuspendedAt: &now}
+	require.NoError(t, db.Store(ctx, suspended))
+	require.NoError(t, db.Store(ctx, reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 1}))
+	require.NoError(t, db.Store(ctx, reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 1}))
+
+	t.Run("healthy", func(t *testing.T) {
+		code, response := check(t, reputation.HealthHandler(db, thresholds))
+		require.Equal(t, http.StatusOK, code)
+		require.True(t, response.Healthy)
+		require.Equal(t, 3, response.Satellites)
+		require.Equal(t, []reputation.ProblematicStatus{{
+			SatelliteID: suspended.SatelliteID,
+			Status:      "Suspended (offline)",
+			Severity:    "critical",
+		}}, response.Problematic)
+
+		code, _ = check(t, reputation.HealthHandler(db, thresholds, reputation.WithDegradedPolicy(reputation.DegradedAll)))
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("degraded", func(t *testing.T) {
+		code, response := check(t, reputation.HealthHandler(db, thresholds, reputation.WithDegradedPolicy(reputation.DegradedAny)))
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.False(t, response.Healthy)
+		require.Len(t, response.Problematic, 1)
+	})
+
+	disqualified := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 1, DisqualifiedAt: &now}
+	require.NoError(t, db.Store(ctx, disqualified))
+
+	t.Run("half isn't majority", func(t *testing.T) {
+		code, response := check(t, reputation.HealthHandler(db, thresholds))
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, response.Problematic, 2)
+	})
+
+	t.Run("majority", func(t *testing.T) {
+		require.NoError(t, db.Store(ctx, reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 1, SuspendedAt: &now}))
+
+		code, response := check(t, reputation.HealthHandler(db, thresholds))
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.False(t, response.Healthy)
+		require.Equal(t, 5, response.Satellites)
+		require.Len(t, response.Problematic, 3)
+	})
+
+	t.Run("min windows", func(t *testing.T) {
+		// All doesn't include the audit history, so the severity is classified from Statuses.
+		thresholds := reputation.DefaultThresholds()
+		thresholds.MinWindows = 1
+
+		code, response := check(t, reputation.HealthHandler(db, thresholds))
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		for _, status := range response.Problematic {
+			require.Equal(t, "safe", status.Severity)
+		}
+
+		history := &pb.AuditHistory{Windows: []*pb.AuditWindow{
+			{WindowStart: now.Add(-time.Hour), TotalCount: 1},
+			{WindowStart: now, TotalCount: 1},
+		}}
+		withHistory := suspended
+		withHistory.AuditHistory = history
+		require.NoError(t, db.Store(ctx, withHistory))
+
+		_, response = check(t, reputation.HealthHandler(db, thresholds))
+		for _, status := range response.Problematic {
+			if status.SatelliteID == suspended.SatelliteID {
+				require.Equal(t, "critical", status.Severity)
+			} else {
+				require.Equal(t, "safe", status.Severity)
+			}
+		}
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		code, _ := check(t, reputation.HealthHandler(&failingDB{MemoryDB: db}, thresholds))
+		require.Equal(t, http.StatusInternalServerError, code)
+	})
+}
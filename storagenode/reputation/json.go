@@ -0,0 +1,291 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+)
+
+// ErrInvalidJSON is returned when stats read by ReadJSON are malformed.
+var ErrInvalidJSON = errs.Class("invalid reputation json")
+
+// StatsJSON is the API representation of reputation stats.
+type StatsJSON struct {
+	SatelliteID      storj.NodeID `json:"satelliteId"`
+	SatelliteAddress string       `json:"satelliteAddress"`
+
+	// Uptime and Audit are as stored, while the scores below are normalized.
+	Uptime          Metric  `json:"uptime"`
+	Audit           Metric  `json:"audit"`
+	UptimeScore     float64 `json:"uptimeScore"`
+	AuditScore      float64 `json:"auditScore"`
+	SuspensionScore float64 `json:"suspensionScore"`
+	OnlineScore     float64 `json:"onlineScore"`
+
+	DisqualifiedAt       *time.Time        `json:"disqualifiedAt"`
+	SuspendedAt          *time.Time        `json:"suspendedAt"`
+	OfflineSuspendedAt   *time.Time        `json:"offlineSuspendedAt"`
+	OfflineUnderReviewAt *time.Time        `json:"offlineUnderReviewAt"`
+	AuditHistory         *AuditHistoryJSON `json:"auditHistory"`
+
+	DisqualifiedObservedAt *time.Time             `json:"disqualifiedObservedAt"`
+	DisqualificationReason DisqualificationReason `json:"disqualificationReason"`
+	Generation             int64                  `json:"generation"`
+	LastContactAt          *time.Time             `json:"lastContactAt"`
+	LastAuditAt            *time.Time             `json:"lastAuditAt"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+	JoinedAt  time.Time `json:"joinedAt"`
+	// Stale is set when the stats weren't fetched from the satellite recently.
+	Stale bool `json:"stale"`
+}
+
+// AuditHistoryJSON is the API representation of audit history.
+type AuditHistoryJSON struct {
+	Score   float64           `json:"score"`
+	Windows []AuditWindowJSON `json:"windows"`
+}
+
+// AuditWindowJSON is the API representation of a single audit history window.
+type AuditWindowJSON struct {
+	WindowStart    time.Time `json:"windowStart"`
+	OnlineCount    int32     `json:"onlineCount"`
+	TotalCount     int32     `json:"totalCount"`
+	OnlineFraction float64   `json:"onlineFraction"`
+}
+
+// NewStatsJSON creates the API representation of reputation stats.
+// Timestamps are in UTC unless WithLocation is given.
+func NewStatsJSON(stats Stats, opts ...ExportOption) StatsJSON {
+	options := newExportOptions(opts)
+	return StatsJSON{
+		SatelliteID:          stats.SatelliteID,
+		SatelliteAddress:     stats.SatelliteAddress,
+		Uptime:               stats.Uptime,
+		Audit:                stats.Audit,
+		UptimeScore:          stats.Uptime.Normalized().Score,
+		AuditScore:           stats.Audit.ComputedScore(),
+		SuspensionScore:      stats.Audit.Normalized().UnknownScore,
+		OnlineScore:          stats.OnlineScore,
+		DisqualifiedAt:       options.inPtr(stats.DisqualifiedAt),
+		SuspendedAt:          options.inPtr(stats.SuspendedAt),
+		OfflineSuspendedAt:   options.inPtr(stats.OfflineSuspendedAt),
+		OfflineUnderReviewAt: options.inPtr(stats.OfflineUnderReviewAt),
+		AuditHistory:         newAuditHistoryJSON(stats.AuditHistory, options),
+
+		DisqualifiedObservedAt: options.inPtr(stats.DisqualifiedObservedAt),
+		DisqualificationReason: stats.DisqualificationReason,
+		Generation:             stats.Generation,
+		LastContactAt:          options.inPtr(stats.LastContactAt),
+		LastAuditAt:            options.inPtr(stats.LastAuditAt),
+		UpdatedAt:              options.in(stats.UpdatedAt),
+		JoinedAt:               options.in(stats.JoinedAt),
+		Stale:                  stats.Stale,
+	}
+}
+
+// newAuditHistoryJSON flattens audit history protobuf, returns nil when history is nil.
+func newAuditHistoryJSON(auditHistory *pb.AuditHistory, options exportOptions) *AuditHistoryJSON {
+	if auditHistory == nil {
+		return nil
+	}
+
+	history := &AuditHistoryJSON{
+		Score:   auditHistory.Score,
+		Windows: make([]AuditWindowJSON, 0, len(auditHistory.Windows)),
+	}
+	for _, window := range auditHistory.Windows {
+		var onlineFraction float64
+		if window.TotalCount > 0 {
+			onlineFraction = float64(window.OnlineCount) / float64(window.TotalCount)
+		}
+
+		history.Windows = append(history.Windows, AuditWindowJSON{
+			WindowStart:    options.in(window.WindowStart),
+			OnlineCount:    window.OnlineCount,
+			TotalCount:     window.TotalCount,
+			OnlineFraction: onlineFraction,
+		})
+	}
+
+	return history
+}
+
+// MarshalJSON implements json.Marshaler using the StatsJSON representation.
+func (s Stats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(NewStatsJSON(s))
+}
+
+// Stats converts the API representation back to reputation stats. The derived scores
+// are ignored, as they are computed from Uptime and Audit, and so is Stale, which is
+// derived when the stats are read.
+func (s StatsJSON) Stats() Stats {
+	return Stats{
+		SatelliteID:          s.SatelliteID,
+		SatelliteAddress:     s.SatelliteAddress,
+		Uptime:               s.Uptime,
+		Audit:                s.Audit,
+		OnlineScore:          s.OnlineScore,
+		DisqualifiedAt:       s.DisqualifiedAt,
+		SuspendedAt:          s.SuspendedAt,
+		OfflineSuspendedAt:   s.OfflineSuspendedAt,
+		OfflineUnderReviewAt: s.OfflineUnderReviewAt,
+		AuditHistory:         auditHistoryFromJSON(s.AuditHistory),
+
+		DisqualifiedObservedAt: s.DisqualifiedObservedAt,
+		DisqualificationReason: s.DisqualificationReason,
+		Generation:             s.Generation,
+		LastContactAt:          s.LastContactAt,
+		LastAuditAt:            s.LastAuditAt,
+		UpdatedAt:              s.UpdatedAt,
+		JoinedAt:               s.JoinedAt,
+	}
+}
+
+// auditHistoryFromJSON converts audit history back to protobuf, returns nil when history is nil.
+func auditHistoryFromJSON(history *AuditHistoryJSON) *pb.AuditHistory {
+	if history == nil {
+		return nil
+	}
+
+	auditHistory := &pb.AuditHistory{
+		Score:   history.Score,
+		Windows: make([]*pb.AuditWindow, 0, len(history.Windows)),
+	}
+	for _, window := range history.Windows {
+		auditHistory.Windows = append(auditHistory.Windows, &pb.AuditWindow{
+			WindowStart: window.WindowStart,
+			OnlineCount: window.OnlineCount,
+			TotalCount:  window.TotalCount,
+		})
+	}
+	return auditHistory
+}
+
+// WriteJSON writes stats to w as a JSON array of StatsJSON, which can be read back with ReadJSON.
+func WriteJSON(w io.Writer, stats []Stats, opts ...ExportOption) error {
+	writer := NewJSONWriter(w, opts...)
+	for _, s := range stats {
+		if err := writer.Write(s); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// JSONWriter writes stats as they come in the format of WriteJSON, e.g. from DB.ForEach,
+// so they don't have to be held in memory at once.
+type JSONWriter struct {
+	w       io.Writer
+	opts    []ExportOption
+	written bool
+}
+
+// NewJSONWriter creates a new JSONWriter, nothing is written to w until Write or Close.
+func NewJSONWriter(w io.Writer, opts ...ExportOption) *JSONWriter {
+	return &JSONWriter{w: w, opts: opts}
+}
+
+// Write writes s as the next element of the array.
+func (writer *JSONWriter) Write(s Stats) error {
+	// elements are indented as if the whole array was encoded at once.
+	data, err := json.MarshalIndent(NewStatsJSON(s, writer.opts...), "  ", "  ")
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	separator := ",\n  "
+	if !writer.written {
+		separator = "[\n  "
+	}
+	writer.written = true
+
+	_, err = io.WriteString(writer.w, separator+string(data))
+	return errs.Wrap(err)
+}
+
+// Close ends the array, it doesn't close the underlying writer.
+func (writer *JSONWriter) Close() error {
+	end := "\n]\n"
+	if !writer.written {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(writer.w, end)
+	return errs.Wrap(err)
+}
+
+// ExportJSON writes stats of all satellites in db to w in the format of WriteJSON. Unlike stats
+// from DB.ForEach they include the audit history, so importing the export doesn't lose it.
+// Stats are read one satellite at a time, so they aren't held in memory at once.
+func ExportJSON(ctx context.Context, w io.Writer, db DB, opts ...ExportOption) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	satelliteIDs, err := db.SatelliteIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	writer := NewJSONWriter(w, opts...)
+	for _, satelliteID := range satelliteIDs {
+		stats, err := db.Get(ctx, satelliteID)
+		if err != nil {
+			// the stats were deleted after the IDs were read.
+			if errors.Is(err, ErrNoStats) {
+				continue
+			}
+			return err
+		}
+		if err := writer.Write(*stats); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// ReadJSON reads stats written by WriteJSON. The whole input is rejected when it contains
+// fields which aren't part of StatsJSON, scores outside of [0, 1], invalid audit history,
+// or stats without or with a duplicate satellite ID.
+func ReadJSON(r io.Reader) ([]Stats, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var list []StatsJSON
+	if err := decoder.Decode(&list); err != nil {
+		return nil, ErrInvalidJSON.Wrap(err)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, ErrInvalidJSON.New("unexpected data after stats")
+	}
+
+	seen := make(map[storj.NodeID]struct{}, len(list))
+	statsList := make([]Stats, 0, len(list))
+	for i, s := range list {
+		stats := s.Stats()
+		if stats.SatelliteID.IsZero() {
+			return nil, ErrInvalidJSON.New("stats %d: missing satellite id", i)
+		}
+		if _, ok := seen[stats.SatelliteID]; ok {
+			return nil, ErrInvalidJSON.New("stats %d: duplicate satellite id %s", i, stats.SatelliteID)
+		}
+		seen[stats.SatelliteID] = struct{}{}
+
+		if err := CheckScores(&stats); err != nil {
+			return nil, ErrInvalidJSON.New("stats %d: %v", i, err)
+		}
+		if err := ValidateAuditHistory(stats.AuditHistory); err != nil {
+			return nil, ErrInvalidJSON.New("stats %d: %v", i, err)
+		}
+		statsList = append(statsList, stats)
+	}
+	return statsList, nil
+}
@@ -0,0 +1,232 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestStatsMarshalJSON(t *testing.T) {
+	timestamp := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("nil fields", func(t *testing.T) {
+		data, err := json.Marshal(reputation.Stats{SatelliteID: testrand.NodeID()})
+		require.NoError(t, err)
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &decoded))
+
+		for _, field := range []string{"disqualifiedAt", "suspendedAt", "offlineSuspendedAt", "offlineUnderReviewAt", "auditHistory"} {
+			value, ok := decoded[field]
+			assert.True(t, ok, field)
+			assert.Nil(t, value, field)
+		}
+	})
+
+	t.Run("audit history", func(t *testing.T) {
+		stats := reputation.Stats{
+			SatelliteID: testrand.NodeID(),
+			Audit: reputation.Metric{
+				Alpha:        0.9,
+				Beta:         0.1,
+				Score:        0.9,
+				UnknownScore: 0.8,
+			},
+			OnlineScore:    0.7,
+			DisqualifiedAt: &timestamp,
+			AuditHistory: &pb.AuditHistory{
+				Score: 0.5,
+				Windows: []*pb.AuditWindow{
+					{WindowStart: timestamp, OnlineCount: 3, TotalCount: 4},
+					{WindowStart: timestamp.Add(12 * time.Hour), OnlineCount: 0, TotalCount: 0},
+				},
+			},
+		}
+
+		data, err := json.Marshal(stats)
+		require.NoError(t, err)
+
+		var decoded reputation.StatsJSON
+		require.NoError(t, json.Unmarshal(data, &decoded))
+
+		assert.Equal(t, stats.SatelliteID, decoded.SatelliteID)
+		assert.Equal(t, 0.9, decoded.AuditScore)
+		assert.Equal(t, 0.8, decoded.SuspensionScore)
+		assert.Equal(t, 0.7, decoded.OnlineScore)
+		require.NotNil(t, decoded.DisqualifiedAt)
+		assert.True(t, decoded.DisqualifiedAt.Equal(timestamp))
+
+		require.NotNil(t, decoded.AuditHistory)
+		assert.Equal(t, 0.5, decoded.AuditHistory.Score)
+		require.Len(t, decoded.AuditHistory.Windows, 2)
+		assert.Equal(t, 0.75, decoded.AuditHistory.Windows[0].OnlineFraction)
+		assert.EqualValues(t, 3, decoded.AuditHistory.Windows[0].OnlineCount)
+		assert.EqualValues(t, 4, decoded.AuditHistory.Windows[0].TotalCount)
+		assert.Equal(t, float64(0), decoded.AuditHistory.Windows[1].OnlineFraction)
+	})
+	t.Run("location", func(t *testing.T) {
+		loc := time.FixedZone("UTC-5", -5*60*60)
+		stats := reputation.Stats{
+			SatelliteID:    testrand.NodeID(),
+			DisqualifiedAt: &timestamp,
+			AuditHistory: &pb.AuditHistory{
+				Windows: []*pb.AuditWindow{{WindowStart: timestamp}},
+			},
+			UpdatedAt: timestamp,
+		}
+
+		data, err := json.Marshal(reputation.NewStatsJSON(stats, reputation.WithLocation(loc)))
+		require.NoError(t, err)
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &decoded))
+
+		const expected = "2021-01-01T22:04:05-05:00"
+		assert.Equal(t, expected, decoded["disqualifiedAt"])
+		assert.Equal(t, expected, decoded["updatedAt"])
+		assert.Nil(t, decoded["suspendedAt"])
+		assert.Equal(t, "0001-01-01T00:00:00Z", decoded["joinedAt"])
+
+		windows := decoded["auditHistory"].(map[string]interface{})["windows"].([]interface{})
+		require.Len(t, windows, 1)
+		assert.Equal(t, expected, windows[0].(map[string]interface{})["windowStart"])
+
+		// stats are exported in UTC by default.
+		data, err = json.Marshal(reputation.NewStatsJSON(reputation.Stats{UpdatedAt: timestamp.In(loc)}))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, "2021-01-02T03:04:05Z", decoded["updatedAt"])
+	})
+}
+
+func TestReadJSON(t *testing.T) {
+	timestamp := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	stats := []reputation.Stats{
+		{
+			SatelliteID:      testrand.NodeID(),
+			SatelliteAddress: "us1.storj.io:7777",
+			Audit:            reputation.Metric{TotalCount: 10, SuccessCount: 9, Alpha: 9, Beta: 1, Score: 0.9},
+			OnlineScore:      0.95,
+			SuspendedAt:      &timestamp,
+			AuditHistory: &pb.AuditHistory{
+				Score:   0.5,
+				Windows: []*pb.AuditWindow{{WindowStart: timestamp, OnlineCount: 1, TotalCount: 2}},
+			},
+			DisqualificationReason: reputation.DisqualificationReasonUnknown,
+			Generation:             2,
+			UpdatedAt:              timestamp,
+			JoinedAt:               timestamp.AddDate(-1, 0, 0),
+		},
+		{SatelliteID: testrand.NodeID()},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, reputation.WriteJSON(&buf, stats))
+
+	read, err := reputation.ReadJSON(&buf)
+	require.NoError(t, err)
+	require.Len(t, read, 2)
+	for i := range stats {
+		assert.True(t, stats[i].EqualIgnoringTimestamps(read[i]), i)
+		assert.True(t, stats[i].UpdatedAt.Equal(read[i].UpdatedAt), i)
+	}
+
+	for _, tt := range []struct {
+		name string
+		data string
+	}{
+		{"not an array", `{}`},
+		{"unknown field", `[{"satelliteId": "` + stats[1].SatelliteID.String() + `", "extra": 1}]`},
+		{"trailing data", `[] []`},
+		{"invalid timestamp", `[{"satelliteId": "` + stats[1].SatelliteID.String() + `", "updatedAt": "yesterday"}]`},
+		{"score out of range", `[{"satelliteId": "` + stats[1].SatelliteID.String() + `", "onlineScore": 10}]`},
+		{"missing satellite id", `[{"onlineScore": 1}]`},
+		{"duplicate satellite id", `[{"satelliteId": "` + stats[1].SatelliteID.String() + `"}, {"satelliteId": "` + stats[1].SatelliteID.String() + `"}]`},
+	} {
+		_, err := reputation.ReadJSON(strings.NewReader(tt.data))
+		assert.True(t, reputation.ErrInvalidJSON.Has(err), "%s: %v", tt.name, err)
+	}
+}
+
+func TestJSONWriter(t *testing.T) {
+	timestamp := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	stats := []reputation.Stats{
+		{SatelliteID: testrand.NodeID(), OnlineScore: 0.95, SuspendedAt: &timestamp},
+		{SatelliteID: testrand.NodeID()},
+	}
+
+	// streamed stats are written the same as the whole array encoded at once.
+	for _, list := range [][]reputation.Stats{nil, stats[:1], stats} {
+		expected := make([]reputation.StatsJSON, 0, len(list))
+		for _, s := range list {
+			expected = append(expected, reputation.NewStatsJSON(s))
+		}
+		var encoded bytes.Buffer
+		encoder := json.NewEncoder(&encoded)
+		encoder.SetIndent("", "  ")
+		require.NoError(t, encoder.Encode(expected))
+
+		var streamed bytes.Buffer
+		writer := reputation.NewJSONWriter(&streamed)
+		for _, s := range list {
+			require.NoError(t, writer.Write(s))
+		}
+		require.NoError(t, writer.Close())
+
+		assert.Equal(t, encoded.String(), streamed.String())
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		timestamp := time.Date(2021, 1, 2, 3, 0, 0, 0, time.UTC)
+		stats := reputation.Stats{
+			SatelliteID: testrand.NodeID(),
+			OnlineScore: 0.5,
+			AuditHistory: &pb.AuditHistory{
+				Score: 0.5,
+				Windows: []*pb.AuditWindow{
+					{WindowStart: timestamp, OnlineCount: 1, TotalCount: 2},
+					{WindowStart: timestamp.Add(time.Hour), OnlineCount: 2, TotalCount: 2},
+				},
+			},
+		}
+		require.NoError(t, db.Reputation().Store(ctx, stats))
+
+		var exported bytes.Buffer
+		require.NoError(t, reputation.ExportJSON(ctx, &exported, db.Reputation()))
+
+		imported, err := reputation.ReadJSON(&exported)
+		require.NoError(t, err)
+		require.Len(t, imported, 1)
+		require.NotNil(t, imported[0].AuditHistory)
+
+		// importing the export keeps the audit history.
+		require.NoError(t, db.Reputation().ReplaceAll(ctx, imported))
+		got, err := db.Reputation().Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		require.NotNil(t, got.AuditHistory)
+		assert.Equal(t, stats.AuditHistory.Score, got.AuditHistory.Score)
+		require.Len(t, got.AuditHistory.Windows, 2)
+		for i, window := range got.AuditHistory.Windows {
+			assert.True(t, stats.AuditHistory.Windows[i].WindowStart.Equal(window.WindowStart))
+			assert.Equal(t, stats.AuditHistory.Windows[i].OnlineCount, window.OnlineCount)
+			assert.Equal(t, stats.AuditHistory.Windows[i].TotalCount, window.TotalCount)
+		}
+	})
+}
@@ -0,0 +1,1267 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+)
+
+var _ DB = (*MemoryDB)(nil)
+
+// MemoryDB implements DB in memory, it's intended for tests and tooling.
+type MemoryDB struct {
+	log *zap.Logger
+
+	mu        sync.Mutex
+	entries   map[storj.NodeID]memoryEntry
+	history   map[storj.NodeID][]ScoreSample
+	activity  map[storj.NodeID][]ActivitySample
+	statuses  map[storj.NodeID][]StatusTransition
+	changelog map[storj.NodeID][]ChangeRecord
+	snapshots map[storj.NodeID][]memorySnapshot
+	lastSeen  map[storj.NodeID]Stats
+
+	// changelogID is the ID of the last stored changelog record.
+	changelogID int64
+
+	// scoreHistoryEpsilon is the minimal online score change recorded in the history.
+	scoreHistoryEpsilon float64
+	// maxAge is the age of LastContactAt after which read stats are stale, 0 means never.
+	maxAge time.Duration
+
+	broadcast *Broadcaster
+}
+
+// memoryEntry holds stored stats, audit history is kept marshaled
+// so callers can't modify the stored value.
+type memoryEntry struct {
+	stats            Stats
+	auditHistory     []byte
+	historyUpdatedAt *time.Time
+}
+
+// memorySnapshot holds stats without audit history captured at a specific time.
+type memorySnapshot struct {
+	at    time.Time
+	stats Stats
+}
+
+// NewMemory creates a new in-memory reputation DB.
+func NewMemory() *MemoryDB {
+	return &MemoryDB{
+		log: zap.NewNop(),
+
+		entries:   make(map[storj.NodeID]memoryEntry),
+		history:   make(map[storj.NodeID][]ScoreSample),
+		activity:  make(map[storj.NodeID][]ActivitySample),
+		statuses:  make(map[storj.NodeID][]StatusTransition),
+		changelog: make(map[storj.NodeID][]ChangeRecord),
+		snapshots: make(map[storj.NodeID][]memorySnapshot),
+		lastSeen:  make(map[storj.NodeID]Stats),
+
+		scoreHistoryEpsilon: OnlineScoreHistoryEpsilon,
+
+		broadcast: NewBroadcaster(zap.NewNop()),
+	}
+}
+
+// SetScoreHistoryEpsilon sets the minimal online score change which is recorded in the history,
+// it's OnlineScoreHistoryEpsilon by default. Returns ErrInvalidScoreHistoryEpsilon when it's negative.
+func (db *MemoryDB) SetScoreHistoryEpsilon(epsilon float64) error {
+	if err := ValidateScoreHistoryEpsilon(epsilon); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.scoreHistoryEpsilon = epsilon
+	return nil
+}
+
+// SetLog sets the logger which reports skipped invalid stats, nothing is logged by default.
+func (db *MemoryDB) SetLog(log *zap.Logger) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.log = log
+}
+
+// SetMaxAge sets the age of LastContactAt after which read stats are marked as stale,
+// stats are never stale when it's 0, which is the default.
+func (db *MemoryDB) SetMaxAge(maxAge time.Duration) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.maxAge = maxAge
+}
+
+// markStale sets Stale of stats read at now, db.mu must be held.
+func (db *MemoryDB) markStale(stats Stats, now time.Time) Stats {
+	stats.Stale = stats.IsStale(db.maxAge, now)
+	return stats
+}
+
+// Store inserts or updates reputation stats.
+func (db *MemoryDB) Store(ctx context.Context, stats Stats, opts ...StoreOption) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.storeAll([]Stats{stats}, !NewStoreOptions(opts).WithoutValidation)
+}
+
+// StoreAll inserts or updates all reputation stats at once, stats which don't pass
+// CheckStats are logged and skipped.
+func (db *MemoryDB) StoreAll(ctx context.Context, stats []Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	log := db.log
+	db.mu.Unlock()
+
+	return db.storeAll(ValidStats(log, stats), false)
+}
+
+// storeAll stores all stats, they are checked by CheckStats first when validate is set.
+func (db *MemoryDB) storeAll(stats []Stats, validate bool) error {
+	entries := make([]memoryEntry, 0, len(stats))
+	for _, s := range stats {
+		entry, err := newMemoryEntry(s, validate)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	stored := make([]Stats, 0, len(entries))
+	db.mu.Lock()
+	for _, entry := range entries {
+		stored = append(stored, db.store(entry))
+	}
+	db.mu.Unlock()
+
+	db.broadcast.Publish(stored...)
+	return nil
+}
+
+// ReplaceAll replaces all stored stats with provided stats, stats of satellites
+// not in the input are deleted. Returns ErrEmptyReplace when stats is empty.
+func (db *MemoryDB) ReplaceAll(ctx context.Context, stats []Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(stats) == 0 {
+		return ErrEmptyReplace
+	}
+
+	entries := make([]memoryEntry, 0, len(stats))
+	for _, s := range stats {
+		entry, err := newMemoryEntry(s, true)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	replaced := make(map[storj.NodeID]struct{}, len(entries))
+	for _, entry := range entries {
+		replaced[entry.stats.SatelliteID] = struct{}{}
+	}
+
+	stored := make([]Stats, 0, len(entries))
+	db.mu.Lock()
+	for satelliteID := range db.entries {
+		if _, ok := replaced[satelliteID]; !ok {
+			delete(db.entries, satelliteID)
+		}
+	}
+	for _, entry := range entries {
+		stored = append(stored, db.store(entry))
+	}
+	db.mu.Unlock()
+
+	db.broadcast.Publish(stored...)
+	return nil
+}
+
+// StoreIfNewer inserts stats or updates them when stats.UpdatedAt is after
+// the stored UpdatedAt and they changed besides timestamps. Returns whether stats were written.
+func (db *MemoryDB) StoreIfNewer(ctx context.Context, stats Stats) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	entry, err := newMemoryEntry(stats, true)
+	if err != nil {
+		return false, err
+	}
+
+	db.mu.Lock()
+	if existing, ok := db.entries[stats.SatelliteID]; ok && (!entry.stats.UpdatedAt.After(existing.stats.UpdatedAt) ||
+		bytes.Equal(entry.auditHistory, existing.auditHistory) && entry.stats.EqualIgnoringTimestamps(existing.stats)) {
+		db.mu.Unlock()
+		return false, nil
+	}
+	stored := db.store(entry)
+	db.mu.Unlock()
+
+	db.broadcast.Publish(stored)
+	return true, nil
+}
+
+// Subscribe returns a channel which receives stats whenever they are written,
+// the channel is closed when ctx is canceled.
+func (db *MemoryDB) Subscribe(ctx context.Context) (_ <-chan Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.broadcast.Subscribe(ctx)
+}
+
+// newMemoryEntry converts stats into the stored form, they are checked by CheckStats first when validate is set.
+func newMemoryEntry(stats Stats, validate bool) (entry memoryEntry, err error) {
+	if validate {
+		if err := CheckStats(&stats); err != nil {
+			return memoryEntry{}, err
+		}
+	}
+
+	if stats.AuditHistory != nil {
+		entry.auditHistory, err = pb.Marshal(stats.AuditHistory)
+		if err != nil {
+			return memoryEntry{}, err
+		}
+		stats.AuditHistory = nil
+
+		updatedAt := stats.UpdatedAt.UTC()
+		entry.historyUpdatedAt = &updatedAt
+	}
+	stats.HistoryUpdatedAt = nil
+	stats.Stale = false
+
+	stats.DisqualifiedAt = utcPtr(stats.DisqualifiedAt)
+	stats.SuspendedAt = utcPtr(stats.SuspendedAt)
+	stats.OfflineSuspendedAt = utcPtr(stats.OfflineSuspendedAt)
+	stats.OfflineUnderReviewAt = utcPtr(stats.OfflineUnderReviewAt)
+	stats.DisqualifiedObservedAt = utcPtr(stats.DisqualifiedObservedAt)
+	stats.LastContactAt = utcPtr(stats.LastContactAt)
+	stats.LastAuditAt = utcPtr(stats.LastAuditAt)
+	stats.UpdatedAt = stats.UpdatedAt.UTC()
+	stats.JoinedAt = stats.JoinedAt.UTC()
+
+	entry.stats = stats
+	return entry, nil
+}
+
+// store replaces stored stats of the satellite and returns them, db.mu must be held.
+func (db *MemoryDB) store(entry memoryEntry) Stats {
+	satelliteID := entry.stats.SatelliteID
+
+	// keep the time when the disqualification was observed for the first time,
+	// the last contact time when the stats weren't fetched from the satellite,
+	// the muted flag, which is only changed by Mute and Unmute, and the last
+	// audit time unless the audit count increased.
+	existing, ok := db.entries[satelliteID]
+	switch {
+	case ok && existing.stats.DisqualifiedObservedAt != nil:
+		entry.stats.DisqualifiedObservedAt = existing.stats.DisqualifiedObservedAt
+	case entry.stats.DisqualifiedAt != nil:
+		now := time.Now().UTC()
+		entry.stats.DisqualifiedObservedAt = &now
+	default:
+		entry.stats.DisqualifiedObservedAt = nil
+	}
+	if ok && entry.stats.LastContactAt == nil {
+		entry.stats.LastContactAt = existing.stats.LastContactAt
+	}
+	if ok && entry.stats.LastAuditAt == nil {
+		entry.stats.LastAuditAt = existing.stats.LastAuditAt
+		if entry.stats.Audit.TotalCount > existing.stats.Audit.TotalCount {
+			auditedAt := entry.stats.UpdatedAt
+			entry.stats.LastAuditAt = &auditedAt
+		}
+	}
+	if ok {
+		entry.stats.Muted = existing.stats.Muted
+	}
+
+	var previous *Stats
+	if ok {
+		previous = &existing.stats
+	}
+	db.storeChangelog(previous, entry.stats)
+
+	db.entries[satelliteID] = entry
+	db.storeOnlineScoreSample(entry.stats)
+	db.storeStatusTransition(entry.stats)
+	if ok {
+		db.storeAuditActivitySample(existing.stats.Audit, entry.stats)
+	}
+
+	// the audit history was marshaled by newMemoryEntry, so it can't fail to unmarshal.
+	stats, _ := entry.withAuditHistory()
+	return stats
+}
+
+// storeChangelog appends records of the fields which changed since previous, db.mu must be held.
+func (db *MemoryDB) storeChangelog(previous *Stats, stats Stats) {
+	timestamp := stats.UpdatedAt
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+
+	records := NewChangeRecords(previous, stats, timestamp)
+	for i := range records {
+		db.changelogID++
+		records[i].ID = db.changelogID
+	}
+	if len(records) > 0 {
+		db.changelog[stats.SatelliteID] = append(db.changelog[stats.SatelliteID], records...)
+	}
+}
+
+// storeOnlineScoreSample appends an online score sample when the online score
+// changed since the last sample by at least the epsilon, the first sample of a
+// satellite is always appended as the baseline. db.mu must be held.
+func (db *MemoryDB) storeOnlineScoreSample(stats Stats) {
+	timestamp := stats.UpdatedAt
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+
+	samples := db.history[stats.SatelliteID]
+	if len(samples) > 0 && !ScoreSampleChanged(samples[len(samples)-1].Score, stats.OnlineScore, db.scoreHistoryEpsilon) {
+		return
+	}
+
+	sample := ScoreSample{Timestamp: timestamp, Score: stats.OnlineScore}
+	i := sort.Search(len(samples), func(i int) bool {
+		return !samples[i].Timestamp.Before(timestamp)
+	})
+	if i < len(samples) && samples[i].Timestamp.Equal(timestamp) {
+		samples[i] = sample
+	} else {
+		samples = append(samples, ScoreSample{})
+		copy(samples[i+1:], samples[i:])
+		samples[i] = sample
+	}
+	db.history[stats.SatelliteID] = samples
+}
+
+// storeStatusTransition appends a status transition when the availability changed
+// since the last transition, db.mu must be held.
+func (db *MemoryDB) storeStatusTransition(stats Stats) {
+	transitions := db.statuses[stats.SatelliteID]
+
+	var last *StatusTransition
+	if len(transitions) > 0 {
+		last = &transitions[len(transitions)-1]
+	}
+	transition, ok := NextStatusTransition(last, stats)
+	switch {
+	case !ok:
+		return
+	case last != nil && last.Timestamp.Equal(transition.Timestamp):
+		*last = transition
+	default:
+		transitions = append(transitions, transition)
+	}
+	db.statuses[stats.SatelliteID] = transitions
+}
+
+// storeAuditActivitySample appends the change of audit counts since the previously stored stats,
+// db.mu must be held.
+func (db *MemoryDB) storeAuditActivitySample(previous Metric, stats Stats) {
+	totalDelta := stats.Audit.TotalCount - previous.TotalCount
+	successDelta := stats.Audit.SuccessCount - previous.SuccessCount
+	if totalDelta < 0 || successDelta < 0 || (totalDelta == 0 && successDelta == 0) {
+		return
+	}
+
+	timestamp := stats.UpdatedAt
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+
+	samples := db.activity[stats.SatelliteID]
+	i := sort.Search(len(samples), func(i int) bool {
+		return !samples[i].Timestamp.Before(timestamp)
+	})
+	if i < len(samples) && samples[i].Timestamp.Equal(timestamp) {
+		samples[i].TotalCount += totalDelta
+		samples[i].SuccessCount += successDelta
+	} else {
+		samples = append(samples, ActivitySample{})
+		copy(samples[i+1:], samples[i:])
+		samples[i] = ActivitySample{Timestamp: timestamp, TotalCount: totalDelta, SuccessCount: successDelta}
+	}
+	db.activity[stats.SatelliteID] = samples
+}
+
+// Get retrieves stats for specific satellite, returns ErrNoStats when there are no stats for the satellite.
+func (db *MemoryDB) Get(ctx context.Context, satelliteID storj.NodeID, opts ...GetOption) (_ *Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, ok := db.entries[satelliteID]
+	if !ok {
+		return nil, ErrNoStats
+	}
+
+	if NewGetOptions(opts).WithoutAuditHistory {
+		stats := db.markStale(entry.stats, time.Now())
+		return &stats, nil
+	}
+
+	stats, err := entry.withAuditHistory()
+	if err != nil {
+		return nil, err
+	}
+	stats = db.markStale(stats, time.Now())
+	return &stats, nil
+}
+
+// GetBySatellites retrieves stats for the specified satellites, satellites without stats are omitted.
+func (db *MemoryDB) GetBySatellites(ctx context.Context, satelliteIDs []storj.NodeID) (_ map[storj.NodeID]Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := time.Now()
+	result := make(map[storj.NodeID]Stats, len(satelliteIDs))
+	for _, satelliteID := range satelliteIDs {
+		entry, ok := db.entries[satelliteID]
+		if !ok {
+			continue
+		}
+
+		stats, err := entry.withAuditHistory()
+		if err != nil {
+			return nil, err
+		}
+		result[satelliteID] = db.markStale(stats, now)
+	}
+	return result, nil
+}
+
+// SchemaVersion returns SchemaVersion, the memory DB doesn't need migrations.
+func (db *MemoryDB) SchemaVersion(ctx context.Context) (_ int, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return SchemaVersion, nil
+}
+
+// Exists returns whether stats are stored for specific satellite.
+func (db *MemoryDB) Exists(ctx context.Context, satelliteID storj.NodeID) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	_, ok := db.entries[satelliteID]
+	return ok, nil
+}
+
+// SatelliteIDs retrieves IDs of all satellites with stored stats ordered by satellite ID.
+func (db *MemoryDB) SatelliteIDs(ctx context.Context) (_ []storj.NodeID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	satelliteIDs := make([]storj.NodeID, 0, len(db.entries))
+	for satelliteID := range db.entries {
+		satelliteIDs = append(satelliteIDs, satelliteID)
+	}
+	db.mu.Unlock()
+
+	sort.Slice(satelliteIDs, func(i, k int) bool {
+		return satelliteIDs[i].Less(satelliteIDs[k])
+	})
+	return satelliteIDs, nil
+}
+
+// GetOnlineScore retrieves only the online score of specific satellite, returns false when there are no stats.
+func (db *MemoryDB) GetOnlineScore(ctx context.Context, satelliteID storj.NodeID) (_ float64, _ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, ok := db.entries[satelliteID]
+	return entry.stats.OnlineScore, ok, nil
+}
+
+// GetAuditScore retrieves only the computed audit score of specific satellite, returns false when there are no stats.
+func (db *MemoryDB) GetAuditScore(ctx context.Context, satelliteID storj.NodeID) (_ float64, _ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, ok := db.entries[satelliteID]
+	return entry.stats.Audit.ComputedScore(), ok, nil
+}
+
+// withAuditHistory returns stored stats including the audit history.
+func (entry memoryEntry) withAuditHistory() (Stats, error) {
+	stats := entry.stats
+	if entry.auditHistory != nil {
+		stats.AuditHistory = &pb.AuditHistory{}
+		if err := pb.Unmarshal(entry.auditHistory, stats.AuditHistory); err != nil {
+			return Stats{}, err
+		}
+		stats.HistoryUpdatedAt = entry.historyUpdatedAt
+	}
+	return stats, nil
+}
+
+// All retrieves all stats ordered by satellite ID, audit history is not included.
+func (db *MemoryDB) All(ctx context.Context) (_ []Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var statsList []Stats
+	err = db.ForEach(ctx, func(stats Stats) error {
+		statsList = append(statsList, stats)
+		return nil
+	})
+	return statsList, err
+}
+
+// ForEach calls fn for all stats ordered by satellite ID, audit history is not included.
+// The DB isn't locked while fn is called.
+func (db *MemoryDB) ForEach(ctx context.Context, fn func(Stats) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	statsList, err := db.Filter(ctx, FilterOpts{})
+	if err != nil {
+		return err
+	}
+
+	for _, stats := range statsList {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(stats); err != nil {
+			if errors.Is(err, ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Filter retrieves stats matching all of the provided options ordered by opts.SortBy, audit history is not included.
+func (db *MemoryDB) Filter(ctx context.Context, opts FilterOpts) (_ []Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := time.Now()
+	var statsList []Stats
+	for _, entry := range db.entries {
+		stats := db.markStale(entry.stats, now)
+		if opts.OnlySuspended && stats.SuspendedAt == nil && stats.OfflineSuspendedAt == nil {
+			continue
+		}
+		if opts.OnlyDisqualified && stats.DisqualifiedAt == nil {
+			continue
+		}
+		if opts.MinOnlineScore != nil && stats.OnlineScore < *opts.MinOnlineScore {
+			continue
+		}
+		if opts.UpdatedAfter != nil && !stats.UpdatedAt.After(*opts.UpdatedAfter) {
+			continue
+		}
+		statsList = append(statsList, stats)
+	}
+
+	sort.Slice(statsList, func(i, k int) bool {
+		a, b := statsList[i], statsList[k]
+		switch {
+		case opts.SortBy == SortByOnlineScore && a.OnlineScore != b.OnlineScore:
+			return a.OnlineScore < b.OnlineScore
+		case opts.SortBy == SortByJoinedAt && !a.JoinedAt.Equal(b.JoinedAt):
+			return a.JoinedAt.Before(b.JoinedAt)
+		}
+		return a.SatelliteID.Less(b.SatelliteID)
+	})
+	return statsList, nil
+}
+
+// UpdatedSince retrieves stats updated after t ordered by UpdatedAt, audit history is not included.
+// Stats updated exactly at t are excluded, so t can be the latest UpdatedAt seen by the caller.
+func (db *MemoryDB) UpdatedSince(ctx context.Context, t time.Time) (_ []Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	statsList, err := db.Filter(ctx, FilterOpts{UpdatedAfter: &t})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(statsList, func(i, k int) bool {
+		return statsList[i].UpdatedAt.Before(statsList[k].UpdatedAt)
+	})
+	return statsList, nil
+}
+
+// GetByOnlineScoreRange retrieves stats with online score in [min, max] ordered by online score,
+// returns ErrInvalidScoreRange when min is above max.
+func (db *MemoryDB) GetByOnlineScoreRange(ctx context.Context, min, max float64) (_ []Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !(min <= max) {
+		return nil, ErrInvalidScoreRange.New("min %v is above max %v", min, max)
+	}
+
+	all, err := db.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var statsList []Stats
+	for _, stats := range all {
+		if min <= stats.OnlineScore && stats.OnlineScore <= max {
+			statsList = append(statsList, stats)
+		}
+	}
+
+	sort.Slice(statsList, func(i, k int) bool {
+		if statsList[i].OnlineScore != statsList[k].OnlineScore {
+			return statsList[i].OnlineScore < statsList[k].OnlineScore
+		}
+		return statsList[i].SatelliteID.Less(statsList[k].SatelliteID)
+	})
+	return statsList, nil
+}
+
+// GetRecentlyJoined retrieves stats of satellites joined at or after since ordered by JoinedAt descending,
+// stats without JoinedAt are excluded.
+func (db *MemoryDB) GetRecentlyJoined(ctx context.Context, since time.Time) (_ []Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	all, err := db.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var statsList []Stats
+	for _, stats := range all {
+		if !stats.JoinedAt.IsZero() && !stats.JoinedAt.Before(since) {
+			statsList = append(statsList, stats)
+		}
+	}
+
+	sort.Slice(statsList, func(i, k int) bool {
+		if !statsList[i].JoinedAt.Equal(statsList[k].JoinedAt) {
+			return statsList[i].JoinedAt.After(statsList[k].JoinedAt)
+		}
+		return statsList[i].SatelliteID.Less(statsList[k].SatelliteID)
+	})
+	return statsList, nil
+}
+
+// Snapshot stores a copy of all current stats and removes snapshots outside of the retention period.
+func (db *MemoryDB) Snapshot(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := time.Now().UTC()
+	for satelliteID, entry := range db.entries {
+		db.snapshots[satelliteID] = append(db.snapshots[satelliteID], memorySnapshot{at: now, stats: entry.stats})
+	}
+
+	cutoff := now.Add(-SnapshotRetention)
+	for satelliteID, snapshots := range db.snapshots {
+		retained := snapshots[:0]
+		for _, snapshot := range snapshots {
+			if !snapshot.at.Before(cutoff) {
+				retained = append(retained, snapshot)
+			}
+		}
+		if len(retained) == 0 {
+			delete(db.snapshots, satelliteID)
+			continue
+		}
+		db.snapshots[satelliteID] = retained
+	}
+	return nil
+}
+
+// SnapshotAt retrieves the latest snapshot of satellite stats taken at or before t,
+// audit history is not included. Returns ErrNoStats when there is no such snapshot.
+func (db *MemoryDB) SnapshotAt(ctx context.Context, satelliteID storj.NodeID, t time.Time) (_ Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	snapshots := db.snapshots[satelliteID]
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if !snapshots[i].at.After(t) {
+			return snapshots[i].stats, nil
+		}
+	}
+	return Stats{}, ErrNoStats
+}
+
+// Backup writes all stored stats including the audit history to w ordered by satellite ID.
+func (db *MemoryDB) Backup(ctx context.Context, w io.Writer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	records := make([]BackupRecord, 0, len(db.entries))
+	for _, entry := range db.entries {
+		stats := entry.stats
+		stats.HistoryUpdatedAt = entry.historyUpdatedAt
+		records = append(records, BackupRecord{Stats: stats, AuditHistory: entry.auditHistory})
+	}
+	db.mu.Unlock()
+
+	sort.Slice(records, func(i, k int) bool {
+		return records[i].Stats.SatelliteID.Less(records[k].Stats.SatelliteID)
+	})
+
+	writer, err := NewBackupWriter(w)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// Restore replaces all stored stats with stats of a backup as they are, stats aren't
+// published to subscribers and other history of the satellites is kept.
+func (db *MemoryDB) Restore(ctx context.Context, r io.Reader, opts ...RestoreOption) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	records, err := ReadBackup(r)
+	if err != nil {
+		return err
+	}
+
+	entries := make(map[storj.NodeID]memoryEntry, len(records))
+	for _, record := range records {
+		stats := record.Stats
+		historyUpdatedAt := utcPtr(stats.HistoryUpdatedAt)
+		stats.AuditHistory = nil
+		stats.HistoryUpdatedAt = nil
+		entries[stats.SatelliteID] = memoryEntry{
+			stats:            stats,
+			auditHistory:     record.AuditHistory,
+			historyUpdatedAt: historyUpdatedAt,
+		}
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if len(db.entries) > 0 && !NewRestoreOptions(opts).Force {
+		return ErrRestoreNotEmpty
+	}
+	db.entries = entries
+	return nil
+}
+
+// GetWorst retrieves stats of the satellite with the lowest score of the metric, returns false when there are no stats.
+func (db *MemoryDB) GetWorst(ctx context.Context, metric MetricKind) (_ Stats, _ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if metric < MetricOnline || metric > MetricAuditUnknown {
+		return Stats{}, false, errs.New("unknown metric %v", metric)
+	}
+
+	statsList, err := db.Filter(ctx, FilterOpts{})
+	if err != nil || len(statsList) == 0 {
+		return Stats{}, false, err
+	}
+
+	// stats are sorted by satellite id, so ties are resolved by it.
+	worst := statsList[0]
+	for _, stats := range statsList[1:] {
+		if metric.Score(stats) < metric.Score(worst) {
+			worst = stats
+		}
+	}
+	return worst, true, nil
+}
+
+// OldestContact retrieves stats of the satellite with the oldest LastContactAt, stats without
+// LastContactAt are the oldest. Returns false when there are no stats.
+func (db *MemoryDB) OldestContact(ctx context.Context) (_ Stats, _ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	statsList, err := db.Filter(ctx, FilterOpts{})
+	if err != nil || len(statsList) == 0 {
+		return Stats{}, false, err
+	}
+
+	// stats are sorted by satellite id, so ties are resolved by it.
+	oldest := statsList[0]
+	for _, stats := range statsList[1:] {
+		if oldest.LastContactAt == nil {
+			break
+		}
+		if stats.LastContactAt == nil || stats.LastContactAt.Before(*oldest.LastContactAt) {
+			oldest = stats
+		}
+	}
+	return oldest, true, nil
+}
+
+// DeleteBefore deletes stats updated before provided time, stats of disqualified nodes are kept.
+func (db *MemoryDB) DeleteBefore(ctx context.Context, before time.Time) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for satelliteID, entry := range db.entries {
+		if entry.stats.UpdatedAt.Before(before) && entry.stats.DisqualifiedAt == nil {
+			delete(db.entries, satelliteID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// DeleteScoreHistoryBefore deletes online score samples recorded before provided time.
+func (db *MemoryDB) DeleteScoreHistoryBefore(ctx context.Context, before time.Time) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for satelliteID, samples := range db.history {
+		retained := samples[:0]
+		for _, sample := range samples {
+			if sample.Timestamp.Before(before) {
+				deleted++
+				continue
+			}
+			retained = append(retained, sample)
+		}
+		db.history[satelliteID] = retained
+	}
+	return deleted, nil
+}
+
+// DeleteAuditActivityBefore deletes audit activity samples recorded before provided time.
+func (db *MemoryDB) DeleteAuditActivityBefore(ctx context.Context, before time.Time) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for satelliteID, samples := range db.activity {
+		retained := samples[:0]
+		for _, sample := range samples {
+			if sample.Timestamp.Before(before) {
+				deleted++
+				continue
+			}
+			retained = append(retained, sample)
+		}
+		db.activity[satelliteID] = retained
+	}
+	return deleted, nil
+}
+
+// Mute marks stats of specific satellite as muted, returns ErrNoStats when there are no stats for the satellite.
+func (db *MemoryDB) Mute(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return db.setMuted(satelliteID, true)
+}
+
+// Unmute clears the muted mark of specific satellite, returns ErrNoStats when there are no stats for the satellite.
+func (db *MemoryDB) Unmute(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return db.setMuted(satelliteID, false)
+}
+
+// Compact does nothing, memory is freed on delete.
+func (db *MemoryDB) Compact(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return nil
+}
+
+// TouchContact updates only the last contact time of specific satellite,
+// returns ErrNoStats when there are no stats for the satellite.
+func (db *MemoryDB) TouchContact(ctx context.Context, satelliteID storj.NodeID, at time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, ok := db.entries[satelliteID]
+	if !ok {
+		return ErrNoStats
+	}
+	at = at.UTC()
+	entry.stats.LastContactAt = &at
+	db.entries[satelliteID] = entry
+	return nil
+}
+
+// StoreAuditHistory replaces only the audit history of specific satellite, the other stats
+// are kept, returns ErrNoStats when there are no stats for the satellite.
+func (db *MemoryDB) StoreAuditHistory(ctx context.Context, satelliteID storj.NodeID, history *pb.AuditHistory) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var auditHistory []byte
+	var historyUpdatedAt *time.Time
+	if history != nil {
+		auditHistory, err = pb.Marshal(history)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		historyUpdatedAt = &now
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, ok := db.entries[satelliteID]
+	if !ok {
+		return ErrNoStats
+	}
+	entry.auditHistory = auditHistory
+	entry.historyUpdatedAt = historyUpdatedAt
+	db.entries[satelliteID] = entry
+	return nil
+}
+
+// setMuted updates the muted flag of stats of specific satellite.
+func (db *MemoryDB) setMuted(satelliteID storj.NodeID, muted bool) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, ok := db.entries[satelliteID]
+	if !ok {
+		return ErrNoStats
+	}
+	entry.stats.Muted = muted
+	db.entries[satelliteID] = entry
+	return nil
+}
+
+// Reset deletes stats of specific satellite, returns ErrNoStats when there are no stats for the satellite.
+func (db *MemoryDB) Reset(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.entries[satelliteID]; !ok {
+		return ErrNoStats
+	}
+	delete(db.entries, satelliteID)
+	return nil
+}
+
+// RenameSatellite moves stats and history of a satellite to its new ID.
+func (db *MemoryDB) RenameSatellite(ctx context.Context, oldID, newID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entry, ok := db.entries[oldID]
+	if !ok {
+		return ErrNoStats
+	}
+	if _, ok := db.entries[newID]; ok {
+		return ErrSatelliteExists
+	}
+
+	entry.stats.SatelliteID = newID
+	db.entries[newID] = entry
+	delete(db.entries, oldID)
+
+	db.history[newID] = db.history[oldID]
+	delete(db.history, oldID)
+	db.activity[newID] = db.activity[oldID]
+	delete(db.activity, oldID)
+	db.statuses[newID] = db.statuses[oldID]
+	delete(db.statuses, oldID)
+
+	snapshots := db.snapshots[oldID]
+	for i := range snapshots {
+		snapshots[i].stats.SatelliteID = newID
+	}
+	db.snapshots[newID] = snapshots
+	delete(db.snapshots, oldID)
+
+	delete(db.lastSeen, newID)
+	if lastSeen, ok := db.lastSeen[oldID]; ok {
+		lastSeen.SatelliteID = newID
+		db.lastSeen[newID] = lastSeen
+		delete(db.lastSeen, oldID)
+	}
+	return nil
+}
+
+// CountDisqualified returns the number of satellites which disqualified the node.
+func (db *MemoryDB) CountDisqualified(ctx context.Context) (_ int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.count(func(stats Stats) bool { return stats.DisqualifiedAt != nil }), nil
+}
+
+// CountSuspended returns the number of satellites which suspended the node for unknown audit errors.
+func (db *MemoryDB) CountSuspended(ctx context.Context) (_ int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.count(func(stats Stats) bool { return stats.SuspendedAt != nil }), nil
+}
+
+// CountOfflineSuspended returns the number of satellites which suspended the node for being offline.
+func (db *MemoryDB) CountOfflineSuspended(ctx context.Context) (_ int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.count(func(stats Stats) bool { return stats.OfflineSuspendedAt != nil }), nil
+}
+
+// Summary returns counts of stored stats.
+func (db *MemoryDB) Summary(ctx context.Context) (_ Summary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var summary Summary
+	for _, entry := range db.entries {
+		if summary.TotalSatellites == 0 || entry.stats.OnlineScore < summary.MinOnlineScore {
+			summary.MinOnlineScore = entry.stats.OnlineScore
+		}
+		summary.TotalSatellites++
+		if entry.stats.SuspendedAt != nil {
+			summary.Suspended++
+		}
+		if entry.stats.DisqualifiedAt != nil {
+			summary.Disqualified++
+		}
+	}
+	return summary, nil
+}
+
+// count returns the number of stored stats matching fn.
+func (db *MemoryDB) count(fn func(Stats) bool) int {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var count int
+	for _, entry := range db.entries {
+		if fn(entry.stats) {
+			count++
+		}
+	}
+	return count
+}
+
+// Statuses classifies stats of all satellites against t.
+func (db *MemoryDB) Statuses(ctx context.Context, t Thresholds) (_ map[storj.NodeID]StatusReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	statuses := make(map[storj.NodeID]StatusReport, len(db.entries))
+	for satelliteID, entry := range db.entries {
+		stats := entry.stats
+		if t.MinWindows > 0 {
+			// the audit history was marshaled by newMemoryEntry, so it can't fail to unmarshal.
+			stats, _ = entry.withAuditHistory()
+		}
+		statuses[satelliteID] = t.Classify(stats)
+	}
+	return statuses, nil
+}
+
+// OnlineScoreHistory retrieves online score samples of a specific satellite recorded in the provided time range.
+func (db *MemoryDB) OnlineScoreHistory(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ []ScoreSample, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var samples []ScoreSample
+	for _, sample := range db.history[satelliteID] {
+		if sample.Timestamp.Before(from) || sample.Timestamp.After(to) {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// Sparkline retrieves the online score history of specific satellite downsampled into at most points scores.
+func (db *MemoryDB) Sparkline(ctx context.Context, satelliteID storj.NodeID, points int) (_ []float64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if points <= 0 {
+		return nil, ErrInvalidSparkline.New("points must be positive: %d", points)
+	}
+
+	db.mu.Lock()
+	samples := append([]ScoreSample(nil), db.history[satelliteID]...)
+	db.mu.Unlock()
+
+	// samples are appended in the order stats were stored, which doesn't have to be their time order.
+	sort.SliceStable(samples, func(i, k int) bool {
+		return samples[i].Timestamp.Before(samples[k].Timestamp)
+	})
+	return DownsampleScores(samples, points), nil
+}
+
+// Changelog retrieves changes of scores and flags of specific satellite in the provided time range.
+func (db *MemoryDB) Changelog(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ []ChangeRecord, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var records []ChangeRecord
+	for _, record := range db.changelog[satelliteID] {
+		if record.Timestamp.Before(from) || record.Timestamp.After(to) {
+			continue
+		}
+		records = append(records, record)
+	}
+	// records are appended in the order they were stored, which keeps the order of equal timestamps.
+	sort.SliceStable(records, func(i, k int) bool {
+		return records[i].Timestamp.Before(records[k].Timestamp)
+	})
+	return records, nil
+}
+
+// GetChanges retrieves changelog records of all satellites stored after the record with sinceID.
+func (db *MemoryDB) GetChanges(ctx context.Context, sinceID int64) (_ []ChangeRecord, cursor int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var records []ChangeRecord
+	for _, satelliteRecords := range db.changelog {
+		for _, record := range satelliteRecords {
+			if record.ID > sinceID {
+				records = append(records, record)
+			}
+		}
+	}
+	sort.Slice(records, func(i, k int) bool {
+		return records[i].ID < records[k].ID
+	})
+	if len(records) > MaxChanges {
+		records = records[:MaxChanges]
+	}
+
+	cursor = sinceID
+	if len(records) > 0 {
+		cursor = records[len(records)-1].ID
+	}
+	return records, cursor, nil
+}
+
+// DeleteChangelogBefore deletes changelog records recorded before provided time.
+func (db *MemoryDB) DeleteChangelogBefore(ctx context.Context, before time.Time) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for satelliteID, records := range db.changelog {
+		retained := records[:0]
+		for _, record := range records {
+			if record.Timestamp.Before(before) {
+				deleted++
+				continue
+			}
+			retained = append(retained, record)
+		}
+		db.changelog[satelliteID] = retained
+	}
+	return deleted, nil
+}
+
+// Availability returns the time-weighted fraction of [from, to) the node was available on a specific satellite.
+func (db *MemoryDB) Availability(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ float64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return ComputeAvailability(db.statuses[satelliteID], from, to)
+}
+
+// AuditActivity retrieves audit count changes of a specific satellite recorded in the provided time range.
+func (db *MemoryDB) AuditActivity(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ []ActivitySample, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var samples []ActivitySample
+	for _, sample := range db.activity[satelliteID] {
+		if sample.Timestamp.Before(from) || sample.Timestamp.After(to) {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// utcPtr returns a copy of t converted to UTC.
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// LastSeenStates retrieves stats last seen by the transition log chore.
+func (db *MemoryDB) LastSeenStates(ctx context.Context) (_ map[storj.NodeID]Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	states := make(map[storj.NodeID]Stats, len(db.lastSeen))
+	for satelliteID, stats := range db.lastSeen {
+		states[satelliteID] = stats
+	}
+	return states, nil
+}
+
+// StoreLastSeenStates replaces stats last seen by the transition log chore.
+func (db *MemoryDB) StoreLastSeenStates(ctx context.Context, stats []Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.lastSeen = make(map[storj.NodeID]Stats, len(stats))
+	for _, s := range stats {
+		db.lastSeen[s.SatelliteID] = lastSeenState(s)
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestMemoryDB(t *testing.T) {
+	ctx := testcontext.New(t)
+	db := reputation.NewMemory()
+
+	now := time.Now()
+	stats := reputation.Stats{
+		SatelliteID:  testrand.NodeID(),
+		OnlineScore:  0.5,
+		AuditHistory: &pb.AuditHistory{Score: 0.5},
+		UpdatedAt:    now,
+	}
+
+	_, err := db.Get(ctx, stats.SatelliteID)
+	require.True(t, errors.Is(err, reputation.ErrNoStats))
+
+	exists, err := db.Exists(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, db.Store(ctx, stats))
+
+	exists, err = db.Exists(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	t.Run("times are stored in utc", func(t *testing.T) {
+		local := time.Date(2021, 1, 2, 3, 4, 5, 0, time.FixedZone("UTC+2", 2*60*60))
+		withTimes := stats
+		withTimes.SatelliteID = testrand.NodeID()
+		withTimes.LastContactAt = &local
+		withTimes.LastAuditAt = &local
+		require.NoError(t, db.Store(ctx, withTimes))
+		defer func() { require.NoError(t, db.Reset(ctx, withTimes.SatelliteID)) }()
+
+		// the stored stats don't share the pointers of the provided stats.
+		local = local.Add(time.Hour)
+
+		res, err := db.Get(ctx, withTimes.SatelliteID)
+		require.NoError(t, err)
+		require.NotNil(t, res.LastContactAt)
+		assert.Equal(t, time.Date(2021, 1, 2, 1, 4, 5, 0, time.UTC), *res.LastContactAt)
+		assert.Equal(t, time.Date(2021, 1, 2, 1, 4, 5, 0, time.UTC), *res.LastAuditAt)
+	})
+
+	t.Run("upsert by satellite", func(t *testing.T) {
+		updated := stats
+		updated.OnlineScore = 0.7
+		require.NoError(t, db.Store(ctx, updated))
+
+		res, err := db.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, 0.7, res.OnlineScore)
+		require.NotNil(t, res.AuditHistory)
+		assert.Equal(t, 0.5, res.AuditHistory.Score)
+
+		all, err := db.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Nil(t, all[0].AuditHistory)
+	})
+
+	t.Run("store if newer", func(t *testing.T) {
+		older := stats
+		older.OnlineScore = 0.1
+		older.UpdatedAt = now.Add(-time.Hour)
+		written, err := db.StoreIfNewer(ctx, older)
+		require.NoError(t, err)
+		assert.False(t, written)
+
+		newer := stats
+		newer.OnlineScore = 0.9
+		newer.UpdatedAt = now.Add(time.Hour)
+		written, err = db.StoreIfNewer(ctx, newer)
+		require.NoError(t, err)
+		assert.True(t, written)
+
+		history, err := db.OnlineScoreHistory(ctx, stats.SatelliteID, now.Add(-time.Hour), now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, 0.7, history[0].Score)
+		assert.Equal(t, 0.9, history[1].Score)
+	})
+
+	t.Run("audit activity", func(t *testing.T) {
+		updated := stats
+		updated.Audit = reputation.Metric{TotalCount: 10, SuccessCount: 8}
+		updated.UpdatedAt = now.Add(90 * time.Minute)
+		require.NoError(t, db.Store(ctx, updated))
+
+		activity, err := db.AuditActivity(ctx, stats.SatelliteID, now, now.Add(3*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, activity, 1)
+		assert.EqualValues(t, 10, activity[0].TotalCount)
+		assert.EqualValues(t, 8, activity[0].SuccessCount)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		require.NoError(t, db.Snapshot(ctx))
+
+		res, err := db.SnapshotAt(ctx, stats.SatelliteID, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, stats.SatelliteID, res.SatelliteID)
+		assert.Nil(t, res.AuditHistory)
+
+		_, err = db.SnapshotAt(ctx, stats.SatelliteID, now)
+		require.True(t, errors.Is(err, reputation.ErrNoStats))
+	})
+
+	t.Run("store all skips invalid", func(t *testing.T) {
+		invalid := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 2}
+		other := reputation.Stats{SatelliteID: testrand.NodeID()}
+
+		core, logs := observer.New(zap.WarnLevel)
+		db.SetLog(zap.New(core))
+		defer db.SetLog(zap.NewNop())
+
+		require.NoError(t, db.StoreAll(ctx, []reputation.Stats{other, invalid}))
+		defer func() { require.NoError(t, db.Reset(ctx, other.SatelliteID)) }()
+		require.Equal(t, 1, logs.FilterMessage("skipping invalid reputation stats").Len())
+
+		_, err := db.Get(ctx, other.SatelliteID)
+		require.NoError(t, err)
+
+		_, err = db.Get(ctx, invalid.SatelliteID)
+		require.True(t, errors.Is(err, reputation.ErrNoStats))
+	})
+
+	t.Run("counts and delete", func(t *testing.T) {
+		disqualified := reputation.Stats{SatelliteID: testrand.NodeID(), DisqualifiedAt: &now}
+		require.NoError(t, db.Store(ctx, disqualified))
+
+		count, err := db.CountDisqualified(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		res, err := db.Get(ctx, disqualified.SatelliteID)
+		require.NoError(t, err)
+		assert.NotNil(t, res.DisqualifiedObservedAt)
+
+		deleted, err := db.DeleteBefore(ctx, now.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, deleted)
+
+		all, err := db.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, disqualified.SatelliteID, all[0].SatelliteID)
+
+		require.NoError(t, db.Reset(ctx, disqualified.SatelliteID))
+		require.True(t, errors.Is(db.Reset(ctx, disqualified.SatelliteID), reputation.ErrNoStats))
+	})
+}
+
+func TestMemoryDBReplaceAll(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db := reputation.NewMemory()
+
+	removed := reputation.Stats{SatelliteID: testrand.NodeID()}
+	require.NoError(t, db.Store(ctx, removed))
+
+	err := db.ReplaceAll(ctx, []reputation.Stats{})
+	require.True(t, errors.Is(err, reputation.ErrEmptyReplace))
+
+	added := reputation.Stats{SatelliteID: testrand.NodeID()}
+	require.NoError(t, db.ReplaceAll(ctx, []reputation.Stats{added}))
+
+	all, err := db.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, added.SatelliteID, all[0].SatelliteID)
+}
+
+func TestMemoryDBScoreHistoryEpsilon(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	for _, test := range []struct {
+		epsilon float64
+		scores  []float64
+	}{
+		{epsilon: reputation.OnlineScoreHistoryEpsilon, scores: []float64{0.5, 0.75, 0.875}},
+		{epsilon: 0, scores: []float64{0.5, 0.5, 0.75, 0.875, 0.8755}},
+		// a change of exactly epsilon is recorded, the first score is always the baseline.
+		{epsilon: 0.25, scores: []float64{0.5, 0.75}},
+	} {
+		db := reputation.NewMemory()
+		require.NoError(t, db.SetScoreHistoryEpsilon(test.epsilon))
+
+		satelliteID := testrand.NodeID()
+		start := time.Now().Add(-time.Hour)
+		for i, score := range []float64{0.5, 0.5, 0.75, 0.875, 0.8755} {
+			require.NoError(t, db.Store(ctx, reputation.Stats{
+				SatelliteID: satelliteID,
+				OnlineScore: score,
+				UpdatedAt:   start.Add(time.Duration(i) * time.Minute),
+			}))
+		}
+
+		samples, err := db.OnlineScoreHistory(ctx, satelliteID, start, start.Add(time.Hour))
+		require.NoError(t, err)
+
+		var scores []float64
+		for _, sample := range samples {
+			scores = append(scores, sample.Score)
+		}
+		require.Equal(t, test.scores, scores, test.epsilon)
+	}
+
+	err := reputation.NewMemory().SetScoreHistoryEpsilon(-0.001)
+	require.True(t, reputation.ErrInvalidScoreHistoryEpsilon.Has(err), err)
+}
+
+func TestMemoryDBMaxAge(t *testing.T) {
+	ctx := testcontext.New(t)
+	db := reputation.NewMemory()
+
+	now := time.Now()
+	contactedAt, staleAt := now.Add(-time.Minute), now.Add(-2*time.Hour)
+	fresh := reputation.Stats{SatelliteID: testrand.NodeID(), LastContactAt: &contactedAt, UpdatedAt: now}
+	stale := reputation.Stats{SatelliteID: testrand.NodeID(), LastContactAt: &staleAt, UpdatedAt: now}
+	require.NoError(t, db.StoreAll(ctx, []reputation.Stats{fresh, stale}))
+
+	requireStale := func(satelliteID storj.NodeID, expected bool) {
+		stats, err := db.Get(ctx, satelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, stats.Stale)
+
+		stats, err = db.Get(ctx, satelliteID, reputation.WithoutAuditHistory())
+		require.NoError(t, err)
+		assert.Equal(t, expected, stats.Stale)
+
+		bySatellites, err := db.GetBySatellites(ctx, []storj.NodeID{satelliteID})
+		require.NoError(t, err)
+		assert.Equal(t, expected, bySatellites[satelliteID].Stale)
+
+		all, err := db.All(ctx)
+		require.NoError(t, err)
+		for _, stats := range all {
+			if stats.SatelliteID == satelliteID {
+				assert.Equal(t, expected, stats.Stale)
+			}
+		}
+	}
+
+	// stats are never stale by default.
+	requireStale(fresh.SatelliteID, false)
+	requireStale(stale.SatelliteID, false)
+
+	db.SetMaxAge(time.Hour)
+	requireStale(fresh.SatelliteID, false)
+	requireStale(stale.SatelliteID, true)
+
+	// Stale isn't stored, so stats read as stale are fresh once they are fetched again.
+	stats, err := db.Get(ctx, stale.SatelliteID)
+	require.NoError(t, err)
+	require.True(t, stats.Stale)
+	stats.LastContactAt = &now
+	require.NoError(t, db.Store(ctx, *stats))
+	requireStale(stale.SatelliteID, false)
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import "math"
+
+// ComputedScore derives the score from alpha and beta, it returns 0 when both are 0.
+// It's the canonical audit score, everything which reports or evaluates the audit score
+// uses it, while Score holds what the satellite reported and is only shown as stored.
+// Diff, the transition log and the changelog are the exception, they track changes of the
+// reported Score, because last seen stats and changelog records don't keep alpha and beta.
+func (m Metric) ComputedScore() float64 {
+	if m.Alpha+m.Beta == 0 {
+		return 0
+	}
+	return m.Alpha / (m.Alpha + m.Beta)
+}
+
+// Merge combines the metric with other, e.g. a cached metric with a freshly fetched part of it.
+// Counts, alphas and betas are added and Score and UnknownScore are recomputed from the combined
+// alpha and beta, they aren't averaged. When the combined alpha and beta are both 0, there is
+// nothing to recompute from and the higher score is kept. Merging assumes both metrics come
+// from the same satellite and cover separate periods on the same time basis.
+func (m Metric) Merge(other Metric) Metric {
+	merged := Metric{
+		TotalCount:   m.TotalCount + other.TotalCount,
+		SuccessCount: m.SuccessCount + other.SuccessCount,
+		Alpha:        m.Alpha + other.Alpha,
+		Beta:         m.Beta + other.Beta,
+		UnknownAlpha: m.UnknownAlpha + other.UnknownAlpha,
+		UnknownBeta:  m.UnknownBeta + other.UnknownBeta,
+	}
+	merged.Score = mergedScore(merged.Alpha, merged.Beta, m.Score, other.Score)
+	merged.UnknownScore = mergedScore(merged.UnknownAlpha, merged.UnknownBeta, m.UnknownScore, other.UnknownScore)
+	return merged
+}
+
+// mergedScore derives the score from the combined alpha and beta, when both are 0 the higher score is kept.
+func mergedScore(alpha, beta, score, otherScore float64) float64 {
+	if alpha+beta != 0 {
+		return alpha / (alpha + beta)
+	}
+	return math.Max(score, otherScore)
+}
+
+// Normalized returns a copy of the metric with Score and UnknownScore derived from alpha
+// and beta, so they are in [0, 1] even when the satellite reported them on a different
+// scale. Scores without alpha and beta are clamped into [0, 1]. Counts, alpha and beta
+// are kept as they are.
+func (m Metric) Normalized() Metric {
+	m.Score = normalizedScore(m.Alpha, m.Beta, m.Score)
+	m.UnknownScore = normalizedScore(m.UnknownAlpha, m.UnknownBeta, m.UnknownScore)
+	return m
+}
+
+// normalizedScore derives the score from alpha and beta, when both are 0 score is clamped instead.
+func normalizedScore(alpha, beta, score float64) float64 {
+	if alpha+beta != 0 {
+		score = alpha / (alpha + beta)
+	}
+	switch {
+	case score > 1:
+		return 1
+	case score >= 0:
+		return score
+	default:
+		// also covers NaN.
+		return 0
+	}
+}
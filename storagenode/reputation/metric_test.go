@@ -0,0 +1,111 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestComputedScore(t *testing.T) {
+	assert.Equal(t, 0.0, reputation.Metric{}.ComputedScore())
+	assert.Equal(t, 0.0, reputation.Metric{Score: 1}.ComputedScore())
+
+	// healthy metrics as reported by satellites.
+	for _, metric := range []reputation.Metric{
+		{Alpha: 20, Beta: 0, Score: 1},
+		{Alpha: 19.99, Beta: 0.01, Score: 0.9995},
+		{Alpha: 19.5, Beta: 0.5, Score: 0.975},
+		{Alpha: 18.9, Beta: 1.1, Score: 0.945},
+	} {
+		assert.InDelta(t, metric.Score, metric.ComputedScore(), 1e-9, "%+v", metric)
+	}
+
+	// the audit score is reported as computed, even when the satellite reported another one.
+	stats := reputation.Stats{Audit: reputation.Metric{Alpha: 1, Beta: 1, Score: 1}, OnlineScore: 1}
+	assert.Equal(t, 0.5, reputation.MetricAuditKnown.Score(stats))
+	assert.Equal(t, 0.5, reputation.NewStatsJSON(stats).AuditScore)
+	assert.Equal(t, 0.5, reputation.OverallHealth([]reputation.Stats{stats}).MinAuditScore)
+}
+
+func TestNormalized(t *testing.T) {
+	legacy := reputation.Metric{
+		TotalCount:   10,
+		SuccessCount: 9,
+		Alpha:        9,
+		Beta:         1,
+		UnknownAlpha: 3,
+		UnknownBeta:  1,
+		Score:        10,
+		UnknownScore: 10,
+	}
+
+	normalized := legacy.Normalized()
+	assert.Equal(t, reputation.Metric{
+		TotalCount:   10,
+		SuccessCount: 9,
+		Alpha:        9,
+		Beta:         1,
+		UnknownAlpha: 3,
+		UnknownBeta:  1,
+		Score:        0.9,
+		UnknownScore: 0.75,
+	}, normalized)
+	assert.Equal(t, 10.0, legacy.Score, "the raw metric is kept")
+
+	// scores without alpha and beta are clamped.
+	assert.Equal(t, reputation.Metric{Score: 1, UnknownScore: 0.5}, reputation.Metric{Score: 10, UnknownScore: 0.5}.Normalized())
+	assert.Equal(t, reputation.Metric{}, reputation.Metric{Score: -1, UnknownScore: math.NaN()}.Normalized())
+}
+
+func TestMetricMerge(t *testing.T) {
+	cached := reputation.Metric{
+		TotalCount:   10,
+		SuccessCount: 9,
+		Alpha:        9,
+		Beta:         1,
+		UnknownAlpha: 10,
+		UnknownBeta:  0,
+		Score:        0.9,
+		UnknownScore: 1,
+	}
+	fetched := reputation.Metric{
+		TotalCount:   5,
+		SuccessCount: 3,
+		Alpha:        3,
+		Beta:         2,
+		UnknownAlpha: 4,
+		UnknownBeta:  1,
+		Score:        0.6,
+		UnknownScore: 0.8,
+	}
+
+	merged := cached.Merge(fetched)
+	assert.Equal(t, reputation.Metric{
+		TotalCount:   15,
+		SuccessCount: 12,
+		Alpha:        12,
+		Beta:         3,
+		UnknownAlpha: 14,
+		UnknownBeta:  1,
+		// the scores are recomputed, averaging them would give 0.75 and 0.9.
+		Score:        0.8,
+		UnknownScore: 14.0 / 15,
+	}, merged)
+
+	// merging is commutative.
+	assert.Equal(t, merged, fetched.Merge(cached))
+	assert.Equal(t, merged.TotalCount, fetched.TotalCount+cached.TotalCount)
+
+	// merging with an empty metric only recomputes the scores.
+	assert.Equal(t, reputation.Metric{Alpha: 3, Beta: 1, Score: 0.75}, reputation.Metric{Alpha: 3, Beta: 1, Score: 0.5}.Merge(reputation.Metric{}))
+
+	// without alpha and beta the higher score is kept.
+	assert.Equal(t, reputation.Metric{Score: 1, UnknownScore: 0.5},
+		reputation.Metric{Score: 1}.Merge(reputation.Metric{Score: 0.5, UnknownScore: 0.5}))
+}
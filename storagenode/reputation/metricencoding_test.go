@@ -0,0 +1,101 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestMetricMarshal(t *testing.T) {
+	roundTrip := func(t *testing.T, metric reputation.Metric) {
+		data, err := metric.Marshal()
+		require.NoError(t, err)
+
+		var decoded reputation.Metric
+		require.NoError(t, decoded.Unmarshal(data))
+		require.Equal(t, metric, decoded)
+	}
+
+	t.Run("edge cases", func(t *testing.T) {
+		for _, metric := range []reputation.Metric{
+			{},
+			{TotalCount: math.MaxInt64, SuccessCount: math.MinInt64},
+			{Alpha: math.MaxFloat64, Beta: -math.MaxFloat64, UnknownAlpha: math.SmallestNonzeroFloat64},
+			{TotalCount: 100, SuccessCount: 99, Alpha: 19.9, Beta: 0.1, UnknownAlpha: 20, Score: 0.995, UnknownScore: 1},
+		} {
+			roundTrip(t, metric)
+		}
+	})
+
+	t.Run("random", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(rand.Int63()))
+		for i := 0; i < 1000; i++ {
+			roundTrip(t, reputation.Metric{
+				TotalCount:   rng.Int63() - rng.Int63(),
+				SuccessCount: rng.Int63() - rng.Int63(),
+				Alpha:        rng.NormFloat64() * 1e6,
+				Beta:         rng.NormFloat64(),
+				UnknownAlpha: rng.ExpFloat64(),
+				UnknownBeta:  rng.Float64(),
+				Score:        rng.Float64(),
+				UnknownScore: rng.Float64(),
+			})
+		}
+	})
+
+	t.Run("invalid floats", func(t *testing.T) {
+		for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+			for _, metric := range []reputation.Metric{
+				{Alpha: f}, {Beta: f}, {UnknownAlpha: f}, {UnknownBeta: f}, {Score: f}, {UnknownScore: f},
+			} {
+				_, err := metric.Marshal()
+				require.True(t, reputation.ErrMetricEncoding.Has(err), err)
+			}
+		}
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		data, err := reputation.Metric{Score: 1}.Marshal()
+		require.NoError(t, err)
+
+		var metric reputation.Metric
+		require.True(t, reputation.ErrMetricEncoding.Has(metric.Unmarshal(nil)))
+		require.True(t, reputation.ErrMetricEncoding.Has(metric.Unmarshal(data[:len(data)-1])))
+		require.True(t, reputation.ErrMetricEncoding.Has(metric.Unmarshal(append(data, 0))))
+
+		unsupported := append([]byte{}, data...)
+		unsupported[0] = 2
+		require.True(t, reputation.ErrMetricEncoding.Has(metric.Unmarshal(unsupported)))
+
+		nan, err := reputation.Metric{}.Marshal()
+		require.NoError(t, err)
+		copy(nan[len(nan)-8:], []byte{0x7f, 0xf8, 0, 0, 0, 0, 0, 1})
+		require.True(t, reputation.ErrMetricEncoding.Has(metric.Unmarshal(nan)))
+	})
+
+	t.Run("random data", func(t *testing.T) {
+		// random bytes either fail to decode or decode to a metric which is marshaled to the same bytes.
+		rng := rand.New(rand.NewSource(rand.Int63()))
+		for i := 0; i < 1000; i++ {
+			data := make([]byte, 65)
+			_, _ = rng.Read(data)
+			data[0] = 1
+
+			var metric reputation.Metric
+			if err := metric.Unmarshal(data); err != nil {
+				require.True(t, reputation.ErrMetricEncoding.Has(err), err)
+				continue
+			}
+			encoded, err := metric.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, data, encoded)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"context"
+	"sync"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+)
+
+// Metrics periodically reads reputation stats of all satellites and
+// exposes them as per satellite gauges.
+//
+// The debug server exports these in the prometheus format as
+// storagenode_audit_score, storagenode_online_score and storagenode_suspended.
+//
+// architecture: Chore
+type Metrics struct {
+	log  *zap.Logger
+	db   DB
+	Loop *sync2.Cycle
+
+	mu         sync.Mutex
+	satellites []satelliteMetrics
+}
+
+// satelliteMetrics are the exposed values of a single satellite, so only
+// they are kept instead of the whole stats.
+type satelliteMetrics struct {
+	satellite   string
+	auditScore  float64
+	onlineScore float64
+	suspended   float64
+}
+
+// NewMetrics creates a new reputation metrics chore.
+func NewMetrics(log *zap.Logger, db DB, config Config) *Metrics {
+	return &Metrics{
+		log:  log,
+		db:   db,
+		Loop: sync2.NewCycle(config.MetricsInterval),
+	}
+}
+
+// Run starts the background process which updates the reputation metrics.
+func (metrics *Metrics) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return metrics.Loop.Run(ctx, metrics.Update)
+}
+
+// Update reads reputation stats from the db and replaces the exposed values.
+// Satellites which are no longer in the db are removed from the metrics.
+func (metrics *Metrics) Update(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var satellites []satelliteMetrics
+	err = metrics.db.ForEach(ctx, func(stats Stats) error {
+		var suspended float64
+		if stats.SuspendedAt != nil || stats.OfflineSuspendedAt != nil {
+			suspended = 1
+		}
+
+		satellites = append(satellites, satelliteMetrics{
+			satellite:   stats.SatelliteID.String(),
+			auditScore:  stats.Audit.ComputedScore(),
+			onlineScore: stats.OnlineScore,
+			suspended:   suspended,
+		})
+		return nil
+	})
+	if err != nil {
+		metrics.log.Error("Could not read reputation stats", zap.Error(err))
+		return nil
+	}
+
+	metrics.mu.Lock()
+	metrics.satellites = satellites
+	metrics.mu.Unlock()
+
+	return nil
+}
+
+// Stats implements monkit.StatSource.
+func (metrics *Metrics) Stats(cb func(key monkit.SeriesKey, field string, val float64)) {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	for _, m := range metrics.satellites {
+		cb(monkit.NewSeriesKey("storagenode_audit_score").WithTag("satellite", m.satellite), "recent", m.auditScore)
+		cb(monkit.NewSeriesKey("storagenode_online_score").WithTag("satellite", m.satellite), "recent", m.onlineScore)
+		cb(monkit.NewSeriesKey("storagenode_suspended").WithTag("satellite", m.satellite), "recent", m.suspended)
+	}
+}
+
+// Close stops the background process.
+func (metrics *Metrics) Close() error {
+	metrics.Loop.Close()
+	return nil
+}
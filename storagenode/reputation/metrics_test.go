@@ -0,0 +1,75 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestMetrics(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+		now := time.Now().UTC()
+
+		healthy := reputation.Stats{
+			SatelliteID: testrand.NodeID(),
+			Audit:       reputation.Metric{Alpha: 1, Score: 1},
+			OnlineScore: 0.9,
+			UpdatedAt:   now,
+		}
+		suspended := reputation.Stats{
+			SatelliteID:        testrand.NodeID(),
+			Audit:              reputation.Metric{Alpha: 0.8, Beta: 0.2, Score: 0.8},
+			OnlineScore:        0.5,
+			OfflineSuspendedAt: &now,
+			UpdatedAt:          now.Add(-time.Hour),
+		}
+		require.NoError(t, reputationDB.Store(ctx, healthy))
+		require.NoError(t, reputationDB.Store(ctx, suspended))
+
+		metrics := reputation.NewMetrics(zaptest.NewLogger(t), reputationDB, reputation.Config{MetricsInterval: time.Hour})
+		defer ctx.Check(metrics.Close)
+
+		collect := func() map[string]float64 {
+			values := make(map[string]float64)
+			metrics.Stats(func(key monkit.SeriesKey, field string, val float64) {
+				values[key.WithField(field)] = val
+			})
+			return values
+		}
+		key := func(measurement string, stats reputation.Stats) string {
+			return monkit.NewSeriesKey(measurement).WithTag("satellite", stats.SatelliteID.String()).WithField("recent")
+		}
+
+		require.NoError(t, metrics.Update(ctx))
+
+		values := collect()
+		require.Len(t, values, 6)
+		require.Equal(t, float64(1), values[key("storagenode_audit_score", healthy)])
+		require.Equal(t, 0.9, values[key("storagenode_online_score", healthy)])
+		require.Equal(t, float64(0), values[key("storagenode_suspended", healthy)])
+		require.Equal(t, 0.8, values[key("storagenode_audit_score", suspended)])
+		require.Equal(t, 0.5, values[key("storagenode_online_score", suspended)])
+		require.Equal(t, float64(1), values[key("storagenode_suspended", suspended)])
+
+		_, err := reputationDB.DeleteBefore(ctx, now.Add(-time.Minute))
+		require.NoError(t, err)
+		require.NoError(t, metrics.Update(ctx))
+
+		values = collect()
+		require.Len(t, values, 3)
+		require.NotContains(t, values, key("storagenode_audit_score", suspended))
+	})
+}
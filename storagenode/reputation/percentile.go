@@ -0,0 +1,49 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"math"
+	"sort"
+)
+
+// OnlineScorePercentiles returns the requested percentiles, e.g. 50 and 90, of online scores
+// across satellites. Percentiles are in [0, 100], values out of the range are clamped. Scores
+// are interpolated linearly between ranks, all percentiles are 0 when stats are empty.
+func OnlineScorePercentiles(stats []Stats, ps ...float64) map[float64]float64 {
+	scores := make([]float64, 0, len(stats))
+	for _, s := range stats {
+		scores = append(scores, s.OnlineScore)
+	}
+	return percentiles(scores, ps)
+}
+
+// AuditScorePercentiles returns the requested percentiles of computed audit scores across
+// satellites, the same way as OnlineScorePercentiles.
+func AuditScorePercentiles(stats []Stats, ps ...float64) map[float64]float64 {
+	scores := make([]float64, 0, len(stats))
+	for _, s := range stats {
+		scores = append(scores, s.Audit.ComputedScore())
+	}
+	return percentiles(scores, ps)
+}
+
+// percentiles sorts scores and computes the percentiles ps of them.
+func percentiles(scores []float64, ps []float64) map[float64]float64 {
+	sort.Float64s(scores)
+
+	result := make(map[float64]float64, len(ps))
+	for _, p := range ps {
+		if len(scores) == 0 {
+			result[p] = 0
+			continue
+		}
+
+		rank := math.Max(0, math.Min(p, 100)) / 100 * float64(len(scores)-1)
+		lower := int(math.Floor(rank))
+		upper := int(math.Ceil(rank))
+		result[p] = scores[lower] + (scores[upper]-scores[lower])*(rank-float64(lower))
+	}
+	return result
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestOnlineScorePercentiles(t *testing.T) {
+	// unordered, percentiles sort a copy.
+	stats := []reputation.Stats{
+		{OnlineScore: 0.9},
+		{OnlineScore: 0.5},
+		{OnlineScore: 1},
+		{OnlineScore: 0.7},
+		{OnlineScore: 0.8},
+	}
+
+	percentiles := reputation.OnlineScorePercentiles(stats, 0, 50, 90, 100, 150)
+	assert.InDelta(t, 0.5, percentiles[0], 1e-9)
+	assert.InDelta(t, 0.8, percentiles[50], 1e-9)
+	// interpolated between 0.9 and 1.
+	assert.InDelta(t, 0.96, percentiles[90], 1e-9)
+	assert.InDelta(t, 1, percentiles[100], 1e-9)
+	assert.InDelta(t, 1, percentiles[150], 1e-9)
+	assert.Equal(t, 0.9, stats[0].OnlineScore)
+
+	single := reputation.OnlineScorePercentiles(stats[:1], 10, 90)
+	assert.Equal(t, map[float64]float64{10: 0.9, 90: 0.9}, single)
+
+	empty := reputation.OnlineScorePercentiles(nil, 50, 90)
+	assert.Equal(t, map[float64]float64{50: 0, 90: 0}, empty)
+}
+
+func TestAuditScorePercentiles(t *testing.T) {
+	stats := []reputation.Stats{
+		{Audit: reputation.Metric{Alpha: 1, Beta: 0}},
+		{Audit: reputation.Metric{Alpha: 1, Beta: 1}},
+		// score is ignored, it's computed from alpha and beta.
+		{Audit: reputation.Metric{Alpha: 3, Beta: 1, Score: 0.1}},
+	}
+
+	percentiles := reputation.AuditScorePercentiles(stats, 0, 50, 75)
+	assert.InDelta(t, 0.5, percentiles[0], 1e-9)
+	assert.InDelta(t, 0.75, percentiles[50], 1e-9)
+	assert.InDelta(t, 0.875, percentiles[75], 1e-9)
+
+	assert.Equal(t, map[float64]float64{50: 0}, reputation.AuditScorePercentiles(nil, 50))
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+const (
+	// AuditLambda is the default forgetting factor satellites use to calculate audit reputation.
+	AuditLambda = 0.95
+	// AuditWeight is the default normalization weight satellites use to calculate audit reputation.
+	AuditWeight = 1.0
+	// AuditDQThreshold is the audit score below which satellites disqualify nodes.
+	AuditDQThreshold = 0.6
+
+	// maxProjectedAudits limits how far projections look ahead.
+	maxProjectedAudits = 100000
+)
+
+// ProjectDisqualification estimates how many audits remain until the audit score
+// drops below AuditDQThreshold when audits keep failing at recentFailRate.
+// It returns ok=false when the score is not declining at the given rate.
+func ProjectDisqualification(metric Metric, recentFailRate float64) (auditsRemaining int, ok bool) {
+	return projectAuditsUntil(metric.Alpha, metric.Beta, recentFailRate, AuditDQThreshold)
+}
+
+// AuditsUntilSuspension estimates how many audits remain until the unknown audit
+// score drops below threshold when audits keep ending with unknown errors at
+// recentUnknownRate. Satellites suspend nodes with an unknown audit score below
+// AuditDQThreshold. It returns ok=false when the unknown score is not declining at the given rate.
+func AuditsUntilSuspension(m Metric, recentUnknownRate float64, threshold float64) (auditsRemaining int, ok bool) {
+	return projectAuditsUntil(m.UnknownAlpha, m.UnknownBeta, recentUnknownRate, threshold)
+}
+
+// projectAuditsUntil applies the expected beta reputation update for audits failing
+// at failRate until the score drops below threshold. It's shared by the projections
+// of the audit score and of the unknown audit score.
+func projectAuditsUntil(alpha, beta, failRate, threshold float64) (audits int, ok bool) {
+	if failRate < 0 || failRate > 1 {
+		return 0, false
+	}
+	if alpha+beta > 0 && alpha/(alpha+beta) < threshold {
+		return 0, true
+	}
+
+	next := func(alpha, beta float64) (float64, float64) {
+		return AuditLambda*alpha + AuditWeight*(1-failRate), AuditLambda*beta + AuditWeight*failRate
+	}
+
+	// the score converges to 1-failRate, so it never drops below a lower threshold.
+	if 1-failRate >= threshold {
+		return 0, false
+	}
+
+	if alpha+beta > 0 {
+		nextAlpha, nextBeta := next(alpha, beta)
+		if nextAlpha/(nextAlpha+nextBeta) >= alpha/(alpha+beta) {
+			return 0, false
+		}
+	}
+
+	for audits = 1; audits <= maxProjectedAudits; audits++ {
+		alpha, beta = next(alpha, beta)
+		if alpha/(alpha+beta) < threshold {
+			return audits, true
+		}
+	}
+
+	return 0, false
+}
+
+// SimulateAudits applies the beta reputation update rule satellites use to the
+// audit metric for each of the results, true for a passed audit, and returns the
+// resulting metric. Values for unknown audit errors are left unchanged.
+// Satellites use AuditLambda and AuditWeight by default.
+func SimulateAudits(m Metric, results []bool, lambda, weight float64) Metric {
+	for _, success := range results {
+		v := -1.0
+		if success {
+			v = 1
+			m.SuccessCount++
+		}
+		m.TotalCount++
+
+		m.Alpha = lambda*m.Alpha + weight*(1+v)/2
+		m.Beta = lambda*m.Beta + weight*(1-v)/2
+	}
+
+	if len(results) > 0 {
+		m.Score = m.ComputedScore()
+	}
+	return m
+}
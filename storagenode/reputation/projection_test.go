@@ -0,0 +1,85 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestProjectDisqualification(t *testing.T) {
+	healthy := reputation.Metric{Alpha: 20, Beta: 0}
+
+	for _, tt := range []struct {
+		name     string
+		metric   reputation.Metric
+		failRate float64
+		audits   int
+		ok       bool
+	}{
+		// with every audit failing the score decays as 0.95^n, 0.95^10 < 0.6 < 0.95^9.
+		{"all failing", healthy, 1, 10, true},
+		{"half failing", healthy, 0.5, 32, true},
+		{"mostly failing", healthy, 0.45, 43, true},
+		{"converges above threshold", healthy, 0.3, 0, false},
+		{"no failures", healthy, 0, 0, false},
+		{"improving", reputation.Metric{Alpha: 6, Beta: 3}, 0.1, 0, false},
+		{"already below threshold", reputation.Metric{Alpha: 1, Beta: 1}, 1, 0, true},
+		{"invalid fail rate", healthy, 2, 0, false},
+	} {
+		audits, ok := reputation.ProjectDisqualification(tt.metric, tt.failRate)
+		assert.Equal(t, tt.ok, ok, tt.name)
+		assert.Equal(t, tt.audits, audits, tt.name)
+	}
+}
+
+func TestAuditsUntilSuspension(t *testing.T) {
+	healthy := reputation.Metric{Alpha: 1, Beta: 1, UnknownAlpha: 20, UnknownBeta: 0}
+
+	audits, ok := reputation.AuditsUntilSuspension(healthy, 1, reputation.AuditDQThreshold)
+	assert.True(t, ok)
+	assert.Equal(t, 10, audits)
+
+	// the unknown score is projected independently of the audit score.
+	expected, _ := reputation.ProjectDisqualification(reputation.Metric{Alpha: 20, Beta: 0}, 0.5)
+	audits, ok = reputation.AuditsUntilSuspension(healthy, 0.5, reputation.AuditDQThreshold)
+	assert.True(t, ok)
+	assert.Equal(t, expected, audits)
+
+	_, ok = reputation.AuditsUntilSuspension(reputation.Metric{UnknownAlpha: 6, UnknownBeta: 3}, 0.1, reputation.AuditDQThreshold)
+	assert.False(t, ok, "rising unknown score")
+
+	_, ok = reputation.AuditsUntilSuspension(healthy, 0.5, 0.4)
+	assert.False(t, ok, "converges above threshold")
+}
+
+func TestSimulateAudits(t *testing.T) {
+	// new nodes start with alpha 1 and beta 0.
+	metric := reputation.Metric{Alpha: 1, Beta: 0, Score: 1, UnknownScore: 1}
+
+	// one failed audit followed by two passed ones:
+	//   alpha = 0.95*1 + 0 = 0.95,            beta = 0.95*0 + 1 = 1
+	//   alpha = 0.95*0.95 + 1 = 1.9025,       beta = 0.95*1 = 0.95
+	//   alpha = 0.95*1.9025 + 1 = 2.807375,   beta = 0.95*0.95 = 0.9025
+	simulated := reputation.SimulateAudits(metric, []bool{false, true, true}, reputation.AuditLambda, reputation.AuditWeight)
+	assert.InDelta(t, 2.807375, simulated.Alpha, 1e-9)
+	assert.InDelta(t, 0.9025, simulated.Beta, 1e-9)
+	assert.InDelta(t, 0.7567303480575491, simulated.Score, 1e-9)
+	assert.EqualValues(t, 3, simulated.TotalCount)
+	assert.EqualValues(t, 2, simulated.SuccessCount)
+	assert.Equal(t, float64(1), simulated.UnknownScore)
+
+	// passing the next 50 audits recovers a node with a few failures.
+	passes := make([]bool, 50)
+	for i := range passes {
+		passes[i] = true
+	}
+	recovered := reputation.SimulateAudits(reputation.Metric{Alpha: 18, Beta: 2, Score: 0.9}, passes, reputation.AuditLambda, reputation.AuditWeight)
+	assert.InDelta(t, 0.9923055024723286, recovered.Score, 1e-9)
+
+	assert.Equal(t, metric, reputation.SimulateAudits(metric, nil, reputation.AuditLambda, reputation.AuditWeight))
+}
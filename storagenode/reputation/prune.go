@@ -0,0 +1,99 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+)
+
+// PruneChore periodically deletes reputation history outside of the retention period.
+//
+// architecture: Chore
+type PruneChore struct {
+	log       *zap.Logger
+	db        DB
+	retention RetentionConfig
+	Loop      *sync2.Cycle
+}
+
+// NewPruneChore creates a new reputation history pruning chore.
+func NewPruneChore(log *zap.Logger, db DB, config Config) *PruneChore {
+	return &PruneChore{
+		log:       log,
+		db:        db,
+		retention: config.Retention,
+		Loop:      sync2.NewCycle(config.PruneInterval),
+	}
+}
+
+// Run starts the background process which prunes reputation history.
+func (chore *PruneChore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.Prune(ctx); err != nil {
+			chore.log.Error("Could not prune reputation history", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Prune deletes online score and audit activity samples older than the retention period and
+// changelog records when ChangelogDays is set, the DB is compacted when at least CompactThreshold
+// samples were deleted.
+func (chore *PruneChore) Prune(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := time.Now().UTC()
+
+	scoreHistory, err := chore.db.DeleteScoreHistoryBefore(ctx, now.AddDate(0, 0, -chore.retention.ScoreHistoryDays))
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	auditActivity, err := chore.db.DeleteAuditActivityBefore(ctx, now.AddDate(0, 0, -chore.retention.AuditActivityDays))
+	if err != nil {
+		return err
+	}
+
+	// the changelog is a compliance record, so it's only pruned when it's configured.
+	var changelog int64
+	if chore.retention.ChangelogDays > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		changelog, err = chore.db.DeleteChangelogBefore(ctx, now.AddDate(0, 0, -chore.retention.ChangelogDays))
+		if err != nil {
+			return err
+		}
+	}
+
+	chore.log.Info("Pruned reputation history",
+		zap.Int64("Online Score Samples", scoreHistory),
+		zap.Int64("Audit Activity Samples", auditActivity),
+		zap.Int64("Changelog Records", changelog))
+
+	// the freed space is only returned to the file system by compacting, which
+	// is only worth it after large deletes.
+	threshold := chore.retention.CompactThreshold
+	if threshold <= 0 || scoreHistory+auditActivity+changelog < threshold {
+		return nil
+	}
+	return chore.db.Compact(ctx)
+}
+
+// Close stops the background process.
+func (chore *PruneChore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestPruneChore(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testPruneChore(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testPruneChore(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testPruneChore(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	now := time.Now().UTC().Truncate(time.Second)
+	old := now.AddDate(0, 0, -100)
+	satelliteID := testrand.NodeID()
+
+	// every store changes the online score and audit counts, so each of them
+	// records an online score sample and all except the first an audit activity sample.
+	for i, updatedAt := range []time.Time{old, old.Add(time.Hour), now.Add(-time.Hour), now} {
+		require.NoError(t, db.Store(ctx, reputation.Stats{
+			SatelliteID: satelliteID,
+			Audit:       reputation.Metric{TotalCount: int64(i + 1), SuccessCount: int64(i + 1)},
+			OnlineScore: 1 - float64(i)/10,
+			UpdatedAt:   updatedAt,
+		}))
+	}
+
+	chore := reputation.NewPruneChore(zaptest.NewLogger(t), db, reputation.Config{
+		PruneInterval: time.Hour,
+		Retention: reputation.RetentionConfig{
+			ScoreHistoryDays:  90,
+			AuditActivityDays: 90,
+			// every prune which deletes samples compacts the db.
+			CompactThreshold: 1,
+		},
+	})
+	defer ctx.Check(chore.Close)
+
+	require.NoError(t, chore.Prune(ctx))
+
+	history, err := db.OnlineScoreHistory(ctx, satelliteID, old.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, now.Add(-time.Hour), history[0].Timestamp.UTC())
+	require.Equal(t, now, history[1].Timestamp.UTC())
+
+	activity, err := db.AuditActivity(ctx, satelliteID, old.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, activity, 2)
+	require.Equal(t, now.Add(-time.Hour), activity[0].Timestamp.UTC())
+	require.Equal(t, now, activity[1].Timestamp.UTC())
+
+	// pruning again doesn't remove samples inside the retention period.
+	require.NoError(t, chore.Prune(ctx))
+
+	history, err = db.OnlineScoreHistory(ctx, satelliteID, old.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	// the changelog is kept unless its retention is configured.
+	changelog, err := db.Changelog(ctx, satelliteID, old.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.NotEmpty(t, changelog)
+	require.Equal(t, old, changelog[0].Timestamp.UTC())
+
+	chore = reputation.NewPruneChore(zaptest.NewLogger(t), db, reputation.Config{
+		PruneInterval: time.Hour,
+		Retention: reputation.RetentionConfig{
+			ScoreHistoryDays:  90,
+			AuditActivityDays: 90,
+			ChangelogDays:     90,
+		},
+	})
+	defer ctx.Check(chore.Close)
+
+	require.NoError(t, chore.Prune(ctx))
+
+	changelog, err = db.Changelog(ctx, satelliteID, old.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.NotEmpty(t, changelog)
+	for _, record := range changelog {
+		require.False(t, record.Timestamp.Before(now.AddDate(0, 0, -90)))
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+)
+
+// ErrReadOnly is returned by ReadOnlyDB for methods which would write to the DB.
+var ErrReadOnly = errs.New("reputation database is read-only")
+
+// ReadOnlyDB wraps a DB and refuses all writes with ErrReadOnly, reads are passed through.
+type ReadOnlyDB struct {
+	DB
+}
+
+// NewReadOnlyDB creates a new DB which refuses writes to db.
+func NewReadOnlyDB(db DB) *ReadOnlyDB {
+	return &ReadOnlyDB{DB: db}
+}
+
+// Store returns ErrReadOnly.
+func (db *ReadOnlyDB) Store(ctx context.Context, stats Stats, opts ...StoreOption) error {
+	return ErrReadOnly
+}
+
+// StoreAll returns ErrReadOnly.
+func (db *ReadOnlyDB) StoreAll(ctx context.Context, stats []Stats) error { return ErrReadOnly }
+
+// ReplaceAll returns ErrReadOnly.
+func (db *ReadOnlyDB) ReplaceAll(ctx context.Context, stats []Stats) error { return ErrReadOnly }
+
+// StoreIfNewer returns ErrReadOnly.
+func (db *ReadOnlyDB) StoreIfNewer(ctx context.Context, stats Stats) (bool, error) {
+	return false, ErrReadOnly
+}
+
+// DeleteBefore returns ErrReadOnly.
+func (db *ReadOnlyDB) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, ErrReadOnly
+}
+
+// DeleteScoreHistoryBefore returns ErrReadOnly.
+func (db *ReadOnlyDB) DeleteScoreHistoryBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, ErrReadOnly
+}
+
+// DeleteAuditActivityBefore returns ErrReadOnly.
+func (db *ReadOnlyDB) DeleteAuditActivityBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, ErrReadOnly
+}
+
+// DeleteChangelogBefore returns ErrReadOnly.
+func (db *ReadOnlyDB) DeleteChangelogBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, ErrReadOnly
+}
+
+// Mute returns ErrReadOnly.
+func (db *ReadOnlyDB) Mute(ctx context.Context, satelliteID storj.NodeID) error { return ErrReadOnly }
+
+// Unmute returns ErrReadOnly.
+func (db *ReadOnlyDB) Unmute(ctx context.Context, satelliteID storj.NodeID) error { return ErrReadOnly }
+
+// TouchContact returns ErrReadOnly.
+func (db *ReadOnlyDB) TouchContact(ctx context.Context, satelliteID storj.NodeID, at time.Time) error {
+	return ErrReadOnly
+}
+
+// StoreAuditHistory returns ErrReadOnly.
+func (db *ReadOnlyDB) StoreAuditHistory(ctx context.Context, satelliteID storj.NodeID, history *pb.AuditHistory) error {
+	return ErrReadOnly
+}
+
+// Restore returns ErrReadOnly.
+func (db *ReadOnlyDB) Restore(ctx context.Context, r io.Reader, opts ...RestoreOption) error {
+	return ErrReadOnly
+}
+
+// Compact returns ErrReadOnly.
+func (db *ReadOnlyDB) Compact(ctx context.Context) error { return ErrReadOnly }
+
+// Reset returns ErrReadOnly.
+func (db *ReadOnlyDB) Reset(ctx context.Context, satelliteID storj.NodeID) error { return ErrReadOnly }
+
+// RenameSatellite returns ErrReadOnly.
+func (db *ReadOnlyDB) RenameSatellite(ctx context.Context, oldID, newID storj.NodeID) error {
+	return ErrReadOnly
+}
+
+// StoreLastSeenStates returns ErrReadOnly.
+func (db *ReadOnlyDB) StoreLastSeenStates(ctx context.Context, stats []Stats) error {
+	return ErrReadOnly
+}
+
+// Snapshot returns ErrReadOnly.
+func (db *ReadOnlyDB) Snapshot(ctx context.Context) error { return ErrReadOnly }
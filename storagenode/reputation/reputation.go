@@ -5,27 +5,171 @@ package reputation
 
 import (
 	"context"
+	"io"
+	"math"
 	"time"
 
+	"github.com/zeebo/errs"
+
 	"storj.io/common/pb"
 	"storj.io/common/storj"
 )
 
+// ErrStopIteration can be returned by the callback of DB.ForEach to stop iterating without an error.
+var ErrStopIteration = errs.New("stop iteration")
+
+// ErrSchemaVersion is returned when the reputation database schema doesn't match SchemaVersion.
+var ErrSchemaVersion = errs.Class("reputation schema version")
+
+// SchemaVersion is the version of the reputation database schema this build expects,
+// it's the version of the latest migration of the reputation database.
+const SchemaVersion = 64
+
+// ErrNoStats is returned when there are no reputation stats stored for a satellite.
+var ErrNoStats = errs.New("no reputation stats")
+
+// ErrInvalidScoreRange is returned when the minimum of a score range is above the maximum.
+var ErrInvalidScoreRange = errs.Class("invalid reputation score range")
+
+// ErrSatelliteExists is returned by DB.RenameSatellite when there are already stats for the new satellite ID.
+var ErrSatelliteExists = errs.New("reputation stats already exist for satellite")
+
+// ErrInvalidScoreHistoryEpsilon is returned when the online score history epsilon is negative.
+var ErrInvalidScoreHistoryEpsilon = errs.Class("invalid online score history epsilon")
+
+// ErrEmptyReplace is returned when ReplaceAll is called without any stats,
+// which would delete all stored stats.
+var ErrEmptyReplace = errs.New("refusing to replace reputation stats with no stats")
+
 // DB works with reputation database.
 //
 // architecture: Database
 type DB interface {
-	// Store inserts or updates reputation stats into the DB
-	Store(ctx context.Context, stats Stats) error
-	// Get retrieves stats for specific satellite
-	Get(ctx context.Context, satelliteID storj.NodeID) (*Stats, error)
-	// All retrieves all stats from DB
+	// Store inserts or updates reputation stats into the DB, stats are checked by CheckStats unless
+	// WithoutValidation is given
+	Store(ctx context.Context, stats Stats, opts ...StoreOption) error
+	// StoreAll inserts or updates all reputation stats into the DB in a single transaction,
+	// stats which don't pass CheckStats are logged and skipped
+	StoreAll(ctx context.Context, stats []Stats) error
+	// ReplaceAll replaces all stored stats with provided stats in a single transaction, stats of satellites
+	// not present in the input are deleted, returns ErrEmptyReplace when stats is empty
+	ReplaceAll(ctx context.Context, stats []Stats) error
+	// StoreIfNewer inserts stats or updates them when stats are more recent than the stored ones and differ from them
+	// besides timestamps, returns whether stats were written
+	StoreIfNewer(ctx context.Context, stats Stats) (bool, error)
+	// Get retrieves stats for specific satellite, returns ErrNoStats when there are no stats for the satellite
+	Get(ctx context.Context, satelliteID storj.NodeID, opts ...GetOption) (*Stats, error)
+	// SchemaVersion returns the version of the latest migration applied to the DB
+	SchemaVersion(ctx context.Context) (int, error)
+	// Exists returns whether stats are stored for specific satellite
+	Exists(ctx context.Context, satelliteID storj.NodeID) (bool, error)
+	// SatelliteIDs retrieves IDs of all satellites with stored stats ordered by satellite ID
+	SatelliteIDs(ctx context.Context) ([]storj.NodeID, error)
+	// GetOnlineScore retrieves only the online score of specific satellite, returns false when there are no stats
+	GetOnlineScore(ctx context.Context, satelliteID storj.NodeID) (float64, bool, error)
+	// GetAuditScore retrieves only the computed audit score of specific satellite, returns false when there are no stats
+	GetAuditScore(ctx context.Context, satelliteID storj.NodeID) (float64, bool, error)
+	// GetBySatellites retrieves stats for the specified satellites, satellites without stats are omitted
+	GetBySatellites(ctx context.Context, satelliteIDs []storj.NodeID) (map[storj.NodeID]Stats, error)
+	// All retrieves all stats from DB ordered by satellite ID
 	All(ctx context.Context) ([]Stats, error)
+	// ForEach calls fn for all stats ordered by satellite ID without loading them at once, audit history is not
+	// included. Iteration stops when fn returns an error, which is returned unless it's ErrStopIteration
+	ForEach(ctx context.Context, fn func(Stats) error) error
+	// Filter retrieves stats matching all of the provided options ordered by opts.SortBy, empty options
+	// match all stats
+	Filter(ctx context.Context, opts FilterOpts) ([]Stats, error)
+	// GetByOnlineScoreRange retrieves stats with online score in [min, max] ordered by online score,
+	// returns ErrInvalidScoreRange when min is above max
+	GetByOnlineScoreRange(ctx context.Context, min, max float64) ([]Stats, error)
+	// UpdatedSince retrieves stats updated after t ordered by UpdatedAt, stats updated exactly at t are excluded
+	UpdatedSince(ctx context.Context, t time.Time) ([]Stats, error)
+	// GetRecentlyJoined retrieves stats of satellites joined at or after since ordered by JoinedAt descending,
+	// stats without JoinedAt are excluded
+	GetRecentlyJoined(ctx context.Context, since time.Time) ([]Stats, error)
+	// DeleteBefore deletes stats updated before provided time, stats of disqualified nodes are kept
+	DeleteBefore(ctx context.Context, before time.Time) (deleted int64, err error)
+	// DeleteScoreHistoryBefore deletes online score samples recorded before provided time
+	DeleteScoreHistoryBefore(ctx context.Context, before time.Time) (deleted int64, err error)
+	// DeleteAuditActivityBefore deletes audit activity samples recorded before provided time
+	DeleteAuditActivityBefore(ctx context.Context, before time.Time) (deleted int64, err error)
+	// Mute marks stats of specific satellite as muted, returns ErrNoStats when there are no stats for the satellite
+	Mute(ctx context.Context, satelliteID storj.NodeID) error
+	// Unmute clears the muted mark of specific satellite, returns ErrNoStats when there are no stats for the satellite
+	Unmute(ctx context.Context, satelliteID storj.NodeID) error
+	// TouchContact updates only LastContactAt of specific satellite, e.g. when the fetched stats are unchanged,
+	// UpdatedAt is kept, returns ErrNoStats when there are no stats for the satellite
+	TouchContact(ctx context.Context, satelliteID storj.NodeID, at time.Time) error
+	// StoreAuditHistory replaces only AuditHistory of specific satellite, the other stats and UpdatedAt are kept,
+	// returns ErrNoStats when there are no stats for the satellite
+	StoreAuditHistory(ctx context.Context, satelliteID storj.NodeID, history *pb.AuditHistory) error
+	// Reset deletes stats of specific satellite, returns ErrNoStats when there are no stats for the satellite
+	Reset(ctx context.Context, satelliteID storj.NodeID) error
+	// RenameSatellite moves stats and history of a satellite to its new ID in a single transaction, e.g. when
+	// the satellite was re-keyed. Returns ErrNoStats when there are no stats for oldID and ErrSatelliteExists
+	// when there are already stats for newID, history left for newID by a reset is replaced. The changelog
+	// isn't changed, records stay under oldID
+	RenameSatellite(ctx context.Context, oldID, newID storj.NodeID) error
+	// Compact reclaims disk space freed by deletes, it may lock the DB for a while, so it
+	// should be run from a maintenance chore rather than while serving requests
+	Compact(ctx context.Context) error
+	// CountDisqualified returns the number of satellites which disqualified the node
+	CountDisqualified(ctx context.Context) (int, error)
+	// CountSuspended returns the number of satellites which suspended the node for unknown audit errors
+	CountSuspended(ctx context.Context) (int, error)
+	// CountOfflineSuspended returns the number of satellites which suspended the node for being offline
+	CountOfflineSuspended(ctx context.Context) (int, error)
+	// Summary returns counts of stored stats, it's maintained on every write so it's cheap to read
+	Summary(ctx context.Context) (Summary, error)
+	// Statuses classifies stats of all satellites against t, only the columns needed by Classify are read,
+	// the audit history is only read when t.MinWindows is set
+	Statuses(ctx context.Context, t Thresholds) (map[storj.NodeID]StatusReport, error)
+	// OnlineScoreHistory retrieves online score samples for specific satellite in the provided time range
+	OnlineScoreHistory(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) ([]ScoreSample, error)
+	// Sparkline retrieves the online score history of specific satellite downsampled by DownsampleScores
+	// into at most points scores, returns ErrInvalidSparkline when points isn't positive
+	Sparkline(ctx context.Context, satelliteID storj.NodeID, points int) ([]float64, error)
+	// Availability returns the time-weighted fraction of [from, to) the node was neither suspended nor disqualified
+	// on specific satellite, the node is assumed to be available before the first recorded status transition
+	Availability(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (float64, error)
+	// Changelog retrieves changes of scores and flags of specific satellite in the provided time range ordered
+	// by time, changes are recorded in the same transaction as the stats and only deleted by DeleteChangelogBefore
+	Changelog(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) ([]ChangeRecord, error)
+	// GetChanges retrieves at most MaxChanges changelog records of all satellites stored after the record
+	// with sinceID ordered by ID, and the ID to continue from, which is sinceID when there are no newer records
+	GetChanges(ctx context.Context, sinceID int64) (_ []ChangeRecord, cursor int64, err error)
+	// DeleteChangelogBefore deletes changelog records recorded before provided time
+	DeleteChangelogBefore(ctx context.Context, before time.Time) (deleted int64, err error)
+	// AuditActivity retrieves audit count changes of specific satellite in the provided time range
+	AuditActivity(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) ([]ActivitySample, error)
+	// LastSeenStates retrieves stats last seen by the transition log chore, only scores and
+	// suspension and disqualification times are included
+	LastSeenStates(ctx context.Context) (map[storj.NodeID]Stats, error)
+	// StoreLastSeenStates replaces stats last seen by the transition log chore
+	StoreLastSeenStates(ctx context.Context, stats []Stats) error
+	// Snapshot stores a copy of all current stats, so they can be retrieved later with SnapshotAt
+	Snapshot(ctx context.Context) error
+	// SnapshotAt retrieves the latest snapshot of satellite stats taken at or before t, returns ErrNoStats when there is none
+	SnapshotAt(ctx context.Context, satelliteID storj.NodeID, t time.Time) (Stats, error)
+	// Backup writes all stored stats including the audit history to w in the format read by ReadBackup
+	Backup(ctx context.Context, w io.Writer) error
+	// Restore replaces all stored stats with stats of a backup written by Backup, it refuses to replace
+	// already stored stats with ErrRestoreNotEmpty unless ForceRestore is given
+	Restore(ctx context.Context, r io.Reader, opts ...RestoreOption) error
+	// GetWorst retrieves stats of the satellite with the lowest score of the metric, returns false when there are no stats
+	GetWorst(ctx context.Context, metric MetricKind) (Stats, bool, error)
+	// OldestContact retrieves stats of the satellite with the oldest LastContactAt, stats without LastContactAt
+	// were never contacted and are the oldest, returns false when there are no stats
+	OldestContact(ctx context.Context) (Stats, bool, error)
+	// Subscribe returns a channel which receives stats whenever they are written, the channel is closed when ctx is canceled
+	Subscribe(ctx context.Context) (<-chan Stats, error)
 }
 
 // Stats consist of reputation metrics.
 type Stats struct {
 	SatelliteID storj.NodeID
+	// SatelliteAddress is empty when the address is unknown.
+	SatelliteAddress string
 
 	Uptime      Metric
 	Audit       Metric
@@ -37,10 +181,193 @@ type Stats struct {
 	OfflineUnderReviewAt *time.Time
 	AuditHistory         *pb.AuditHistory
 
+	// DisqualifiedObservedAt is when the node observed the disqualification for the first time.
+	DisqualifiedObservedAt *time.Time
+	// DisqualificationReason is DisqualificationReasonUnknown when the satellite didn't report it.
+	DisqualificationReason DisqualificationReason
+	// Generation is the reputation generation claimed by the satellite,
+	// it's increased when the satellite resets reputation.
+	Generation int64
+	// LastContactAt is when the stats were last successfully fetched from the satellite,
+	// stats stored without it keep the previously stored time.
+	LastContactAt *time.Time
+	// LastAuditAt is when the node was last audited. Satellites don't report it yet, so
+	// stats stored without it keep the previously stored time, or get UpdatedAt when
+	// the audit count increased since the previously stored stats.
+	LastAuditAt *time.Time
+	// Muted satellites don't raise notifications. It's only changed by DB.Mute and
+	// DB.Unmute, storing stats keeps the stored value.
+	Muted bool
+	// HistoryUpdatedAt is when AuditHistory was last written, it's UpdatedAt for stats
+	// written by Store, it's only returned together with AuditHistory.
+	HistoryUpdatedAt *time.Time
+	// Stale is set when the stats are read after the max age of the DB passed since they
+	// were last fetched from the satellite, see IsStale. It isn't stored.
+	Stale bool
+
+	// UpdatedAt is when the stats were last written.
 	UpdatedAt time.Time
 	JoinedAt  time.Time
 }
 
+// Summary summarizes stats of all satellites.
+type Summary struct {
+	TotalSatellites int
+	// Suspended is the number of satellites which suspended the node for unknown audit errors.
+	Suspended    int
+	Disqualified int
+	// MinOnlineScore is the lowest online score, it's 0 when there are no stats.
+	MinOnlineScore float64
+}
+
+// FilterOpts defines which stats are returned by DB.Filter.
+type FilterOpts struct {
+	// OnlySuspended matches stats of satellites which suspended the node
+	// either for unknown audit errors or for being offline.
+	OnlySuspended bool
+	// OnlyDisqualified matches stats of satellites which disqualified the node.
+	OnlyDisqualified bool
+	// MinOnlineScore matches stats with online score greater or equal.
+	MinOnlineScore *float64
+	// UpdatedAfter matches stats updated after the time.
+	UpdatedAfter *time.Time
+
+	// SortBy is the order of matched stats, ties are ordered by satellite ID.
+	SortBy SortOrder
+}
+
+// SortOrder defines the order of stats returned by DB.Filter.
+type SortOrder int
+
+const (
+	// SortBySatelliteID orders stats by satellite ID, it's the default.
+	SortBySatelliteID SortOrder = iota
+	// SortByOnlineScore orders stats by online score, the lowest first.
+	SortByOnlineScore
+	// SortByJoinedAt orders stats by the time the node joined the satellite, the earliest first.
+	SortByJoinedAt
+)
+
+// GetOption customizes which stats are read by DB.Get.
+type GetOption func(opts *GetOptions)
+
+// GetOptions are the read settings applied by GetOption.
+type GetOptions struct {
+	// WithoutAuditHistory skips reading and decoding the audit history, Stats.AuditHistory is nil.
+	WithoutAuditHistory bool
+}
+
+// WithoutAuditHistory skips reading the audit history, for callers which need only the scores.
+func WithoutAuditHistory() GetOption {
+	return func(opts *GetOptions) { opts.WithoutAuditHistory = true }
+}
+
+// NewGetOptions applies opts on top of the defaults.
+func NewGetOptions(opts []GetOption) GetOptions {
+	var options GetOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// StoreOption customizes how stats are stored by DB.Store.
+type StoreOption func(opts *StoreOptions)
+
+// StoreOptions are the store settings applied by StoreOption.
+type StoreOptions struct {
+	// WithoutValidation stores stats as they are, without any of the checks of CheckStats.
+	WithoutValidation bool
+}
+
+// WithoutValidation skips all checks of CheckStats, including score ranges. It's intended
+// only for test fixtures which store intentionally invalid values.
+func WithoutValidation() StoreOption {
+	return func(opts *StoreOptions) { opts.WithoutValidation = true }
+}
+
+// NewStoreOptions applies opts on top of the defaults.
+func NewStoreOptions(opts []StoreOption) StoreOptions {
+	var options StoreOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+const (
+	// OnlineScoreHistoryEpsilon is the default minimal online score change which is recorded in the history,
+	// the first online score of a satellite is always recorded as the baseline regardless of the epsilon.
+	OnlineScoreHistoryEpsilon = 0.001
+	// SnapshotRetention is how long stats snapshots are kept.
+	SnapshotRetention = 90 * 24 * time.Hour
+)
+
+// MetricKind selects a reputation score.
+type MetricKind int
+
+const (
+	// MetricOnline selects the online score.
+	MetricOnline MetricKind = iota
+	// MetricAuditKnown selects the audit score, which leads to disqualification.
+	MetricAuditKnown
+	// MetricAuditUnknown selects the unknown audit score, which leads to suspension.
+	MetricAuditUnknown
+)
+
+// String returns a string representation of the metric.
+func (kind MetricKind) String() string {
+	switch kind {
+	case MetricOnline:
+		return "online"
+	case MetricAuditKnown:
+		return "audit"
+	case MetricAuditUnknown:
+		return "unknownAudit"
+	default:
+		return "unknown"
+	}
+}
+
+// Score returns the score of the metric from stats.
+func (kind MetricKind) Score(stats Stats) float64 {
+	switch kind {
+	case MetricAuditKnown:
+		return stats.Audit.ComputedScore()
+	case MetricAuditUnknown:
+		return stats.Audit.UnknownScore
+	default:
+		return stats.OnlineScore
+	}
+}
+
+// ScoreSample is an online score recorded at a specific time.
+type ScoreSample struct {
+	Timestamp time.Time
+	Score     float64
+}
+
+// ActivitySample is the change of audit counts since the previously stored stats.
+type ActivitySample struct {
+	Timestamp    time.Time
+	TotalCount   int64
+	SuccessCount int64
+}
+
+// DisqualificationReason describes why a satellite disqualified the node.
+type DisqualificationReason string
+
+const (
+	// DisqualificationReasonUnknown is used when the satellite didn't report the reason.
+	DisqualificationReasonUnknown DisqualificationReason = ""
+	// DisqualificationReasonAuditFailure indicates that the node failed too many audits.
+	DisqualificationReasonAuditFailure DisqualificationReason = "audit_failure"
+	// DisqualificationReasonSuspension indicates that the node didn't recover from suspension in time.
+	DisqualificationReasonSuspension DisqualificationReason = "suspension"
+	// DisqualificationReasonNodeOffline indicates that the node was offline for too long.
+	DisqualificationReasonNodeOffline DisqualificationReason = "node_offline"
+)
+
 // Metric encapsulates storagenode reputation metrics.
 type Metric struct {
 	TotalCount   int64 `json:"totalCount"`
@@ -50,6 +377,7 @@ type Metric struct {
 	Beta         float64 `json:"beta"`
 	UnknownAlpha float64 `json:"unknownAlpha"`
 	UnknownBeta  float64 `json:"unknownBeta"`
+	// Score is the score reported by the satellite, use ComputedScore to derive it from Alpha and Beta.
 	Score        float64 `json:"score"`
 	UnknownScore float64 `json:"unknownScore"`
 }
@@ -83,3 +411,18 @@ func GetAuditHistoryFromPB(auditHistoryPB *pb.AuditHistory) AuditHistory {
 	}
 	return ah
 }
+
+// ValidateScoreHistoryEpsilon checks that epsilon can be used as the minimal online score change
+// recorded in the history, it returns ErrInvalidScoreHistoryEpsilon when it's negative or NaN.
+func ValidateScoreHistoryEpsilon(epsilon float64) error {
+	if epsilon < 0 || math.IsNaN(epsilon) {
+		return ErrInvalidScoreHistoryEpsilon.New("%v must not be negative", epsilon)
+	}
+	return nil
+}
+
+// ScoreSampleChanged returns whether score changed from the last sample enough to be recorded
+// in the online score history, i.e. by at least epsilon. A zero epsilon records every sample.
+func ScoreSampleChanged(last, score, epsilon float64) bool {
+	return math.Abs(score-last) >= epsilon
+}
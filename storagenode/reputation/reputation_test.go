@@ -4,13 +4,19 @@
 package reputation_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/pb"
+	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/storagenode"
@@ -30,19 +36,19 @@ func TestReputationDBGetInsert(t *testing.T) {
 				SuccessCount: 2,
 				Alpha:        3,
 				Beta:         4,
-				Score:        5,
+				Score:        0.5,
 			},
 			Audit: reputation.Metric{
 				TotalCount:   6,
 				SuccessCount: 7,
 				Alpha:        8,
 				Beta:         9,
-				Score:        10,
+				Score:        0.10,
 				UnknownAlpha: 11,
 				UnknownBeta:  12,
-				UnknownScore: 13,
+				UnknownScore: 0.13,
 			},
-			OnlineScore:          14,
+			OnlineScore:          0.14,
 			OfflineUnderReviewAt: &timestamp,
 			OfflineSuspendedAt:   &timestamp,
 			DisqualifiedAt:       &timestamp,
@@ -52,7 +58,8 @@ func TestReputationDBGetInsert(t *testing.T) {
 		}
 
 		t.Run("insert", func(t *testing.T) {
-			err := reputationDB.Store(ctx, stats)
+			// the fixture uses placeholder counts which aren't consistent.
+			err := reputationDB.Store(ctx, stats, reputation.WithoutValidation())
 			assert.NoError(t, err)
 		})
 
@@ -73,6 +80,12 @@ func TestReputationDBGetInsert(t *testing.T) {
 			compareReputationMetric(t, &res.Uptime, &stats.Uptime)
 			compareReputationMetric(t, &res.Audit, &stats.Audit)
 		})
+
+		t.Run("get unknown", func(t *testing.T) {
+			res, err := reputationDB.Get(ctx, testrand.NodeID())
+			assert.True(t, errors.Is(err, reputation.ErrNoStats))
+			assert.Nil(t, res)
+		})
 	})
 }
 
@@ -92,19 +105,19 @@ func TestReputationDBGetAll(t *testing.T) {
 					SuccessCount: int64(i + 2),
 					Alpha:        float64(i + 3),
 					Beta:         float64(i + 4),
-					Score:        float64(i + 5),
+					Score:        float64(i+5) / 100,
 				},
 				Audit: reputation.Metric{
 					TotalCount:   int64(i + 6),
 					SuccessCount: int64(i + 7),
 					Alpha:        float64(i + 8),
 					Beta:         float64(i + 9),
-					Score:        float64(i + 10),
+					Score:        float64(i+10) / 100,
 					UnknownAlpha: float64(i + 11),
 					UnknownBeta:  float64(i + 12),
-					UnknownScore: float64(i + 13),
+					UnknownScore: float64(i+13) / 100,
 				},
-				OnlineScore:          float64(i + 14),
+				OnlineScore:          float64(i+14) / 100,
 				OfflineUnderReviewAt: &timestamp,
 				OfflineSuspendedAt:   &timestamp,
 				DisqualifiedAt:       &timestamp,
@@ -113,7 +126,8 @@ func TestReputationDBGetAll(t *testing.T) {
 				JoinedAt:             timestamp,
 			}
 
-			err := reputationDB.Store(ctx, rep)
+			// the fixture uses placeholder counts which aren't consistent.
+			err := reputationDB.Store(ctx, rep, reputation.WithoutValidation())
 			require.NoError(t, err)
 
 			stats = append(stats, rep)
@@ -125,7 +139,14 @@ func TestReputationDBGetAll(t *testing.T) {
 		assert.Equal(t, len(stats), len(res))
 
 		for _, rep := range res {
-			assert.Contains(t, stats, rep)
+			// the observation time of the disqualification is set on store.
+			assert.NotNil(t, rep.DisqualifiedObservedAt)
+
+			var found bool
+			for _, stored := range stats {
+				found = found || stored.EqualIgnoringTimestamps(rep)
+			}
+			assert.True(t, found, "unexpected stats %v", rep.SatelliteID)
 
 			if rep.SatelliteID == stats[0].SatelliteID {
 				assert.Equal(t, rep.DisqualifiedAt, stats[0].DisqualifiedAt)
@@ -193,3 +214,2103 @@ func TestReputationDBGetInsertAuditHistory(t *testing.T) {
 		})
 	})
 }
+
+func TestReputationDBGetInvalidAuditHistory(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		timestamp := time.Now()
+		reputationDB := db.Reputation()
+
+		stats := reputation.Stats{
+			SatelliteID: testrand.NodeID(),
+			AuditHistory: &pb.AuditHistory{
+				Windows: []*pb.AuditWindow{
+					{WindowStart: timestamp, OnlineCount: 1, TotalCount: 1},
+					{WindowStart: timestamp.Add(-time.Hour), OnlineCount: 1, TotalCount: 1},
+				},
+			},
+		}
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		// invalid audit history is omitted unless strict decoding is enabled.
+		res, err := reputationDB.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		require.Nil(t, res.AuditHistory)
+
+		byID, err := reputationDB.GetBySatellites(ctx, []storj.NodeID{stats.SatelliteID})
+		require.NoError(t, err)
+		require.Contains(t, byID, stats.SatelliteID)
+		require.Nil(t, byID[stats.SatelliteID].AuditHistory)
+	})
+}
+
+func TestReputationDBReplaceAll(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		kept := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.5}
+		removed := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.5}
+		require.NoError(t, reputationDB.StoreAll(ctx, []reputation.Stats{kept, removed}))
+
+		err := reputationDB.ReplaceAll(ctx, nil)
+		require.True(t, errors.Is(err, reputation.ErrEmptyReplace))
+
+		all, err := reputationDB.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+
+		kept.OnlineScore = 0.9
+		added := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 1}
+		require.NoError(t, reputationDB.ReplaceAll(ctx, []reputation.Stats{kept, added}))
+
+		all, err = reputationDB.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+
+		scores := make(map[storj.NodeID]float64)
+		for _, stats := range all {
+			scores[stats.SatelliteID] = stats.OnlineScore
+		}
+		require.Equal(t, map[storj.NodeID]float64{
+			kept.SatelliteID:  0.9,
+			added.SatelliteID: 1,
+		}, scores)
+	})
+}
+
+func TestReputationDBSchemaVersion(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		// reputation.SchemaVersion must be bumped with every reputation database migration.
+		version, err := db.Reputation().SchemaVersion(ctx)
+		require.NoError(t, err)
+		require.Equal(t, reputation.SchemaVersion, version)
+	})
+}
+
+func TestReputationDBExists(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		stats := reputation.Stats{SatelliteID: testrand.NodeID()}
+
+		exists, err := reputationDB.Exists(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		exists, err = reputationDB.Exists(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		exists, err = reputationDB.Exists(ctx, testrand.NodeID())
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+}
+
+func TestReputationDBSatelliteIDs(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testSatelliteIDs(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testSatelliteIDs(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testSatelliteIDs(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	satelliteIDs, err := db.SatelliteIDs(ctx)
+	require.NoError(t, err)
+	require.Empty(t, satelliteIDs)
+
+	var expected []storj.NodeID
+	for i := 0; i < 3; i++ {
+		stats := reputation.Stats{
+			SatelliteID:  testrand.NodeID(),
+			AuditHistory: &pb.AuditHistory{Score: 0.5},
+		}
+		require.NoError(t, db.Store(ctx, stats))
+		expected = append(expected, stats.SatelliteID)
+	}
+	sort.Slice(expected, func(i, k int) bool { return expected[i].Less(expected[k]) })
+
+	satelliteIDs, err = db.SatelliteIDs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, satelliteIDs)
+
+	require.NoError(t, db.Reset(ctx, expected[1]))
+	satelliteIDs, err = db.SatelliteIDs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []storj.NodeID{expected[0], expected[2]}, satelliteIDs)
+}
+
+func TestReputationDBGetBySatellites(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		t.Run("empty input", func(t *testing.T) {
+			res, err := reputationDB.GetBySatellites(ctx, nil)
+			require.NoError(t, err)
+			require.NotNil(t, res)
+			require.Empty(t, res)
+		})
+
+		var satelliteIDs []storj.NodeID
+		for i := 0; i < 3; i++ {
+			stats := reputation.Stats{
+				SatelliteID: testrand.NodeID(),
+				Audit: reputation.Metric{
+					TotalCount: int64(i + 1),
+					Score:      float64(i+1) / 10,
+				},
+				OnlineScore: float64(i+1) / 10,
+				UpdatedAt:   time.Now().UTC(),
+				JoinedAt:    time.Now().UTC(),
+			}
+			require.NoError(t, reputationDB.Store(ctx, stats))
+
+			satelliteIDs = append(satelliteIDs, stats.SatelliteID)
+		}
+
+		unknownID := testrand.NodeID()
+
+		res, err := reputationDB.GetBySatellites(ctx, []storj.NodeID{satelliteIDs[0], satelliteIDs[2], unknownID})
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+
+		require.Contains(t, res, satelliteIDs[0])
+		require.Contains(t, res, satelliteIDs[2])
+		require.NotContains(t, res, satelliteIDs[1])
+		require.NotContains(t, res, unknownID)
+
+		assert.Equal(t, satelliteIDs[0], res[satelliteIDs[0]].SatelliteID)
+		assert.Equal(t, int64(1), res[satelliteIDs[0]].Audit.TotalCount)
+		assert.Equal(t, float64(3)/10, res[satelliteIDs[2]].OnlineScore)
+	})
+}
+
+func TestReputationDBDeleteBefore(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		now := time.Now().UTC()
+		old := now.Add(-90 * 24 * time.Hour)
+
+		fresh := reputation.Stats{SatelliteID: testrand.NodeID(), UpdatedAt: now}
+		stale := reputation.Stats{SatelliteID: testrand.NodeID(), UpdatedAt: old}
+		staleDisqualified := reputation.Stats{SatelliteID: testrand.NodeID(), UpdatedAt: old, DisqualifiedAt: &old}
+
+		for _, stats := range []reputation.Stats{fresh, stale, staleDisqualified} {
+			require.NoError(t, reputationDB.Store(ctx, stats))
+		}
+
+		deleted, err := reputationDB.DeleteBefore(ctx, now.Add(-30*24*time.Hour))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, deleted)
+
+		all, err := reputationDB.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+
+		var ids []storj.NodeID
+		for _, stats := range all {
+			ids = append(ids, stats.SatelliteID)
+		}
+		require.ElementsMatch(t, []storj.NodeID{fresh.SatelliteID, staleDisqualified.SatelliteID}, ids)
+
+		deleted, err = reputationDB.DeleteBefore(ctx, now.Add(-30*24*time.Hour))
+		require.NoError(t, err)
+		require.EqualValues(t, 0, deleted)
+	})
+}
+
+func TestReputationDBReset(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		now := time.Now()
+		disqualified := reputation.Stats{SatelliteID: testrand.NodeID(), DisqualifiedAt: &now}
+		other := reputation.Stats{SatelliteID: testrand.NodeID()}
+		require.NoError(t, reputationDB.StoreAll(ctx, []reputation.Stats{disqualified, other}))
+
+		require.NoError(t, reputationDB.Reset(ctx, disqualified.SatelliteID))
+
+		_, err := reputationDB.Get(ctx, disqualified.SatelliteID)
+		require.True(t, errors.Is(err, reputation.ErrNoStats))
+
+		_, err = reputationDB.Get(ctx, other.SatelliteID)
+		require.NoError(t, err)
+
+		err = reputationDB.Reset(ctx, disqualified.SatelliteID)
+		require.True(t, errors.Is(err, reputation.ErrNoStats))
+	})
+}
+
+func TestReputationDBOnlineScoreHistory(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		satelliteID := testrand.NodeID()
+		start := time.Now().UTC().Add(-time.Hour)
+
+		for i, score := range []float64{1, 1, 0.9995, 0.95, 0.9} {
+			err := reputationDB.Store(ctx, reputation.Stats{
+				SatelliteID: satelliteID,
+				OnlineScore: score,
+				UpdatedAt:   start.Add(time.Duration(i) * time.Minute),
+			})
+			require.NoError(t, err)
+		}
+
+		// samples of other satellites must not be returned.
+		err := reputationDB.Store(ctx, reputation.Stats{
+			SatelliteID: testrand.NodeID(),
+			OnlineScore: 0.5,
+			UpdatedAt:   start,
+		})
+		require.NoError(t, err)
+
+		samples, err := reputationDB.OnlineScoreHistory(ctx, satelliteID, start, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, samples, 3)
+
+		assert.Equal(t, float64(1), samples[0].Score)
+		assert.True(t, samples[0].Timestamp.Equal(start))
+		assert.Equal(t, 0.95, samples[1].Score)
+		assert.True(t, samples[1].Timestamp.Equal(start.Add(3*time.Minute)))
+		assert.Equal(t, 0.9, samples[2].Score)
+
+		samples, err = reputationDB.OnlineScoreHistory(ctx, satelliteID, start.Add(2*time.Minute), start.Add(3*time.Minute))
+		require.NoError(t, err)
+		require.Len(t, samples, 1)
+		assert.Equal(t, 0.95, samples[0].Score)
+	})
+}
+
+func TestReputationDBSparkline(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testSparkline(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testSparkline(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testSparkline(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	satelliteID := testrand.NodeID()
+
+	scores, err := db.Sparkline(ctx, satelliteID, 10)
+	require.NoError(t, err)
+	require.Empty(t, scores)
+
+	start := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	for i, score := range []float64{0.9, 0.8, 0.7, 0.6} {
+		require.NoError(t, db.Store(ctx, reputation.Stats{
+			SatelliteID: satelliteID,
+			OnlineScore: score,
+			UpdatedAt:   start.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+
+	// samples of other satellites must not be included.
+	require.NoError(t, db.Store(ctx, reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		OnlineScore: 0.1,
+		UpdatedAt:   start,
+	}))
+
+	scores, err = db.Sparkline(ctx, satelliteID, 10)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{0.9, 0.8, 0.7, 0.6}, scores, 1e-9)
+
+	scores, err = db.Sparkline(ctx, satelliteID, 2)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{0.85, 0.65}, scores, 1e-9)
+
+	_, err = db.Sparkline(ctx, satelliteID, 0)
+	require.True(t, reputation.ErrInvalidSparkline.Has(err), err)
+}
+
+func TestReputationDBAuditActivity(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		start := time.Now().UTC().Truncate(time.Hour)
+		satelliteID := testrand.NodeID()
+		store := func(offset time.Duration, total, success int64) {
+			require.NoError(t, reputationDB.Store(ctx, reputation.Stats{
+				SatelliteID: satelliteID,
+				Audit:       reputation.Metric{TotalCount: total, SuccessCount: success},
+				UpdatedAt:   start.Add(offset),
+			}))
+		}
+
+		// the first stats don't have anything to compare with.
+		store(0, 100, 90)
+		store(time.Minute, 100, 90)
+		store(2*time.Minute, 110, 99)
+		store(3*time.Minute, 115, 103)
+		// counts were reset by the satellite.
+		store(4*time.Minute, 5, 5)
+
+		samples, err := reputationDB.AuditActivity(ctx, satelliteID, start, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, samples, 2)
+
+		assert.True(t, samples[0].Timestamp.Equal(start.Add(2*time.Minute)))
+		assert.EqualValues(t, 10, samples[0].TotalCount)
+		assert.EqualValues(t, 9, samples[0].SuccessCount)
+		assert.EqualValues(t, 5, samples[1].TotalCount)
+		assert.EqualValues(t, 4, samples[1].SuccessCount)
+
+		samples, err = reputationDB.AuditActivity(ctx, satelliteID, start.Add(3*time.Minute), start.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, samples, 1)
+
+		samples, err = reputationDB.AuditActivity(ctx, testrand.NodeID(), start, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, samples)
+	})
+}
+
+func TestReputationDBSnapshot(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		stats := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.9}
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		before := time.Now()
+		_, err := reputationDB.SnapshotAt(ctx, stats.SatelliteID, before)
+		require.True(t, errors.Is(err, reputation.ErrNoStats))
+
+		require.NoError(t, reputationDB.Snapshot(ctx))
+		first := time.Now()
+
+		stats.OnlineScore = 0.5
+		require.NoError(t, reputationDB.Store(ctx, stats))
+		require.NoError(t, reputationDB.Snapshot(ctx))
+
+		res, err := reputationDB.SnapshotAt(ctx, stats.SatelliteID, first)
+		require.NoError(t, err)
+		assert.Equal(t, stats.SatelliteID, res.SatelliteID)
+		assert.Equal(t, 0.9, res.OnlineScore)
+
+		res, err = reputationDB.SnapshotAt(ctx, stats.SatelliteID, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, res.OnlineScore)
+
+		_, err = reputationDB.SnapshotAt(ctx, stats.SatelliteID, before)
+		require.True(t, errors.Is(err, reputation.ErrNoStats))
+
+		_, err = reputationDB.SnapshotAt(ctx, testrand.NodeID(), time.Now())
+		require.True(t, errors.Is(err, reputation.ErrNoStats))
+	})
+}
+
+func TestReputationDBUpdatedSince(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		start := time.Now().UTC().Truncate(time.Second)
+		first := reputation.Stats{SatelliteID: testrand.NodeID(), UpdatedAt: start.Add(500 * time.Millisecond)}
+		second := reputation.Stats{SatelliteID: testrand.NodeID(), UpdatedAt: start.Add(100 * time.Millisecond)}
+		third := reputation.Stats{SatelliteID: testrand.NodeID(), UpdatedAt: start.Add(100*time.Millisecond + time.Microsecond)}
+		require.NoError(t, reputationDB.StoreAll(ctx, []reputation.Stats{first, second, third}))
+
+		ids := func(statsList []reputation.Stats) (ids []storj.NodeID) {
+			for _, stats := range statsList {
+				ids = append(ids, stats.SatelliteID)
+			}
+			return ids
+		}
+
+		statsList, err := reputationDB.UpdatedSince(ctx, start)
+		require.NoError(t, err)
+		require.Equal(t, []storj.NodeID{second.SatelliteID, third.SatelliteID, first.SatelliteID}, ids(statsList))
+
+		// stats updated exactly at the provided time were already seen by the caller.
+		statsList, err = reputationDB.UpdatedSince(ctx, second.UpdatedAt)
+		require.NoError(t, err)
+		require.Equal(t, []storj.NodeID{third.SatelliteID, first.SatelliteID}, ids(statsList))
+
+		statsList, err = reputationDB.UpdatedSince(ctx, first.UpdatedAt)
+		require.NoError(t, err)
+		require.Empty(t, statsList)
+	})
+}
+
+func TestReputationDBGetRecentlyJoined(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testGetRecentlyJoined(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testGetRecentlyJoined(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testGetRecentlyJoined(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	now := time.Now().UTC().Truncate(time.Second)
+	old := reputation.Stats{SatelliteID: testrand.NodeID(), JoinedAt: now.AddDate(0, -1, 0)}
+	first := reputation.Stats{SatelliteID: testrand.NodeID(), JoinedAt: now.Add(-time.Hour)}
+	second := reputation.Stats{SatelliteID: testrand.NodeID(), JoinedAt: now}
+	// stats stored before joined at was tracked.
+	untracked := reputation.Stats{SatelliteID: testrand.NodeID()}
+	require.NoError(t, db.StoreAll(ctx, []reputation.Stats{old, first, second, untracked}))
+
+	ids := func(statsList []reputation.Stats) (ids []storj.NodeID) {
+		for _, stats := range statsList {
+			ids = append(ids, stats.SatelliteID)
+		}
+		return ids
+	}
+
+	statsList, err := db.GetRecentlyJoined(ctx, first.JoinedAt)
+	require.NoError(t, err)
+	require.Equal(t, []storj.NodeID{second.SatelliteID, first.SatelliteID}, ids(statsList))
+
+	statsList, err = db.GetRecentlyJoined(ctx, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, []storj.NodeID{second.SatelliteID, first.SatelliteID, old.SatelliteID}, ids(statsList))
+
+	statsList, err = db.GetRecentlyJoined(ctx, now.Add(time.Second))
+	require.NoError(t, err)
+	require.Empty(t, statsList)
+}
+
+func TestReputationDBGetByOnlineScoreRange(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testGetByOnlineScoreRange(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testGetByOnlineScoreRange(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testGetByOnlineScoreRange(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	low := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.5}
+	lowerBound := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.6}
+	middle := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.75}
+	upperBound := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.9}
+	high := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 1}
+	require.NoError(t, db.StoreAll(ctx, []reputation.Stats{high, middle, low, upperBound, lowerBound}))
+
+	ids := func(statsList []reputation.Stats) (ids []storj.NodeID) {
+		for _, stats := range statsList {
+			ids = append(ids, stats.SatelliteID)
+		}
+		return ids
+	}
+
+	// bounds are inclusive.
+	statsList, err := db.GetByOnlineScoreRange(ctx, 0.6, 0.9)
+	require.NoError(t, err)
+	require.Equal(t, []storj.NodeID{lowerBound.SatelliteID, middle.SatelliteID, upperBound.SatelliteID}, ids(statsList))
+
+	statsList, err = db.GetByOnlineScoreRange(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []storj.NodeID{high.SatelliteID}, ids(statsList))
+
+	_, err = db.GetByOnlineScoreRange(ctx, 0.9, 0.6)
+	require.True(t, reputation.ErrInvalidScoreRange.Has(err), err)
+}
+
+func TestReputationDBCounts(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		count, err := reputationDB.CountDisqualified(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		now := time.Now().UTC()
+		for _, stats := range []reputation.Stats{
+			{SatelliteID: testrand.NodeID()},
+			{SatelliteID: testrand.NodeID(), DisqualifiedAt: &now},
+			{SatelliteID: testrand.NodeID(), DisqualifiedAt: &now, SuspendedAt: &now},
+			{SatelliteID: testrand.NodeID(), SuspendedAt: &now},
+			{SatelliteID: testrand.NodeID(), SuspendedAt: &now},
+			{SatelliteID: testrand.NodeID(), SuspendedAt: &now, OfflineSuspendedAt: &now},
+			{SatelliteID: testrand.NodeID(), OfflineSuspendedAt: &now},
+		} {
+			require.NoError(t, reputationDB.Store(ctx, stats))
+		}
+
+		count, err = reputationDB.CountDisqualified(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		count, err = reputationDB.CountSuspended(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 4, count)
+
+		count, err = reputationDB.CountOfflineSuspended(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+}
+
+func TestReputationDBSummary(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testSummary(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testSummary(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testSummary(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	summary, err := db.Summary(ctx)
+	require.NoError(t, err)
+	require.Equal(t, reputation.Summary{}, summary)
+
+	now := time.Now().UTC()
+	old := now.Add(-time.Hour)
+	stale := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.3, UpdatedAt: old}
+	suspended := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.9, SuspendedAt: &now, UpdatedAt: now}
+	disqualified := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.5, DisqualifiedAt: &now, UpdatedAt: old}
+
+	require.NoError(t, db.Store(ctx, stale))
+	require.NoError(t, db.StoreAll(ctx, []reputation.Stats{suspended, disqualified}))
+
+	summary, err = db.Summary(ctx)
+	require.NoError(t, err)
+	require.Equal(t, reputation.Summary{
+		TotalSatellites: 3,
+		Suspended:       1,
+		Disqualified:    1,
+		MinOnlineScore:  0.3,
+	}, summary)
+
+	// deleting the stale stats raises the minimum online score.
+	deleted, err := db.DeleteBefore(ctx, now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, deleted)
+
+	summary, err = db.Summary(ctx)
+	require.NoError(t, err)
+	require.Equal(t, reputation.Summary{
+		TotalSatellites: 2,
+		Suspended:       1,
+		Disqualified:    1,
+		MinOnlineScore:  0.5,
+	}, summary)
+
+	require.NoError(t, db.Reset(ctx, disqualified.SatelliteID))
+
+	suspended.SuspendedAt = nil
+	suspended.UpdatedAt = now.Add(time.Minute)
+	written, err := db.StoreIfNewer(ctx, suspended)
+	require.NoError(t, err)
+	require.True(t, written)
+
+	summary, err = db.Summary(ctx)
+	require.NoError(t, err)
+	require.Equal(t, reputation.Summary{
+		TotalSatellites: 1,
+		MinOnlineScore:  0.9,
+	}, summary)
+}
+
+func TestReputationDBStoreIfNewer(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		now := time.Now().UTC()
+		satelliteID := testrand.NodeID()
+
+		written, err := reputationDB.StoreIfNewer(ctx, reputation.Stats{
+			SatelliteID: satelliteID,
+			OnlineScore: 0.5,
+			UpdatedAt:   now,
+		})
+		require.NoError(t, err)
+		require.True(t, written)
+
+		// stale stats must not overwrite the stored ones.
+		written, err = reputationDB.StoreIfNewer(ctx, reputation.Stats{
+			SatelliteID: satelliteID,
+			OnlineScore: 0.1,
+			UpdatedAt:   now.Add(-time.Minute),
+		})
+		require.NoError(t, err)
+		require.False(t, written)
+
+		// stats with equal UpdatedAt are not newer.
+		written, err = reputationDB.StoreIfNewer(ctx, reputation.Stats{
+			SatelliteID: satelliteID,
+			OnlineScore: 0.2,
+			UpdatedAt:   now,
+		})
+		require.NoError(t, err)
+		require.False(t, written)
+
+		stats, err := reputationDB.Get(ctx, satelliteID)
+		require.NoError(t, err)
+		require.Equal(t, 0.5, stats.OnlineScore)
+
+		written, err = reputationDB.StoreIfNewer(ctx, reputation.Stats{
+			SatelliteID: satelliteID,
+			OnlineScore: 0.9,
+			UpdatedAt:   now.Add(time.Millisecond),
+		})
+		require.NoError(t, err)
+		require.True(t, written)
+
+		stats, err = reputationDB.Get(ctx, satelliteID)
+		require.NoError(t, err)
+		require.Equal(t, 0.9, stats.OnlineScore)
+
+		// newer stats which only differ in timestamps aren't written.
+		contactedAt := now.Add(time.Hour)
+		written, err = reputationDB.StoreIfNewer(ctx, reputation.Stats{
+			SatelliteID:   satelliteID,
+			OnlineScore:   0.9,
+			LastContactAt: &contactedAt,
+			UpdatedAt:     now.Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.False(t, written)
+
+		stats, err = reputationDB.Get(ctx, satelliteID)
+		require.NoError(t, err)
+		require.Equal(t, now.Add(time.Millisecond), stats.UpdatedAt.UTC())
+	})
+}
+
+func TestReputationDBStoreInvalidScores(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		for _, stats := range []reputation.Stats{
+			{SatelliteID: testrand.NodeID(), Uptime: reputation.Metric{Score: 1.5}},
+			{SatelliteID: testrand.NodeID(), Uptime: reputation.Metric{UnknownScore: -0.1}},
+			{SatelliteID: testrand.NodeID(), Audit: reputation.Metric{Score: 5}},
+			{SatelliteID: testrand.NodeID(), Audit: reputation.Metric{UnknownScore: 2}},
+			{SatelliteID: testrand.NodeID(), OnlineScore: 14},
+		} {
+			err := reputationDB.Store(ctx, stats)
+			require.Error(t, err)
+			require.True(t, reputation.ErrInvalidScore.Has(err), err)
+
+			_, err = reputationDB.Get(ctx, stats.SatelliteID)
+			require.True(t, errors.Is(err, reputation.ErrNoStats))
+		}
+
+		err := reputationDB.Store(ctx, reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 14})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "OnlineScore")
+
+		t.Run("clamp", func(t *testing.T) {
+			reputation.ClampScores = true
+			defer func() { reputation.ClampScores = false }()
+
+			stats := reputation.Stats{
+				SatelliteID: testrand.NodeID(),
+				Audit:       reputation.Metric{Score: 5, UnknownScore: -1},
+				OnlineScore: 14,
+			}
+			require.NoError(t, reputationDB.Store(ctx, stats))
+
+			res, err := reputationDB.Get(ctx, stats.SatelliteID)
+			require.NoError(t, err)
+			require.Equal(t, float64(1), res.Audit.Score)
+			require.Equal(t, float64(0), res.Audit.UnknownScore)
+			require.Equal(t, float64(1), res.OnlineScore)
+		})
+	})
+}
+
+func TestReputationDBFilter(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testFilter(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testFilter(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testFilter(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	now := time.Now().UTC()
+	old := now.Add(-time.Hour)
+
+	healthy := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 1, UpdatedAt: now, JoinedAt: old.Add(-3 * time.Hour)}
+	suspended := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.9, SuspendedAt: &now, UpdatedAt: old, JoinedAt: old.Add(-time.Hour)}
+	offlineSuspended := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.5, OfflineSuspendedAt: &now, UpdatedAt: now, JoinedAt: old.Add(-2 * time.Hour)}
+	disqualified := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.4, DisqualifiedAt: &now, SuspendedAt: &now, UpdatedAt: old, JoinedAt: old}
+
+	for _, stats := range []reputation.Stats{healthy, suspended, offlineSuspended, disqualified} {
+		require.NoError(t, db.Store(ctx, stats))
+	}
+
+	filter := func(opts reputation.FilterOpts) []storj.NodeID {
+		res, err := db.Filter(ctx, opts)
+		require.NoError(t, err)
+
+		var ids []storj.NodeID
+		for _, stats := range res {
+			ids = append(ids, stats.SatelliteID)
+		}
+		return ids
+	}
+
+	all, err := db.All(ctx)
+	require.NoError(t, err)
+	filtered, err := db.Filter(ctx, reputation.FilterOpts{})
+	require.NoError(t, err)
+	require.Equal(t, all, filtered)
+
+	// all stats are ordered by satellite ID by default.
+	ids := []storj.NodeID{healthy.SatelliteID, suspended.SatelliteID, offlineSuspended.SatelliteID, disqualified.SatelliteID}
+	sort.Slice(ids, func(i, k int) bool { return ids[i].Less(ids[k]) })
+	require.Equal(t, ids, filter(reputation.FilterOpts{}))
+
+	require.Equal(t, []storj.NodeID{disqualified.SatelliteID, offlineSuspended.SatelliteID, suspended.SatelliteID, healthy.SatelliteID},
+		filter(reputation.FilterOpts{SortBy: reputation.SortByOnlineScore}))
+	require.Equal(t, []storj.NodeID{healthy.SatelliteID, offlineSuspended.SatelliteID, suspended.SatelliteID, disqualified.SatelliteID},
+		filter(reputation.FilterOpts{SortBy: reputation.SortByJoinedAt}))
+	require.Equal(t, []storj.NodeID{offlineSuspended.SatelliteID, suspended.SatelliteID, disqualified.SatelliteID},
+		filter(reputation.FilterOpts{OnlySuspended: true, SortBy: reputation.SortByJoinedAt}))
+
+	minScore := 0.9
+	require.ElementsMatch(t, []storj.NodeID{suspended.SatelliteID, offlineSuspended.SatelliteID, disqualified.SatelliteID},
+		filter(reputation.FilterOpts{OnlySuspended: true}))
+	require.ElementsMatch(t, []storj.NodeID{disqualified.SatelliteID},
+		filter(reputation.FilterOpts{OnlyDisqualified: true}))
+	require.ElementsMatch(t, []storj.NodeID{healthy.SatelliteID, suspended.SatelliteID},
+		filter(reputation.FilterOpts{MinOnlineScore: &minScore}))
+	require.ElementsMatch(t, []storj.NodeID{healthy.SatelliteID, offlineSuspended.SatelliteID},
+		filter(reputation.FilterOpts{UpdatedAfter: &old}))
+	require.ElementsMatch(t, []storj.NodeID{suspended.SatelliteID},
+		filter(reputation.FilterOpts{OnlySuspended: true, MinOnlineScore: &minScore}))
+	require.Empty(t, filter(reputation.FilterOpts{OnlyDisqualified: true, UpdatedAfter: &old}))
+}
+
+func TestReputationDBSatelliteAddress(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		withAddress := reputation.Stats{
+			SatelliteID:      testrand.NodeID(),
+			SatelliteAddress: "us1.storj.io:7777",
+		}
+		withoutAddress := reputation.Stats{
+			SatelliteID: testrand.NodeID(),
+		}
+		require.NoError(t, reputationDB.Store(ctx, withAddress))
+		require.NoError(t, reputationDB.Store(ctx, withoutAddress))
+
+		res, err := reputationDB.Get(ctx, withAddress.SatelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, withAddress.SatelliteAddress, res.SatelliteAddress)
+
+		res, err = reputationDB.Get(ctx, withoutAddress.SatelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, "", res.SatelliteAddress)
+
+		all, err := reputationDB.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		for _, stats := range all {
+			if stats.SatelliteID == withAddress.SatelliteID {
+				assert.Equal(t, withAddress.SatelliteAddress, stats.SatelliteAddress)
+			} else {
+				assert.Equal(t, "", stats.SatelliteAddress)
+			}
+		}
+	})
+}
+
+func TestReputationDBStoreAll(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		first := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.5}
+		second := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.6}
+		require.NoError(t, reputationDB.StoreAll(ctx, []reputation.Stats{first, second}))
+
+		all, err := reputationDB.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+
+		t.Run("replaces by satellite", func(t *testing.T) {
+			first.OnlineScore = 0.7
+			require.NoError(t, reputationDB.StoreAll(ctx, []reputation.Stats{first}))
+
+			res, err := reputationDB.Get(ctx, first.SatelliteID)
+			require.NoError(t, err)
+			assert.Equal(t, 0.7, res.OnlineScore)
+		})
+
+		t.Run("skips invalid", func(t *testing.T) {
+			updated := second
+			updated.OnlineScore = 0.8
+			invalid := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 2}
+			require.NoError(t, reputationDB.StoreAll(ctx, []reputation.Stats{updated, invalid}))
+
+			res, err := reputationDB.Get(ctx, second.SatelliteID)
+			require.NoError(t, err)
+			assert.Equal(t, 0.8, res.OnlineScore)
+
+			_, err = reputationDB.Get(ctx, invalid.SatelliteID)
+			assert.True(t, errors.Is(err, reputation.ErrNoStats))
+		})
+	})
+}
+
+func TestReputationDBDisqualifiedObservedAt(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		stats := reputation.Stats{SatelliteID: testrand.NodeID()}
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		res, err := reputationDB.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		assert.Nil(t, res.DisqualifiedObservedAt)
+
+		before := time.Now()
+		disqualifiedAt := before.Add(-time.Hour)
+		stats.DisqualifiedAt = &disqualifiedAt
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		res, err = reputationDB.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		require.NotNil(t, res.DisqualifiedObservedAt)
+		assert.False(t, res.DisqualifiedObservedAt.Before(before.Truncate(time.Second)))
+		observedAt := *res.DisqualifiedObservedAt
+
+		// storing again doesn't overwrite the first observation.
+		later := time.Now().Add(time.Hour)
+		stats.DisqualifiedObservedAt = &later
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		all, err := reputationDB.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		require.NotNil(t, all[0].DisqualifiedObservedAt)
+		assert.True(t, observedAt.Equal(*all[0].DisqualifiedObservedAt))
+	})
+}
+
+func TestReputationDBGeneration(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		stats := reputation.Stats{SatelliteID: testrand.NodeID(), Generation: 3}
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		res, err := reputationDB.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, res.Generation)
+
+		// lower generation is accepted.
+		stats.Generation = 1
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		all, err := reputationDB.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.EqualValues(t, 1, all[0].Generation)
+	})
+}
+
+func TestReputationDBDisqualificationReason(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		unknown := reputation.Stats{SatelliteID: testrand.NodeID()}
+		now := time.Now()
+		disqualified := reputation.Stats{
+			SatelliteID:            testrand.NodeID(),
+			DisqualifiedAt:         &now,
+			DisqualificationReason: reputation.DisqualificationReasonAuditFailure,
+		}
+		require.NoError(t, reputationDB.StoreAll(ctx, []reputation.Stats{unknown, disqualified}))
+
+		res, err := reputationDB.Get(ctx, disqualified.SatelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, reputation.DisqualificationReasonAuditFailure, res.DisqualificationReason)
+
+		res, err = reputationDB.Get(ctx, unknown.SatelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, reputation.DisqualificationReasonUnknown, res.DisqualificationReason)
+
+		all, err := reputationDB.Filter(ctx, reputation.FilterOpts{OnlyDisqualified: true})
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, reputation.DisqualificationReasonAuditFailure, all[0].DisqualificationReason)
+
+		require.NoError(t, reputationDB.Snapshot(ctx))
+		snapshot, err := reputationDB.SnapshotAt(ctx, disqualified.SatelliteID, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, reputation.DisqualificationReasonAuditFailure, snapshot.DisqualificationReason)
+	})
+}
+
+func TestReputationDBLastContactAt(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		contactedAt := time.Now().Add(-3 * time.Hour).UTC().Truncate(time.Second)
+		stats := reputation.Stats{
+			SatelliteID:   testrand.NodeID(),
+			LastContactAt: &contactedAt,
+			UpdatedAt:     contactedAt,
+		}
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		// writes without a successful fetch keep the last contact time.
+		stats.LastContactAt = nil
+		stats.UpdatedAt = time.Now()
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		res, err := reputationDB.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		require.NotNil(t, res.LastContactAt)
+		assert.Equal(t, contactedAt, res.LastContactAt.UTC())
+		assert.True(t, res.UpdatedAt.After(*res.LastContactAt))
+
+		contactedAt = time.Now().UTC().Truncate(time.Second)
+		stats.LastContactAt = &contactedAt
+		require.NoError(t, reputationDB.StoreAll(ctx, []reputation.Stats{stats}))
+
+		all, err := reputationDB.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		require.NotNil(t, all[0].LastContactAt)
+		assert.Equal(t, contactedAt, all[0].LastContactAt.UTC())
+	})
+}
+
+func TestReputationDBMute(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		err := reputationDB.Mute(ctx, testrand.NodeID())
+		require.True(t, errors.Is(err, reputation.ErrNoStats))
+
+		stats := reputation.Stats{SatelliteID: testrand.NodeID()}
+		require.NoError(t, reputationDB.Store(ctx, stats))
+		require.NoError(t, reputationDB.Mute(ctx, stats.SatelliteID))
+
+		res, err := reputationDB.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		assert.True(t, res.Muted)
+
+		// syncing stats keeps the muted flag.
+		stats.OnlineScore = 0.5
+		require.NoError(t, reputationDB.StoreAll(ctx, []reputation.Stats{stats}))
+		require.NoError(t, reputationDB.ReplaceAll(ctx, []reputation.Stats{stats}))
+
+		all, err := reputationDB.All(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.True(t, all[0].Muted)
+		assert.Equal(t, 0.5, all[0].OnlineScore)
+
+		require.NoError(t, reputationDB.Unmute(ctx, stats.SatelliteID))
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		res, err = reputationDB.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		assert.False(t, res.Muted)
+	})
+}
+
+func TestReputationDBSubscribe(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		subctx, cancel := context.WithCancel(ctx)
+		ch, err := reputationDB.Subscribe(subctx)
+		require.NoError(t, err)
+
+		stats := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.5}
+		require.NoError(t, reputationDB.Store(ctx, stats))
+
+		received := <-ch
+		assert.Equal(t, stats.SatelliteID, received.SatelliteID)
+		assert.Equal(t, stats.OnlineScore, received.OnlineScore)
+
+		// stats which are not written are not published.
+		written, err := reputationDB.StoreIfNewer(ctx, stats)
+		require.NoError(t, err)
+		require.False(t, written)
+
+		other := reputation.Stats{SatelliteID: testrand.NodeID()}
+		require.NoError(t, reputationDB.StoreAll(ctx, []reputation.Stats{other}))
+		assert.Equal(t, other.SatelliteID, (<-ch).SatelliteID)
+
+		cancel()
+		_, ok := <-ch
+		assert.False(t, ok)
+	})
+}
+
+func TestReputationDBGetWorst(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		_, ok, err := reputationDB.GetWorst(ctx, reputation.MetricOnline)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		lowOnline := reputation.Stats{
+			SatelliteID: testrand.NodeID(),
+			Audit:       reputation.Metric{Alpha: 1, Score: 1, UnknownScore: 0.9},
+			OnlineScore: 0.5,
+		}
+		lowAudit := reputation.Stats{
+			SatelliteID: testrand.NodeID(),
+			Audit:       reputation.Metric{Alpha: 0.7, Beta: 0.3, Score: 0.7, UnknownScore: 1},
+			OnlineScore: 0.9,
+		}
+		lowUnknown := reputation.Stats{
+			SatelliteID: testrand.NodeID(),
+			Audit:       reputation.Metric{Alpha: 0.8, Beta: 0.2, Score: 0.8, UnknownScore: 0.6},
+			OnlineScore: 1,
+		}
+		require.NoError(t, reputationDB.StoreAll(ctx, []reputation.Stats{lowOnline, lowAudit, lowUnknown}))
+
+		for metric, expected := range map[reputation.MetricKind]storj.NodeID{
+			reputation.MetricOnline:       lowOnline.SatelliteID,
+			reputation.MetricAuditKnown:   lowAudit.SatelliteID,
+			reputation.MetricAuditUnknown: lowUnknown.SatelliteID,
+		} {
+			worst, ok, err := reputationDB.GetWorst(ctx, metric)
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, expected, worst.SatelliteID)
+		}
+
+		_, _, err = reputationDB.GetWorst(ctx, reputation.MetricKind(100))
+		require.Error(t, err)
+	})
+}
+
+func TestReputationDBConcurrentReadWrite(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		reputationDB := db.Reputation()
+
+		const (
+			readers    = 8
+			writers    = 2
+			iterations = 100
+		)
+
+		satelliteIDs := make([]storj.NodeID, writers)
+		for i := range satelliteIDs {
+			satelliteIDs[i] = testrand.NodeID()
+			require.NoError(t, reputationDB.Store(ctx, reputation.Stats{SatelliteID: satelliteIDs[i]}))
+		}
+
+		var group errgroup.Group
+		for i := 0; i < writers; i++ {
+			satelliteID := satelliteIDs[i]
+			group.Go(func() error {
+				for k := 0; k < iterations; k++ {
+					err := reputationDB.Store(ctx, reputation.Stats{
+						SatelliteID: satelliteID,
+						Audit:       reputation.Metric{TotalCount: int64(k), SuccessCount: int64(k)},
+						OnlineScore: float64(k%10) / 10,
+						UpdatedAt:   time.Now(),
+					})
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+		for i := 0; i < readers; i++ {
+			satelliteID := satelliteIDs[i%writers]
+			group.Go(func() error {
+				for k := 0; k < iterations; k++ {
+					if _, err := reputationDB.Get(ctx, satelliteID); err != nil {
+						return err
+					}
+					if _, err := reputationDB.All(ctx); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+		require.NoError(t, group.Wait())
+	})
+}
+
+func TestReputationDBTouchContact(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testTouchContact(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testTouchContact(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testTouchContact(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	err := db.TouchContact(ctx, testrand.NodeID(), now)
+	require.True(t, errors.Is(err, reputation.ErrNoStats))
+
+	stats := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		OnlineScore: 0.5,
+		UpdatedAt:   now.Add(-time.Hour),
+	}
+	require.NoError(t, db.Store(ctx, stats))
+	require.NoError(t, db.TouchContact(ctx, stats.SatelliteID, now))
+
+	res, err := db.Get(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	require.NotNil(t, res.LastContactAt)
+	assert.True(t, now.Equal(*res.LastContactAt))
+	assert.True(t, stats.UpdatedAt.Equal(res.UpdatedAt))
+	assert.Equal(t, stats.OnlineScore, res.OnlineScore)
+}
+
+func TestReputationDBCompact(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testCompact(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testCompact(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testCompact(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	old := time.Now().UTC().AddDate(0, -1, 0)
+
+	var statsList []reputation.Stats
+	for i := 0; i < 100; i++ {
+		statsList = append(statsList, reputation.Stats{
+			SatelliteID: testrand.NodeID(),
+			OnlineScore: 0.5,
+			UpdatedAt:   old,
+		})
+	}
+	require.NoError(t, db.StoreAll(ctx, statsList))
+
+	deleted, err := db.DeleteBefore(ctx, old.Add(time.Hour))
+	require.NoError(t, err)
+	require.EqualValues(t, len(statsList), deleted)
+
+	require.NoError(t, db.Compact(ctx))
+
+	all, err := db.All(ctx)
+	require.NoError(t, err)
+	require.Empty(t, all)
+}
+
+func TestReputationDBAvailability(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testAvailability(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testAvailability(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testAvailability(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	start := time.Now().UTC().Truncate(time.Hour).AddDate(0, -1, 0)
+	suspendedAt := start.Add(10 * time.Hour)
+	satelliteID := testrand.NodeID()
+
+	// suspended at 10h, the lifted suspension is observed at 20h.
+	for _, stats := range []reputation.Stats{
+		{SatelliteID: satelliteID, UpdatedAt: start},
+		{SatelliteID: satelliteID, SuspendedAt: &suspendedAt, UpdatedAt: start.Add(12 * time.Hour)},
+		{SatelliteID: satelliteID, SuspendedAt: &suspendedAt, UpdatedAt: start.Add(16 * time.Hour)},
+		{SatelliteID: satelliteID, UpdatedAt: start.Add(20 * time.Hour)},
+	} {
+		require.NoError(t, db.Store(ctx, stats))
+	}
+
+	availability, err := db.Availability(ctx, satelliteID, start, start.Add(40*time.Hour))
+	require.NoError(t, err)
+	assert.InDelta(t, 0.75, availability, 1e-9)
+
+	availability, err = db.Availability(ctx, satelliteID, start.Add(15*time.Hour), start.Add(25*time.Hour))
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, availability, 1e-9)
+
+	availability, err = db.Availability(ctx, satelliteID, start.Add(-10*time.Hour), start)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, availability)
+
+	// satellites without transitions are available.
+	availability, err = db.Availability(ctx, testrand.NodeID(), start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, availability)
+
+	_, err = db.Availability(ctx, satelliteID, start, start)
+	require.True(t, reputation.ErrInvalidTimeRange.Has(err))
+}
+
+func TestReputationDBLastAuditAt(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testLastAuditAt(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testLastAuditAt(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testLastAuditAt(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	stats := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{TotalCount: 10, SuccessCount: 10},
+		UpdatedAt:   now.Add(-3 * time.Hour),
+	}
+
+	lastAuditAt := func() *time.Time {
+		res, err := db.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		return res.LastAuditAt
+	}
+
+	// it's unknown when the first stats were audited.
+	require.NoError(t, db.Store(ctx, stats))
+	require.Nil(t, lastAuditAt())
+
+	// the audit count increased, so the node was audited since the previous stats.
+	stats.Audit = reputation.Metric{TotalCount: 12, SuccessCount: 12}
+	stats.UpdatedAt = now.Add(-2 * time.Hour)
+	require.NoError(t, db.Store(ctx, stats))
+	require.NotNil(t, lastAuditAt())
+	require.True(t, stats.UpdatedAt.Equal(*lastAuditAt()))
+
+	// unchanged counts keep the previous time.
+	stats.UpdatedAt = now.Add(-time.Hour)
+	require.NoError(t, db.Store(ctx, stats))
+	require.True(t, now.Add(-2*time.Hour).Equal(*lastAuditAt()))
+
+	// a time set explicitly is stored as it is.
+	auditedAt := now.Add(-90 * time.Minute)
+	stats.LastAuditAt = &auditedAt
+	stats.UpdatedAt = now
+	require.NoError(t, db.Store(ctx, stats))
+	require.True(t, auditedAt.Equal(*lastAuditAt()))
+
+	all, err := db.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	require.NotNil(t, all[0].LastAuditAt)
+	require.True(t, auditedAt.Equal(*all[0].LastAuditAt))
+}
+
+func TestReputationDBStatuses(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testStatuses(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testStatuses(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testStatuses(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	now := time.Now().UTC()
+	safe := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{TotalCount: 100, SuccessCount: 100, Alpha: 20, Beta: 0, Score: 1},
+		OnlineScore: 1,
+		AuditHistory: &pb.AuditHistory{Windows: []*pb.AuditWindow{
+			{WindowStart: now.Add(-24 * time.Hour), TotalCount: 10, OnlineCount: 10},
+			{WindowStart: now.Add(-12 * time.Hour), TotalCount: 10, OnlineCount: 10},
+		}},
+	}
+	offline := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{TotalCount: 100, SuccessCount: 100, Alpha: 20, Beta: 0, Score: 1},
+		OnlineScore: 0.5,
+	}
+	failing := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{TotalCount: 100, SuccessCount: 50, Alpha: 1, Beta: 1, Score: 0.5},
+		OnlineScore: 0.95,
+	}
+	require.NoError(t, db.StoreAll(ctx, []reputation.Stats{safe, offline, failing}))
+
+	thresholds := reputation.DefaultThresholds()
+	statuses, err := db.Statuses(ctx, thresholds)
+	require.NoError(t, err)
+	require.Equal(t, map[storj.NodeID]reputation.StatusReport{
+		safe.SatelliteID:    thresholds.Classify(safe),
+		offline.SatelliteID: thresholds.Classify(offline),
+		failing.SatelliteID: thresholds.Classify(failing),
+	}, statuses)
+	require.Equal(t, reputation.RiskSafe, statuses[safe.SatelliteID].Overall)
+	require.Equal(t, reputation.RiskCritical, statuses[offline.SatelliteID].Online)
+	require.Equal(t, reputation.RiskCritical, statuses[failing.SatelliteID].Audit)
+
+	// the audit history is read when the windows are needed.
+	thresholds.MinWindows = 1
+	statuses, err = db.Statuses(ctx, thresholds)
+	require.NoError(t, err)
+	require.False(t, statuses[safe.SatelliteID].NotEnoughData)
+	require.True(t, statuses[offline.SatelliteID].NotEnoughData)
+	require.True(t, statuses[failing.SatelliteID].NotEnoughData)
+}
+
+func TestReputationDBGetScore(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testGetScore(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testGetScore(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testGetScore(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	stats := reputation.Stats{
+		SatelliteID:  testrand.NodeID(),
+		OnlineScore:  0.8,
+		Audit:        reputation.Metric{Alpha: 3, Beta: 1, Score: 0.5},
+		AuditHistory: &pb.AuditHistory{Score: 0.8},
+	}
+	require.NoError(t, db.Store(ctx, stats))
+
+	score, ok, err := db.GetOnlineScore(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 0.8, score)
+
+	// the audit score is computed from alpha and beta.
+	score, ok, err = db.GetAuditScore(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 0.75, score)
+
+	_, ok, err = db.GetOnlineScore(ctx, testrand.NodeID())
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, ok, err = db.GetAuditScore(ctx, testrand.NodeID())
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestReputationDBForEach(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testForEach(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testForEach(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testForEach(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	var ids []storj.NodeID
+	for i := 0; i < 5; i++ {
+		stats := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.5}
+		require.NoError(t, db.Store(ctx, stats))
+		ids = append(ids, stats.SatelliteID)
+	}
+	sort.Slice(ids, func(i, k int) bool { return ids[i].Less(ids[k]) })
+
+	var visited []storj.NodeID
+	require.NoError(t, db.ForEach(ctx, func(stats reputation.Stats) error {
+		visited = append(visited, stats.SatelliteID)
+		return nil
+	}))
+	require.Equal(t, ids, visited)
+
+	all, err := db.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, len(ids))
+
+	// the sentinel stops iterating without an error.
+	visited = nil
+	require.NoError(t, db.ForEach(ctx, func(stats reputation.Stats) error {
+		visited = append(visited, stats.SatelliteID)
+		if len(visited) == 2 {
+			return reputation.ErrStopIteration
+		}
+		return nil
+	}))
+	require.Equal(t, ids[:2], visited)
+
+	// other errors are returned.
+	errTest := errors.New("test")
+	err = db.ForEach(ctx, func(stats reputation.Stats) error { return errTest })
+	require.True(t, errors.Is(err, errTest))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = db.ForEach(canceled, func(stats reputation.Stats) error { return nil })
+	require.Error(t, err)
+}
+
+func TestReputationDBGetWithoutAuditHistory(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testGetWithoutAuditHistory(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testGetWithoutAuditHistory(ctx, t, reputation.NewMemory())
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testGetWithoutAuditHistory(ctx, t, reputation.NewCachedDB(reputation.NewMemory(), time.Hour))
+	})
+}
+
+func testGetWithoutAuditHistory(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	stats := reputation.Stats{
+		SatelliteID:  testrand.NodeID(),
+		OnlineScore:  0.8,
+		Audit:        reputation.Metric{Alpha: 3, Beta: 1, Score: 0.5},
+		AuditHistory: &pb.AuditHistory{Score: 0.8},
+	}
+	require.NoError(t, db.Store(ctx, stats))
+
+	withHistory, err := db.Get(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	require.NotNil(t, withHistory.AuditHistory)
+
+	withoutHistory, err := db.Get(ctx, stats.SatelliteID, reputation.WithoutAuditHistory())
+	require.NoError(t, err)
+	require.Nil(t, withoutHistory.AuditHistory)
+	require.Equal(t, stats.OnlineScore, withoutHistory.OnlineScore)
+	require.Equal(t, stats.Audit, withoutHistory.Audit)
+
+	// the rest of the stats is the same as with audit history.
+	require.NotNil(t, withHistory.HistoryUpdatedAt)
+	require.Nil(t, withoutHistory.HistoryUpdatedAt)
+	withHistory.AuditHistory = nil
+	withHistory.HistoryUpdatedAt = nil
+	require.Equal(t, withHistory, withoutHistory)
+
+	// reading without audit history doesn't affect later reads.
+	withHistory, err = db.Get(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	require.NotNil(t, withHistory.AuditHistory)
+
+	_, err = db.Get(ctx, testrand.NodeID(), reputation.WithoutAuditHistory())
+	require.True(t, errors.Is(err, reputation.ErrNoStats), err)
+}
+
+func TestReputationDBRenameSatellite(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testRenameSatellite(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testRenameSatellite(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testRenameSatellite(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	oldID, newID := testrand.NodeID(), testrand.NodeID()
+	start := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+
+	// history of newID is left after it was reset.
+	require.NoError(t, db.Store(ctx, reputation.Stats{SatelliteID: newID, OnlineScore: 0.1, UpdatedAt: start}))
+	require.NoError(t, db.Reset(ctx, newID))
+
+	for i, score := range []float64{1, 0.5} {
+		require.NoError(t, db.Store(ctx, reputation.Stats{
+			SatelliteID:  oldID,
+			OnlineScore:  score,
+			Audit:        reputation.Metric{TotalCount: int64(10 * (i + 1)), SuccessCount: int64(10 * (i + 1))},
+			AuditHistory: &pb.AuditHistory{Score: 0.5},
+			UpdatedAt:    start.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+	require.NoError(t, db.Snapshot(ctx))
+	before, err := db.Get(ctx, oldID)
+	require.NoError(t, err)
+	require.NoError(t, db.StoreLastSeenStates(ctx, []reputation.Stats{*before}))
+
+	other := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.9}
+	require.NoError(t, db.Store(ctx, other))
+
+	err = db.RenameSatellite(ctx, oldID, other.SatelliteID)
+	require.True(t, errors.Is(err, reputation.ErrSatelliteExists), err)
+	err = db.RenameSatellite(ctx, testrand.NodeID(), newID)
+	require.True(t, errors.Is(err, reputation.ErrNoStats), err)
+
+	require.NoError(t, db.RenameSatellite(ctx, oldID, newID))
+
+	_, err = db.Get(ctx, oldID)
+	require.True(t, errors.Is(err, reputation.ErrNoStats), err)
+
+	renamed, err := db.Get(ctx, newID)
+	require.NoError(t, err)
+	require.Equal(t, newID, renamed.SatelliteID)
+	require.Equal(t, before.OnlineScore, renamed.OnlineScore)
+	require.Equal(t, before.Audit, renamed.Audit)
+	require.NotNil(t, renamed.AuditHistory)
+
+	samples, err := db.OnlineScoreHistory(ctx, newID, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	require.Equal(t, 1.0, samples[0].Score)
+	require.Equal(t, 0.5, samples[1].Score)
+	samples, err = db.OnlineScoreHistory(ctx, oldID, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, samples)
+
+	activity, err := db.AuditActivity(ctx, newID, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, activity, 1)
+
+	snapshot, err := db.SnapshotAt(ctx, newID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, newID, snapshot.SatelliteID)
+	_, err = db.SnapshotAt(ctx, oldID, time.Now().Add(time.Hour))
+	require.True(t, errors.Is(err, reputation.ErrNoStats), err)
+
+	lastSeen, err := db.LastSeenStates(ctx)
+	require.NoError(t, err)
+	require.Contains(t, lastSeen, newID)
+	require.NotContains(t, lastSeen, oldID)
+
+	all, err := db.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+}
+
+func TestReputationDBOldestContact(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testOldestContact(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testOldestContact(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testOldestContact(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	_, ok, err := db.OldestContact(ctx)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	recent, stale := now.Add(-time.Hour), now.Add(-5*24*time.Hour)
+	recentStats := reputation.Stats{SatelliteID: testrand.NodeID(), LastContactAt: &recent}
+	staleStats := reputation.Stats{SatelliteID: testrand.NodeID(), LastContactAt: &stale}
+	require.NoError(t, db.StoreAll(ctx, []reputation.Stats{recentStats, staleStats}))
+
+	oldest, ok, err := db.OldestContact(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, staleStats.SatelliteID, oldest.SatelliteID)
+	require.True(t, oldest.LastContactAt.Equal(stale))
+
+	// a satellite which was never contacted is the oldest.
+	neverContacted := reputation.Stats{SatelliteID: testrand.NodeID()}
+	require.NoError(t, db.Store(ctx, neverContacted))
+
+	oldest, ok, err = db.OldestContact(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, neverContacted.SatelliteID, oldest.SatelliteID)
+	require.Nil(t, oldest.LastContactAt)
+}
+
+func TestReputationDBChangelog(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testChangelog(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testChangelog(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testChangelog(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	start := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	satelliteID := testrand.NodeID()
+
+	stats := reputation.Stats{
+		SatelliteID: satelliteID,
+		OnlineScore: 1,
+		Audit:       reputation.Metric{Score: 1, UnknownScore: 1},
+		UpdatedAt:   start,
+	}
+	require.NoError(t, db.Store(ctx, stats))
+
+	// the first stats record all fields.
+	records, err := db.Changelog(ctx, satelliteID, start, start)
+	require.NoError(t, err)
+	require.Len(t, records, 7)
+	require.Equal(t, reputation.ChangeRecord{
+		SatelliteID: satelliteID,
+		Timestamp:   start,
+		Field:       reputation.ChangeOnlineScore,
+		Before:      "",
+		After:       "1",
+	}, normalizeRecord(records[0]))
+
+	// unchanged stats don't record anything.
+	stats.UpdatedAt = start.Add(time.Minute)
+	require.NoError(t, db.Store(ctx, stats))
+
+	records, err = db.Changelog(ctx, satelliteID, start.Add(time.Minute), start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	suspendedAt := start.Add(2 * time.Minute)
+	stats.UpdatedAt = suspendedAt
+	stats.OnlineScore = 0.5
+	stats.OfflineSuspendedAt = &suspendedAt
+	require.NoError(t, db.StoreAll(ctx, []reputation.Stats{stats}))
+
+	records, err = db.Changelog(ctx, satelliteID, start.Add(time.Minute), start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []reputation.ChangeRecord{
+		{SatelliteID: satelliteID, Timestamp: suspendedAt, Field: reputation.ChangeOnlineScore, Before: "1", After: "0.5"},
+		{SatelliteID: satelliteID, Timestamp: suspendedAt, Field: reputation.ChangeOfflineSuspended, Before: "false", After: "true"},
+	}, normalizeRecords(records))
+
+	// other operations don't change the changelog.
+	require.NoError(t, db.Reset(ctx, satelliteID))
+
+	records, err = db.Changelog(ctx, satelliteID, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, records, 9)
+
+	deleted, err := db.DeleteChangelogBefore(ctx, start.Add(time.Minute))
+	require.NoError(t, err)
+	require.EqualValues(t, 7, deleted)
+
+	records, err = db.Changelog(ctx, satelliteID, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	records, err = db.Changelog(ctx, testrand.NodeID(), start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, records)
+}
+
+// normalizeRecords converts timestamps of records to UTC and clears the IDs, so they can be compared.
+func normalizeRecords(records []reputation.ChangeRecord) []reputation.ChangeRecord {
+	for i := range records {
+		records[i] = normalizeRecord(records[i])
+	}
+	return records
+}
+
+// normalizeRecord converts the timestamp of record to UTC and clears the ID, so it can be compared.
+func normalizeRecord(record reputation.ChangeRecord) reputation.ChangeRecord {
+	record.ID = 0
+	record.Timestamp = record.Timestamp.UTC()
+	return record
+}
+
+func TestReputationDBGetChanges(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testGetChanges(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testGetChanges(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testGetChanges(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	start := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	satellites := []storj.NodeID{testrand.NodeID(), testrand.NodeID()}
+
+	records, cursor, err := db.GetChanges(ctx, 0)
+	require.NoError(t, err)
+	require.Empty(t, records)
+	require.EqualValues(t, 0, cursor)
+
+	// seen counts how many times every record was returned.
+	seen := make(map[int64]int)
+	consume := func(expected int) {
+		records, next, err := db.GetChanges(ctx, cursor)
+		require.NoError(t, err)
+		require.Len(t, records, expected)
+		for i, record := range records {
+			require.True(t, record.ID > cursor, "record %d must be after the cursor", record.ID)
+			if i > 0 {
+				require.True(t, record.ID > records[i-1].ID, "records must be ordered by id")
+			}
+			seen[record.ID]++
+		}
+		if expected == 0 {
+			require.Equal(t, cursor, next)
+		} else {
+			require.Equal(t, records[len(records)-1].ID, next)
+		}
+		cursor = next
+	}
+
+	for _, satelliteID := range satellites {
+		require.NoError(t, db.Store(ctx, reputation.Stats{
+			SatelliteID: satelliteID,
+			OnlineScore: 1,
+			Audit:       reputation.Metric{Score: 1, UnknownScore: 1},
+			UpdatedAt:   start,
+		}))
+	}
+	// the first stats of both satellites record all fields.
+	consume(14)
+	consume(0)
+
+	require.NoError(t, db.Store(ctx, reputation.Stats{
+		SatelliteID: satellites[1],
+		OnlineScore: 0.5,
+		Audit:       reputation.Metric{Score: 1, UnknownScore: 1},
+		UpdatedAt:   start.Add(time.Minute),
+	}))
+
+	records, _, err = db.GetChanges(ctx, cursor)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, reputation.ChangeRecord{
+		SatelliteID: satellites[1],
+		Timestamp:   start.Add(time.Minute),
+		Field:       reputation.ChangeOnlineScore,
+		Before:      "1",
+		After:       "0.5",
+	}, normalizeRecord(records[0]))
+	consume(1)
+
+	// deleting the newest records doesn't make their ids available to newer records.
+	deleted, err := db.DeleteChangelogBefore(ctx, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.EqualValues(t, 15, deleted)
+
+	require.NoError(t, db.Store(ctx, reputation.Stats{
+		SatelliteID: satellites[0],
+		OnlineScore: 0.5,
+		Audit:       reputation.Metric{Score: 1, UnknownScore: 1},
+		UpdatedAt:   start.Add(2 * time.Minute),
+	}))
+	consume(1)
+	consume(0)
+
+	require.Len(t, seen, 16)
+	for id, count := range seen {
+		require.Equal(t, 1, count, "record %d returned more than once", id)
+	}
+}
+
+func TestReputationDBBackup(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testBackup(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testBackup(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testBackup(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	updatedAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	lastContactAt := updatedAt.Add(-time.Minute)
+	withHistory := reputation.Stats{
+		SatelliteID:      testrand.NodeID(),
+		SatelliteAddress: "satellite.example.test:7777",
+		OnlineScore:      0.8,
+		Audit:            reputation.Metric{TotalCount: 10, SuccessCount: 9, Alpha: 3, Beta: 1, Score: 0.75, UnknownScore: 1},
+		AuditHistory: &pb.AuditHistory{
+			Score: 0.8,
+			Windows: []*pb.AuditWindow{
+				{WindowStart: updatedAt.Add(-time.Hour), TotalCount: 5, OnlineCount: 4},
+				{WindowStart: updatedAt, TotalCount: 2, OnlineCount: 2},
+			},
+		},
+		LastContactAt: &lastContactAt,
+		UpdatedAt:     updatedAt,
+		JoinedAt:      updatedAt.Add(-time.Hour),
+	}
+	withoutHistory := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		OnlineScore: 1,
+		Audit:       reputation.Metric{Score: 1, UnknownScore: 1},
+		UpdatedAt:   updatedAt,
+		JoinedAt:    updatedAt.Add(-time.Hour),
+	}
+	require.NoError(t, db.StoreAll(ctx, []reputation.Stats{withHistory, withoutHistory}))
+	require.NoError(t, db.Mute(ctx, withoutHistory.SatelliteID))
+
+	satellites := []storj.NodeID{withHistory.SatelliteID, withoutHistory.SatelliteID}
+	expected := make(map[storj.NodeID]*reputation.Stats)
+	for _, satelliteID := range satellites {
+		stats, err := db.Get(ctx, satelliteID)
+		require.NoError(t, err)
+		expected[satelliteID] = stats
+	}
+	require.NotNil(t, expected[withHistory.SatelliteID].AuditHistory)
+	require.True(t, expected[withoutHistory.SatelliteID].Muted)
+
+	var backup bytes.Buffer
+	require.NoError(t, db.Backup(ctx, &backup))
+
+	requireRestored := func(db reputation.DB) {
+		for _, satelliteID := range satellites {
+			stats, err := db.Get(ctx, satelliteID)
+			require.NoError(t, err)
+			requireEqualStats(t, expected[satelliteID], stats)
+		}
+	}
+
+	// stored stats aren't replaced without forcing it.
+	err := db.Restore(ctx, bytes.NewReader(backup.Bytes()))
+	require.True(t, errors.Is(err, reputation.ErrRestoreNotEmpty), err)
+
+	// stats stored after the backup are removed by the restore.
+	added := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		OnlineScore: 1,
+		UpdatedAt:   updatedAt,
+	}
+	require.NoError(t, db.Store(ctx, added))
+	require.NoError(t, db.StoreAuditHistory(ctx, withHistory.SatelliteID, &pb.AuditHistory{Score: 0.1}))
+
+	require.NoError(t, db.Restore(ctx, bytes.NewReader(backup.Bytes()), reputation.ForceRestore()))
+	requireRestored(db)
+	_, err = db.Get(ctx, added.SatelliteID)
+	require.True(t, errors.Is(err, reputation.ErrNoStats), err)
+
+	summary, err := db.Summary(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, summary.TotalSatellites)
+
+	// the format doesn't depend on the database, an empty database doesn't need forcing.
+	memory := reputation.NewMemory()
+	require.NoError(t, memory.Restore(ctx, bytes.NewReader(backup.Bytes())))
+	requireRestored(memory)
+
+	// malformed backups don't change stored stats.
+	err = db.Restore(ctx, bytes.NewReader(backup.Bytes()[:backup.Len()/2]), reputation.ForceRestore())
+	require.True(t, reputation.ErrBackup.Has(err), err)
+	requireRestored(db)
+}
+
+// requireEqualStats checks that stats are equal including the audit history.
+func requireEqualStats(t *testing.T, expected, actual *reputation.Stats) {
+	// pb.Equal doesn't consider nil messages equal.
+	if expected.AuditHistory != nil || actual.AuditHistory != nil {
+		require.True(t, pb.Equal(expected.AuditHistory, actual.AuditHistory), "audit history of %s", expected.SatelliteID)
+	}
+
+	e, a := *expected, *actual
+	e.AuditHistory, a.AuditHistory = nil, nil
+	require.Equal(t, e, a)
+}
+
+func TestReputationDBStoreAuditHistory(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testStoreAuditHistory(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testStoreAuditHistory(ctx, t, reputation.NewMemory())
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testStoreAuditHistory(ctx, t, reputation.NewCachedDB(reputation.NewMemory(), time.Hour))
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		db := reputation.NewMemory()
+		satelliteID := testrand.NodeID()
+		require.NoError(t, db.Store(ctx, reputation.Stats{SatelliteID: satelliteID}))
+
+		err := reputation.NewReadOnlyDB(db).StoreAuditHistory(ctx, satelliteID, &pb.AuditHistory{Score: 1})
+		require.True(t, errors.Is(err, reputation.ErrReadOnly), err)
+	})
+}
+
+func testStoreAuditHistory(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	updatedAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	stats := reputation.Stats{
+		SatelliteID:  testrand.NodeID(),
+		OnlineScore:  0.8,
+		Audit:        reputation.Metric{TotalCount: 10, SuccessCount: 9, Alpha: 3, Beta: 1, Score: 0.75},
+		AuditHistory: &pb.AuditHistory{Score: 0.5},
+		UpdatedAt:    updatedAt,
+		JoinedAt:     updatedAt.Add(-time.Hour),
+	}
+	require.NoError(t, db.Store(ctx, stats))
+
+	before, err := db.Get(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	require.NotNil(t, before.HistoryUpdatedAt)
+	require.True(t, before.HistoryUpdatedAt.Equal(updatedAt))
+
+	history := &pb.AuditHistory{
+		Score: 0.9,
+		Windows: []*pb.AuditWindow{
+			{WindowStart: updatedAt, TotalCount: 4, OnlineCount: 3},
+		},
+	}
+	storedAt := time.Now()
+	require.NoError(t, db.StoreAuditHistory(ctx, stats.SatelliteID, history))
+
+	after, err := db.Get(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	require.NotNil(t, after.AuditHistory)
+	require.Equal(t, history.Score, after.AuditHistory.Score)
+	require.Len(t, after.AuditHistory.Windows, 1)
+	require.EqualValues(t, 4, after.AuditHistory.Windows[0].TotalCount)
+	require.NotNil(t, after.HistoryUpdatedAt)
+	require.False(t, after.HistoryUpdatedAt.Before(storedAt.Add(-time.Second)))
+
+	// scalar stats and UpdatedAt are preserved.
+	require.True(t, after.UpdatedAt.Equal(updatedAt))
+	expected, actual := *before, *after
+	expected.AuditHistory, expected.HistoryUpdatedAt = nil, nil
+	actual.AuditHistory, actual.HistoryUpdatedAt = nil, nil
+	require.Equal(t, expected, actual)
+
+	// a nil audit history clears it.
+	require.NoError(t, db.StoreAuditHistory(ctx, stats.SatelliteID, nil))
+	cleared, err := db.Get(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	require.Nil(t, cleared.AuditHistory)
+	require.Nil(t, cleared.HistoryUpdatedAt)
+	require.Equal(t, stats.OnlineScore, cleared.OnlineScore)
+
+	err = db.StoreAuditHistory(ctx, testrand.NodeID(), history)
+	require.True(t, errors.Is(err, reputation.ErrNoStats), err)
+}
+
+func TestReputationDBStoreValidation(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testStoreValidation(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testStoreValidation(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testStoreValidation(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	now := time.Now().UTC()
+	invalid := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{TotalCount: 1, SuccessCount: 2, Score: 0.5},
+		UpdatedAt:   now,
+		JoinedAt:    now.Add(24 * time.Hour),
+	}
+
+	err := db.Store(ctx, invalid)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Audit.SuccessCount")
+	require.Contains(t, err.Error(), "JoinedAt")
+
+	_, err = db.Get(ctx, invalid.SatelliteID)
+	require.True(t, errors.Is(err, reputation.ErrNoStats), err)
+
+	// StoreAll skips invalid stats, but stores the others.
+	valid := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 1, UpdatedAt: now}
+	require.NoError(t, db.StoreAll(ctx, []reputation.Stats{valid, invalid}))
+	_, err = db.Get(ctx, valid.SatelliteID)
+	require.NoError(t, err)
+	_, err = db.Get(ctx, invalid.SatelliteID)
+	require.True(t, errors.Is(err, reputation.ErrNoStats), err)
+
+	// validation can be skipped for fixtures, including the score ranges.
+	require.NoError(t, db.Store(ctx, invalid, reputation.WithoutValidation()))
+	stored, err := db.Get(ctx, invalid.SatelliteID)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, stored.Audit.SuccessCount)
+
+	outOfRange := invalid
+	outOfRange.OnlineScore = 2
+	require.NoError(t, db.Store(ctx, outOfRange, reputation.WithoutValidation()))
+	stored, err = db.Get(ctx, outOfRange.SatelliteID)
+	require.NoError(t, err)
+	require.Equal(t, float64(2), stored.OnlineScore)
+
+	// the option applies only to the store it's given to.
+	err = db.Store(ctx, outOfRange)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "OnlineScore")
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+// RiskLevel describes how close a node is to being disqualified by a satellite.
+type RiskLevel int
+
+const (
+	// RiskSafe indicates that the audit score is comfortably above the disqualification cut-off.
+	RiskSafe RiskLevel = iota
+	// RiskWarning indicates that the audit score is approaching the disqualification cut-off.
+	RiskWarning
+	// RiskCritical indicates that the node is about to be disqualified.
+	RiskCritical
+)
+
+// String returns a string representation of the risk level.
+func (level RiskLevel) String() string {
+	switch level {
+	case RiskSafe:
+		return "safe"
+	case RiskWarning:
+		return "warning"
+	case RiskCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"math"
+	"sort"
+
+	"storj.io/common/storj"
+)
+
+// SatelliteRollup aggregates reputation of multiple nodes on a satellite.
+type SatelliteRollup struct {
+	SatelliteID storj.NodeID
+	// Nodes is the number of nodes with stats for the satellite.
+	Nodes int
+
+	AvgOnlineScore float64
+	MinOnlineScore float64
+	MaxOnlineScore float64
+
+	// Audit scores are computed from alpha and beta.
+	AvgAuditScore float64
+	MinAuditScore float64
+	MaxAuditScore float64
+
+	// SuspendedNodes are the nodes suspended by the satellite either for unknown
+	// audit errors or for being offline, ordered by node ID.
+	SuspendedNodes []storj.NodeID
+}
+
+// GroupBySatellite aggregates stats of multiple nodes, e.g. as returned by DB.All of every
+// node, per satellite. It helps to tell whether a low score is common to all nodes on a
+// satellite or specific to some of them.
+func GroupBySatellite(perNode map[storj.NodeID][]Stats) map[storj.NodeID]SatelliteRollup {
+	rollups := make(map[storj.NodeID]SatelliteRollup)
+	for nodeID, statsList := range perNode {
+		for _, stats := range statsList {
+			onlineScore := stats.OnlineScore
+			auditScore := stats.Audit.ComputedScore()
+
+			rollup, ok := rollups[stats.SatelliteID]
+			if !ok {
+				rollup = SatelliteRollup{
+					SatelliteID:    stats.SatelliteID,
+					MinOnlineScore: onlineScore,
+					MaxOnlineScore: onlineScore,
+					MinAuditScore:  auditScore,
+					MaxAuditScore:  auditScore,
+				}
+			}
+
+			rollup.Nodes++
+			// averages are kept as sums until all stats are added.
+			rollup.AvgOnlineScore += onlineScore
+			rollup.AvgAuditScore += auditScore
+			rollup.MinOnlineScore = math.Min(rollup.MinOnlineScore, onlineScore)
+			rollup.MaxOnlineScore = math.Max(rollup.MaxOnlineScore, onlineScore)
+			rollup.MinAuditScore = math.Min(rollup.MinAuditScore, auditScore)
+			rollup.MaxAuditScore = math.Max(rollup.MaxAuditScore, auditScore)
+
+			if stats.SuspendedAt != nil || stats.OfflineSuspendedAt != nil {
+				rollup.SuspendedNodes = append(rollup.SuspendedNodes, nodeID)
+			}
+
+			rollups[stats.SatelliteID] = rollup
+		}
+	}
+
+	for satelliteID, rollup := range rollups {
+		rollup.AvgOnlineScore /= float64(rollup.Nodes)
+		rollup.AvgAuditScore /= float64(rollup.Nodes)
+		sort.Slice(rollup.SuspendedNodes, func(i, k int) bool {
+			return rollup.SuspendedNodes[i].Less(rollup.SuspendedNodes[k])
+		})
+		rollups[satelliteID] = rollup
+	}
+	return rollups
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestGroupBySatellite(t *testing.T) {
+	now := time.Now()
+	shared, onlyA, onlyB := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+	nodes := []storj.NodeID{testrand.NodeID(), testrand.NodeID(), testrand.NodeID()}
+	sort.Slice(nodes, func(i, k int) bool { return nodes[i].Less(nodes[k]) })
+
+	perNode := map[storj.NodeID][]reputation.Stats{
+		nodes[0]: {
+			{SatelliteID: shared, OnlineScore: 1, Audit: reputation.Metric{Alpha: 1, Beta: 0}},
+			{SatelliteID: onlyA, OnlineScore: 0.9, Audit: reputation.Metric{Alpha: 1, Beta: 0}, OfflineSuspendedAt: &now},
+		},
+		nodes[1]: {
+			{SatelliteID: shared, OnlineScore: 0.4, Audit: reputation.Metric{Alpha: 1, Beta: 1}, SuspendedAt: &now},
+		},
+		nodes[2]: {
+			{SatelliteID: shared, OnlineScore: 0.7, Audit: reputation.Metric{Alpha: 3, Beta: 1}, OfflineSuspendedAt: &now},
+			{SatelliteID: onlyB, OnlineScore: 0.6, Audit: reputation.Metric{Alpha: 1, Beta: 1}},
+		},
+		// nodes without stats don't affect the rollups.
+		testrand.NodeID(): nil,
+	}
+
+	rollups := reputation.GroupBySatellite(perNode)
+	require.Len(t, rollups, 3)
+
+	t.Run("overlapping", func(t *testing.T) {
+		rollup := rollups[shared]
+		require.Equal(t, shared, rollup.SatelliteID)
+		require.Equal(t, 3, rollup.Nodes)
+		require.InDelta(t, 0.7, rollup.AvgOnlineScore, 1e-9)
+		require.Equal(t, 0.4, rollup.MinOnlineScore)
+		require.Equal(t, 1.0, rollup.MaxOnlineScore)
+		require.InDelta(t, 0.75, rollup.AvgAuditScore, 1e-9)
+		require.Equal(t, 0.5, rollup.MinAuditScore)
+		require.Equal(t, 1.0, rollup.MaxAuditScore)
+		require.Equal(t, []storj.NodeID{nodes[1], nodes[2]}, rollup.SuspendedNodes)
+	})
+
+	t.Run("disjoint", func(t *testing.T) {
+		require.Equal(t, reputation.SatelliteRollup{
+			SatelliteID:    onlyA,
+			Nodes:          1,
+			AvgOnlineScore: 0.9,
+			MinOnlineScore: 0.9,
+			MaxOnlineScore: 0.9,
+			AvgAuditScore:  1,
+			MinAuditScore:  1,
+			MaxAuditScore:  1,
+			SuspendedNodes: []storj.NodeID{nodes[0]},
+		}, rollups[onlyA])
+
+		require.Equal(t, reputation.SatelliteRollup{
+			SatelliteID:    onlyB,
+			Nodes:          1,
+			AvgOnlineScore: 0.6,
+			MinOnlineScore: 0.6,
+			MaxOnlineScore: 0.6,
+			AvgAuditScore:  0.5,
+			MinAuditScore:  0.5,
+			MaxAuditScore:  0.5,
+		}, rollups[onlyB])
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		require.Empty(t, reputation.GroupBySatellite(nil))
+	})
+}
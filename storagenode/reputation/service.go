@@ -5,13 +5,43 @@ package reputation
 
 import (
 	"context"
+	"time"
 
+	"github.com/spacemonkeygo/monkit/v3"
 	"go.uber.org/zap"
 
 	"storj.io/common/storj"
 	"storj.io/storj/storagenode/notifications"
 )
 
+var mon = monkit.Package()
+
+// Config defines reputation service configuration.
+type Config struct {
+	MetricsInterval     time.Duration `help:"how often to update reputation metrics" releaseDefault:"5m" devDefault:"1m"`
+	QueryTimeout        time.Duration `help:"timeout for reputation database queries which don't have a deadline" default:"5s"`
+	StrictDecode        bool          `help:"fail reading reputation stats when the stored audit history can't be decoded instead of omitting it" default:"false"`
+	ScoreHistoryEpsilon float64       `help:"minimal online score change which is recorded in the online score history, 0 records the score of every sync" default:"0.001"`
+	AlertInterval       time.Duration `help:"how often to check whether online scores crossed alert thresholds" releaseDefault:"5m" devDefault:"1m"`
+	PruneInterval       time.Duration `help:"how often to prune reputation history outside of the retention period" releaseDefault:"24h" devDefault:"1h"`
+	TransitionInterval  time.Duration `help:"how often to check for reputation transitions to log" releaseDefault:"5m" devDefault:"1m"`
+	CacheTTL            time.Duration `help:"how long reputation stats read from the database are cached" default:"30s"`
+	MaxAge              time.Duration `help:"reputation stats not fetched from the satellite for longer are marked as stale when read, 0 never marks them as stale" default:"0"`
+	ThresholdsFile      string        `help:"path to a json file with score thresholds of reputation alerts and dashboard statuses, the default thresholds are used when empty" default:""`
+	Hysteresis          float64       `help:"margin scores have to rise above a threshold before a worse dashboard status is lifted, 0 disables the hysteresis" default:"0.01"`
+	Retention           RetentionConfig
+	Webhook             WebhookConfig
+	RapidDrop           RapidDropConfig
+}
+
+// RetentionConfig defines how long reputation history is kept.
+type RetentionConfig struct {
+	ScoreHistoryDays  int   `help:"number of days to keep online score history" default:"90"`
+	AuditActivityDays int   `help:"number of days to keep audit activity history" default:"90"`
+	ChangelogDays     int   `help:"number of days to keep the reputation changelog, 0 keeps it forever" default:"0"`
+	CompactThreshold  int64 `help:"number of samples pruned at once after which the reputation db is compacted, 0 disables compaction" default:"10000"`
+}
+
 // Service is the reputation service.
 //
 // architecture: Service
@@ -33,19 +63,70 @@ func NewService(log *zap.Logger, db DB, nodeID storj.NodeID, notifications *noti
 	}
 }
 
-// Store stores reputation stats into db, and notify's in case of offline suspension.
-func (s *Service) Store(ctx context.Context, stats Stats, satelliteID storj.NodeID) error {
-	if err := s.db.Store(ctx, stats); err != nil {
+// CheckSchemaVersion returns ErrSchemaVersion when the schema of the database doesn't
+// match SchemaVersion, e.g. because a migration wasn't fully applied.
+func (s *Service) CheckSchemaVersion(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	version, err := s.db.SchemaVersion(ctx)
+	if err != nil {
+		return ErrSchemaVersion.Wrap(err)
+	}
+	if version != SchemaVersion {
+		return ErrSchemaVersion.New("database is at version %d, expected %d", version, SchemaVersion)
+	}
+	return nil
+}
+
+// StoreAll stores reputation stats of all satellites into db at once, and notify's in case of offline suspension.
+// Satellites muted in the stored stats don't notify, stats from satellites never carry the muted flag.
+func (s *Service) StoreAll(ctx context.Context, stats []Stats) error {
+	satelliteIDs := make([]storj.NodeID, 0, len(stats))
+	for _, stat := range stats {
+		satelliteIDs = append(satelliteIDs, stat.SatelliteID)
+	}
+
+	stored, err := s.db.GetBySatellites(ctx, satelliteIDs)
+	if err != nil {
+		s.log.Warn("failed to get stored reputation", zap.Error(err))
+	} else {
+		s.logStoredChanges(stats, stored)
+	}
+
+	if err := s.db.StoreAll(ctx, stats); err != nil {
 		return err
 	}
 
-	if stats.DisqualifiedAt == nil && stats.OfflineSuspendedAt != nil {
-		s.notifyOfflineSuspension(ctx, satelliteID)
+	for _, stat := range stats {
+		if stored[stat.SatelliteID].Muted {
+			continue
+		}
+		if stat.DisqualifiedAt == nil && stat.OfflineSuspendedAt != nil {
+			s.notifyOfflineSuspension(ctx, stat.SatelliteID)
+		}
 	}
 
 	return nil
 }
 
+// logStoredChanges logs satellites without stored stats and warns about stats with a lower
+// generation than the stored one, which means the satellite has reset reputation. Such stats
+// are still stored.
+func (s *Service) logStoredChanges(stats []Stats, stored map[storj.NodeID]Stats) {
+	for _, stat := range stats {
+		prev, ok := stored[stat.SatelliteID]
+		switch {
+		case !ok:
+			s.log.Info("storing first reputation stats of satellite", zap.Stringer("Satellite ID", stat.SatelliteID))
+		case stat.Generation < prev.Generation:
+			s.log.Warn("satellite reported lower reputation generation",
+				zap.Stringer("Satellite ID", stat.SatelliteID),
+				zap.Int64("stored", prev.Generation),
+				zap.Int64("received", stat.Generation))
+		}
+	}
+}
+
 // NotifyOfflineSuspension notifies storagenode about offline suspension.
 func (s *Service) notifyOfflineSuspension(ctx context.Context, satelliteID storj.NodeID) {
 	notification := NewSuspensionNotification(satelliteID, s.nodeID)
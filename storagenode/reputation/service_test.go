@@ -0,0 +1,95 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/notifications"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestServiceGenerationDecrease(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	core, logs := observer.New(zap.InfoLevel)
+	db := reputation.NewMemory()
+	service := reputation.NewService(zap.New(core), db, testrand.NodeID(), nil)
+
+	stats := reputation.Stats{SatelliteID: testrand.NodeID(), Generation: 2}
+	require.NoError(t, service.StoreAll(ctx, []reputation.Stats{stats}))
+	assert.Equal(t, 1, logs.FilterMessage("storing first reputation stats of satellite").Len())
+	assert.Equal(t, 1, logs.Len())
+
+	require.NoError(t, service.StoreAll(ctx, []reputation.Stats{stats}))
+	assert.Equal(t, 1, logs.Len())
+
+	stats.Generation = 1
+	require.NoError(t, service.StoreAll(ctx, []reputation.Stats{stats}))
+	assert.Equal(t, 1, logs.FilterMessage("satellite reported lower reputation generation").Len())
+	assert.Equal(t, 1, logs.FilterMessage("storing first reputation stats of satellite").Len())
+
+	res, err := db.Get(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Generation)
+}
+
+// staleSchemaDB is a reputation DB with a schema behind reputation.SchemaVersion.
+type staleSchemaDB struct {
+	*reputation.MemoryDB
+}
+
+func (staleSchemaDB) SchemaVersion(ctx context.Context) (int, error) {
+	return reputation.SchemaVersion - 1, nil
+}
+
+func TestServiceCheckSchemaVersion(t *testing.T) {
+	ctx := testcontext.New(t)
+
+	service := reputation.NewService(zaptest.NewLogger(t), reputation.NewMemory(), testrand.NodeID(), nil)
+	require.NoError(t, service.CheckSchemaVersion(ctx))
+
+	service = reputation.NewService(zaptest.NewLogger(t), staleSchemaDB{reputation.NewMemory()}, testrand.NodeID(), nil)
+	err := service.CheckSchemaVersion(ctx)
+	require.Error(t, err)
+	require.True(t, reputation.ErrSchemaVersion.Has(err))
+}
+
+func TestServiceMutedNotifications(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := reputation.NewService(log, db.Reputation(), testrand.NodeID(), notifications.NewService(log, db.Notifications()))
+
+		muted := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 1}
+		require.NoError(t, db.Reputation().Store(ctx, muted))
+		require.NoError(t, db.Reputation().Mute(ctx, muted.SatelliteID))
+
+		// stats from the satellite don't carry the muted flag, the stored one applies.
+		now := time.Now()
+		muted.OfflineSuspendedAt = &now
+		suspended := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.5, OfflineSuspendedAt: &now}
+		require.NoError(t, service.StoreAll(ctx, []reputation.Stats{muted, suspended}))
+
+		unread, err := db.Notifications().UnreadAmount(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, unread)
+
+		page, err := db.Notifications().List(ctx, notifications.Cursor{Limit: 10, Page: 1})
+		require.NoError(t, err)
+		require.Len(t, page.Notifications, 1)
+		assert.Contains(t, page.Notifications[0].Message, suspended.SatelliteID.String())
+	})
+}
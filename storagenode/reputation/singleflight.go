@@ -0,0 +1,169 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"storj.io/common/context2"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+)
+
+// SingleflightDB shares Get queries of the wrapped DB between concurrent callers,
+// so concurrent reads of the same satellite run a single query. Nothing is kept
+// after the query finishes, errors are returned only to the callers which waited
+// for the failed query.
+type SingleflightDB struct {
+	// generation is increased after every write through SingleflightDB, so Gets
+	// which start after a write don't share queries started before it.
+	generation int64
+
+	DB
+
+	group singleflight.Group
+}
+
+// NewSingleflightDB creates a new DB which shares concurrent Get queries of inner.
+func NewSingleflightDB(inner DB) *SingleflightDB {
+	return &SingleflightDB{DB: inner}
+}
+
+// Get retrieves stats for specific satellite, returns ErrNoStats when there are no stats for the satellite.
+// The shared query isn't canceled when ctx of one of the callers is, callers stop waiting for it instead.
+func (db *SingleflightDB) Get(ctx context.Context, satelliteID storj.NodeID, opts ...GetOption) (_ *Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	key := strconv.FormatInt(atomic.LoadInt64(&db.generation), 10) + "/" + satelliteID.String()
+	if NewGetOptions(opts).WithoutAuditHistory {
+		key += "/without-audit-history"
+	}
+
+	// the query timeout of the DB still applies, because the detached context has no deadline.
+	queryCtx := context2.WithoutCancellation(ctx)
+	result := db.group.DoChan(key, func() (interface{}, error) {
+		return db.DB.Get(queryCtx, satelliteID, opts...)
+	})
+
+	select {
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		// return a copy, so callers can't modify stats returned to the others.
+		stats := *res.Val.(*Stats)
+		return &stats, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Store inserts or updates reputation stats into the DB.
+func (db *SingleflightDB) Store(ctx context.Context, stats Stats, opts ...StoreOption) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.Store(ctx, stats, opts...)
+}
+
+// StoreAll inserts or updates all reputation stats into the DB in a single transaction.
+func (db *SingleflightDB) StoreAll(ctx context.Context, stats []Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.StoreAll(ctx, stats)
+}
+
+// ReplaceAll replaces all stored stats with provided stats in a single transaction.
+func (db *SingleflightDB) ReplaceAll(ctx context.Context, stats []Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.ReplaceAll(ctx, stats)
+}
+
+// Restore replaces all stored stats with stats of a backup.
+func (db *SingleflightDB) Restore(ctx context.Context, r io.Reader, opts ...RestoreOption) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.Restore(ctx, r, opts...)
+}
+
+// StoreIfNewer inserts stats or updates them when stats are more recent than the stored ones.
+func (db *SingleflightDB) StoreIfNewer(ctx context.Context, stats Stats) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.StoreIfNewer(ctx, stats)
+}
+
+// DeleteBefore deletes stats updated before provided time, stats of disqualified nodes are kept.
+func (db *SingleflightDB) DeleteBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.DeleteBefore(ctx, before)
+}
+
+// Mute marks stats of specific satellite as muted.
+func (db *SingleflightDB) Mute(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.Mute(ctx, satelliteID)
+}
+
+// Unmute clears the muted mark of specific satellite.
+func (db *SingleflightDB) Unmute(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.Unmute(ctx, satelliteID)
+}
+
+// TouchContact updates only the last contact time of specific satellite.
+func (db *SingleflightDB) TouchContact(ctx context.Context, satelliteID storj.NodeID, at time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.TouchContact(ctx, satelliteID, at)
+}
+
+// StoreAuditHistory replaces only the audit history of specific satellite.
+func (db *SingleflightDB) StoreAuditHistory(ctx context.Context, satelliteID storj.NodeID, history *pb.AuditHistory) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.StoreAuditHistory(ctx, satelliteID, history)
+}
+
+// Reset deletes stats of specific satellite.
+func (db *SingleflightDB) Reset(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.Reset(ctx, satelliteID)
+}
+
+// RenameSatellite moves stats and history of a satellite to its new ID.
+func (db *SingleflightDB) RenameSatellite(ctx context.Context, oldID, newID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.forget()
+
+	return db.DB.RenameSatellite(ctx, oldID, newID)
+}
+
+// forget makes Gets start new queries instead of sharing the running ones.
+// It must be called after the write, so a Get which starts after the write
+// can't return stats read before it.
+func (db *SingleflightDB) forget() {
+	atomic.AddInt64(&db.generation, 1)
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+// blockingDB counts Get calls which reach the DB and blocks them until release is closed.
+type blockingDB struct {
+	*reputation.MemoryDB
+	gets    int32
+	entered chan struct{}
+	release chan struct{}
+}
+
+func newBlockingDB() *blockingDB {
+	return &blockingDB{
+		MemoryDB: reputation.NewMemory(),
+		entered:  make(chan struct{}, 100),
+		release:  make(chan struct{}),
+	}
+}
+
+func (db *blockingDB) Get(ctx context.Context, satelliteID storj.NodeID, opts ...reputation.GetOption) (*reputation.Stats, error) {
+	atomic.AddInt32(&db.gets, 1)
+	db.entered <- struct{}{}
+	<-db.release
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return db.MemoryDB.Get(ctx, satelliteID, opts...)
+}
+
+func TestSingleflightDB(t *testing.T) {
+	stats := reputation.Stats{SatelliteID: testrand.NodeID(), OnlineScore: 0.5}
+
+	t.Run("concurrent gets share a query", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		inner := newBlockingDB()
+		require.NoError(t, inner.Store(ctx, stats))
+		db := reputation.NewSingleflightDB(inner)
+
+		const callers = 10
+		started := make(chan struct{}, callers)
+		var group errgroup.Group
+		for i := 0; i < callers; i++ {
+			group.Go(func() error {
+				started <- struct{}{}
+				res, err := db.Get(ctx, stats.SatelliteID)
+				if err != nil {
+					return err
+				}
+				if res.OnlineScore != stats.OnlineScore {
+					return errors.New("unexpected online score")
+				}
+				// modifying the returned stats doesn't modify the stats of the others.
+				res.OnlineScore = 0
+				return nil
+			})
+		}
+
+		<-inner.entered
+		for i := 0; i < callers; i++ {
+			<-started
+		}
+		// give the callers time to join the running query.
+		time.Sleep(50 * time.Millisecond)
+		close(inner.release)
+
+		require.NoError(t, group.Wait())
+		require.EqualValues(t, 1, atomic.LoadInt32(&inner.gets))
+	})
+
+	t.Run("errors aren't kept", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		inner := newBlockingDB()
+		close(inner.release)
+		db := reputation.NewSingleflightDB(inner)
+
+		_, err := db.Get(ctx, stats.SatelliteID)
+		require.True(t, errors.Is(err, reputation.ErrNoStats), err)
+
+		require.NoError(t, inner.Store(ctx, stats))
+		res, err := db.Get(ctx, stats.SatelliteID)
+		require.NoError(t, err)
+		assert.Equal(t, stats.OnlineScore, res.OnlineScore)
+		require.EqualValues(t, 2, atomic.LoadInt32(&inner.gets))
+	})
+
+	t.Run("canceled caller doesn't abort the query", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		inner := newBlockingDB()
+		require.NoError(t, inner.Store(ctx, stats))
+		db := reputation.NewSingleflightDB(inner)
+
+		canceledCtx, cancel := context.WithCancel(ctx)
+		canceled := make(chan error, 1)
+		go func() {
+			_, err := db.Get(canceledCtx, stats.SatelliteID)
+			canceled <- err
+		}()
+		<-inner.entered
+
+		waiting := make(chan error, 1)
+		go func() {
+			res, err := db.Get(ctx, stats.SatelliteID)
+			if err == nil && res.OnlineScore != stats.OnlineScore {
+				err = errors.New("unexpected online score")
+			}
+			waiting <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		cancel()
+		require.True(t, errors.Is(<-canceled, context.Canceled))
+
+		close(inner.release)
+		require.NoError(t, <-waiting)
+		require.EqualValues(t, 1, atomic.LoadInt32(&inner.gets))
+	})
+
+	t.Run("gets after a write don't share older queries", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		inner := newBlockingDB()
+		require.NoError(t, inner.Store(ctx, stats))
+		db := reputation.NewSingleflightDB(inner)
+
+		before := make(chan error, 1)
+		go func() {
+			_, err := db.Get(ctx, stats.SatelliteID)
+			before <- err
+		}()
+		<-inner.entered
+
+		updated := stats
+		updated.OnlineScore = 0.7
+		require.NoError(t, db.Store(ctx, updated))
+
+		after := make(chan *reputation.Stats, 1)
+		go func() {
+			res, err := db.Get(ctx, stats.SatelliteID)
+			assert.NoError(t, err)
+			after <- res
+		}()
+		<-inner.entered
+
+		close(inner.release)
+		require.NoError(t, <-before)
+		res := <-after
+		require.NotNil(t, res)
+		assert.Equal(t, 0.7, res.OnlineScore)
+		require.EqualValues(t, 2, atomic.LoadInt32(&inner.gets))
+	})
+}
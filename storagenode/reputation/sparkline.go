@@ -0,0 +1,55 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import "github.com/zeebo/errs"
+
+// ErrInvalidSparkline is returned when a sparkline without any points is requested.
+var ErrInvalidSparkline = errs.Class("invalid sparkline")
+
+// DownsampleScores averages samples ordered by time within points evenly spaced intervals
+// between the first and the last sample. Intervals without samples repeat the previous
+// average, so the returned scores stay evenly spaced. When there are at most points samples,
+// their scores are returned as they are.
+func DownsampleScores(samples []ScoreSample, points int) []float64 {
+	if len(samples) <= points {
+		scores := make([]float64, len(samples))
+		for i, sample := range samples {
+			scores[i] = sample.Score
+		}
+		return scores
+	}
+	if points <= 0 {
+		return nil
+	}
+
+	start := samples[0].Timestamp
+	span := samples[len(samples)-1].Timestamp.Sub(start)
+
+	sums := make([]float64, points)
+	counts := make([]int, points)
+	for i, sample := range samples {
+		// samples recorded at the same time are spread by their position instead.
+		bucket := i * points / len(samples)
+		if span > 0 {
+			bucket = int(float64(sample.Timestamp.Sub(start)) / float64(span) * float64(points))
+		}
+		if bucket >= points {
+			bucket = points - 1
+		}
+		sums[bucket] += sample.Score
+		counts[bucket]++
+	}
+
+	// the first interval always contains the first sample.
+	scores := make([]float64, points)
+	for i := range scores {
+		if counts[i] == 0 {
+			scores[i] = scores[i-1]
+			continue
+		}
+		scores[i] = sums[i] / float64(counts[i])
+	}
+	return scores
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestDownsampleScores(t *testing.T) {
+	start := time.Now().UTC()
+	samples := func(offsets []time.Duration, scores ...float64) []reputation.ScoreSample {
+		var samples []reputation.ScoreSample
+		for i, score := range scores {
+			samples = append(samples, reputation.ScoreSample{Timestamp: start.Add(offsets[i]), Score: score})
+		}
+		return samples
+	}
+	minutes := func(minutes ...int) []time.Duration {
+		var offsets []time.Duration
+		for _, m := range minutes {
+			offsets = append(offsets, time.Duration(m)*time.Minute)
+		}
+		return offsets
+	}
+
+	for _, test := range []struct {
+		name     string
+		samples  []reputation.ScoreSample
+		points   int
+		expected []float64
+	}{
+		{
+			name:     "no samples",
+			points:   5,
+			expected: []float64{},
+		},
+		{
+			name:     "fewer samples than points",
+			samples:  samples(minutes(0, 1, 2), 1, 0.9, 0.8),
+			points:   5,
+			expected: []float64{1, 0.9, 0.8},
+		},
+		{
+			name:     "averages within intervals",
+			samples:  samples(minutes(0, 1, 2, 3, 4, 5), 1, 0.8, 0.6, 0.4, 0.2, 0),
+			points:   3,
+			expected: []float64{0.9, 0.5, 0.1},
+		},
+		{
+			name:     "intervals without samples",
+			samples:  samples(minutes(0, 1, 9, 10), 1, 0.8, 0.4, 0.2),
+			points:   3,
+			expected: []float64{0.9, 0.9, 0.3},
+		},
+		{
+			name:     "samples at the same time",
+			samples:  samples(minutes(0, 0, 0, 0), 1, 0.8, 0.4, 0.2),
+			points:   2,
+			expected: []float64{0.9, 0.3},
+		},
+		{
+			name:    "no points",
+			samples: samples(minutes(0), 1),
+			points:  0,
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			scores := reputation.DownsampleScores(test.samples, test.points)
+			require.Len(t, scores, len(test.expected))
+			require.InDeltaSlice(t, test.expected, scores, 1e-9)
+		})
+	}
+}
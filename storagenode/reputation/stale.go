@@ -0,0 +1,16 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import "time"
+
+// IsStale returns whether more than maxAge passed by now since the stats were last fetched
+// from the satellite, stats without LastContactAt were never fetched and are stale.
+// Stats are never stale when maxAge is 0.
+func (s Stats) IsStale(maxAge time.Duration, now time.Time) bool {
+	if maxAge <= 0 {
+		return false
+	}
+	return s.LastContactAt == nil || now.Sub(*s.LastContactAt) > maxAge
+}
@@ -0,0 +1,29 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestStatsIsStale(t *testing.T) {
+	now := time.Now()
+	contactedAt := now.Add(-2 * time.Hour)
+
+	contacted := reputation.Stats{LastContactAt: &contactedAt}
+	assert.False(t, contacted.IsStale(0, now))
+	assert.False(t, contacted.IsStale(3*time.Hour, now))
+	assert.False(t, contacted.IsStale(2*time.Hour, now))
+	assert.True(t, contacted.IsStale(time.Hour, now))
+
+	// stats which were never fetched are stale, unless stats can't be stale.
+	var never reputation.Stats
+	assert.False(t, never.IsStale(0, now))
+	assert.True(t, never.IsStale(time.Hour, now))
+}
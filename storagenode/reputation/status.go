@@ -0,0 +1,22 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+// StatusLabel returns a single label describing the status of the node on the satellite,
+// e.g. for dashboard badges. When several apply, the most severe one is returned in order:
+// "Disqualified", "Suspended (offline)", "Suspended (audit)", "Under Review" and "OK".
+func (s Stats) StatusLabel() string {
+	switch {
+	case s.DisqualifiedAt != nil:
+		return "Disqualified"
+	case s.OfflineSuspendedAt != nil:
+		return "Suspended (offline)"
+	case s.SuspendedAt != nil:
+		return "Suspended (audit)"
+	case s.OfflineUnderReviewAt != nil:
+		return "Under Review"
+	default:
+		return "OK"
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestStatusLabel(t *testing.T) {
+	now := time.Now()
+
+	for _, tt := range []struct {
+		name  string
+		stats reputation.Stats
+		label string
+	}{
+		{"ok", reputation.Stats{}, "OK"},
+		{"under review", reputation.Stats{OfflineUnderReviewAt: &now}, "Under Review"},
+		{"audit suspended", reputation.Stats{SuspendedAt: &now}, "Suspended (audit)"},
+		{"offline suspended", reputation.Stats{OfflineSuspendedAt: &now}, "Suspended (offline)"},
+		{"disqualified", reputation.Stats{DisqualifiedAt: &now}, "Disqualified"},
+
+		{"audit suspended and under review", reputation.Stats{SuspendedAt: &now, OfflineUnderReviewAt: &now}, "Suspended (audit)"},
+		{"offline and audit suspended", reputation.Stats{OfflineSuspendedAt: &now, SuspendedAt: &now}, "Suspended (offline)"},
+		{"offline suspended and under review", reputation.Stats{OfflineSuspendedAt: &now, OfflineUnderReviewAt: &now}, "Suspended (offline)"},
+		{"disqualified and suspended", reputation.Stats{DisqualifiedAt: &now, SuspendedAt: &now, OfflineSuspendedAt: &now}, "Disqualified"},
+		{"everything", reputation.Stats{
+			DisqualifiedAt:       &now,
+			SuspendedAt:          &now,
+			OfflineSuspendedAt:   &now,
+			OfflineUnderReviewAt: &now,
+		}, "Disqualified"},
+	} {
+		assert.Equal(t, tt.label, tt.stats.StatusLabel(), tt.name)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import "time"
+
+// SuspendedDuration returns how long the node has been suspended for unknown audit errors.
+// It returns false when the node is not suspended.
+func (s Stats) SuspendedDuration(now time.Time) (time.Duration, bool) {
+	return sinceClamped(s.SuspendedAt, now)
+}
+
+// OfflineSuspendedDuration returns how long the node has been suspended for being offline.
+// It returns false when the node is not offline suspended.
+func (s Stats) OfflineSuspendedDuration(now time.Time) (time.Duration, bool) {
+	return sinceClamped(s.OfflineSuspendedAt, now)
+}
+
+// DefaultOfflineReviewPeriod is the usual length of the review period which starts
+// with an offline suspension, satellites may use a different period.
+const DefaultOfflineReviewPeriod = 30 * 24 * time.Hour
+
+// EstimatedRecoveryDate returns the earliest time the offline suspension can be lifted,
+// which is the end of the review period started with the suspension. It returns false
+// when the node isn't offline suspended, when the satellite didn't report the start of
+// the review, or when reviewPeriod isn't positive. A node which is under review without
+// being suspended has nothing to recover from.
+func (s Stats) EstimatedRecoveryDate(reviewPeriod time.Duration) (*time.Time, bool) {
+	if s.OfflineSuspendedAt == nil || s.OfflineUnderReviewAt == nil || reviewPeriod <= 0 {
+		return nil, false
+	}
+
+	recovery := s.OfflineUnderReviewAt.Add(reviewPeriod)
+	return &recovery, true
+}
+
+// sinceClamped returns the time elapsed since t, negative durations caused by
+// clock skew between the node and the satellite are reported as zero.
+func sinceClamped(t *time.Time, now time.Time) (time.Duration, bool) {
+	if t == nil {
+		return 0, false
+	}
+
+	elapsed := now.Sub(*t)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	return elapsed, true
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestSuspendedDuration(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-3 * time.Hour)
+	future := now.Add(time.Minute)
+
+	duration, ok := reputation.Stats{}.SuspendedDuration(now)
+	assert.False(t, ok)
+	assert.Zero(t, duration)
+
+	duration, ok = reputation.Stats{}.OfflineSuspendedDuration(now)
+	assert.False(t, ok)
+	assert.Zero(t, duration)
+
+	stats := reputation.Stats{SuspendedAt: &past, OfflineSuspendedAt: &future}
+
+	duration, ok = stats.SuspendedDuration(now)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Hour, duration)
+
+	// clock skew is clamped to zero.
+	duration, ok = stats.OfflineSuspendedDuration(now)
+	assert.True(t, ok)
+	assert.Zero(t, duration)
+}
+
+func TestEstimatedRecoveryDate(t *testing.T) {
+	reviewStart := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	suspended := reviewStart.Add(time.Hour)
+
+	for _, test := range []struct {
+		name         string
+		stats        reputation.Stats
+		reviewPeriod time.Duration
+		expected     *time.Time
+	}{
+		{name: "not suspended", stats: reputation.Stats{}, reviewPeriod: time.Hour},
+		{
+			name:         "under review only",
+			stats:        reputation.Stats{OfflineUnderReviewAt: &reviewStart},
+			reviewPeriod: time.Hour,
+		},
+		{
+			name:         "suspended without review",
+			stats:        reputation.Stats{OfflineSuspendedAt: &suspended},
+			reviewPeriod: time.Hour,
+		},
+		{
+			name:         "suspended and under review",
+			stats:        reputation.Stats{OfflineSuspendedAt: &suspended, OfflineUnderReviewAt: &reviewStart},
+			reviewPeriod: reputation.DefaultOfflineReviewPeriod,
+			expected:     timePtr(reviewStart.Add(30 * 24 * time.Hour)),
+		},
+		{
+			name:         "custom review period",
+			stats:        reputation.Stats{OfflineSuspendedAt: &suspended, OfflineUnderReviewAt: &reviewStart},
+			reviewPeriod: 7 * 24 * time.Hour,
+			expected:     timePtr(reviewStart.Add(7 * 24 * time.Hour)),
+		},
+		{
+			name:         "missing review period",
+			stats:        reputation.Stats{OfflineSuspendedAt: &suspended, OfflineUnderReviewAt: &reviewStart},
+			reviewPeriod: 0,
+		},
+	} {
+		recovery, ok := test.stats.EstimatedRecoveryDate(test.reviewPeriod)
+		assert.Equal(t, test.expected != nil, ok, test.name)
+		assert.Equal(t, test.expected, recovery, test.name)
+	}
+}
+
+func timePtr(t time.Time) *time.Time { return &t }
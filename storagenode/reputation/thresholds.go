@@ -0,0 +1,207 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+)
+
+// ErrInvalidThresholds is returned when thresholds are not ordered correctly.
+var ErrInvalidThresholds = errs.Class("invalid reputation thresholds")
+
+// Thresholds defines the score boundaries used to classify reputation stats.
+type Thresholds struct {
+	AuditWarn     float64
+	AuditCritical float64
+
+	// Satellites suspend nodes with an online score below 0.6.
+	OnlineWarn     float64
+	OnlineCritical float64
+
+	// MinAudits is the number of audits the satellite has to exceed before
+	// stats are classified, zero disables the gate.
+	MinAudits int64
+	// MinWindows is the number of audit history windows the satellite has to
+	// exceed before stats are classified, zero disables the gate.
+	MinWindows int
+}
+
+// DefaultThresholds returns the thresholds shared by the dashboard and notifications.
+// Satellites disqualify nodes with an audit score below 0.6.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		AuditWarn:      0.9,
+		AuditCritical:  0.75,
+		OnlineWarn:     0.9,
+		OnlineCritical: 0.7,
+	}
+}
+
+// NewThresholds creates thresholds and checks that warning thresholds are above critical ones.
+func NewThresholds(auditWarn, auditCritical, onlineWarn, onlineCritical float64) (Thresholds, error) {
+	thresholds := Thresholds{
+		AuditWarn:      auditWarn,
+		AuditCritical:  auditCritical,
+		OnlineWarn:     onlineWarn,
+		OnlineCritical: onlineCritical,
+	}
+	return thresholds, thresholds.Validate()
+}
+
+// LoadThresholds parses thresholds from a JSON document, e.g. {"onlineWarn": 0.95}. Omitted
+// fields are taken from DefaultThresholds, unknown fields are rejected, so typos are caught.
+func LoadThresholds(r io.Reader) (Thresholds, error) {
+	defaults := DefaultThresholds()
+	document := struct {
+		AuditWarn      float64 `json:"auditWarn"`
+		AuditCritical  float64 `json:"auditCritical"`
+		OnlineWarn     float64 `json:"onlineWarn"`
+		OnlineCritical float64 `json:"onlineCritical"`
+		MinAudits      int64   `json:"minAudits"`
+		MinWindows     int     `json:"minWindows"`
+	}{
+		AuditWarn:      defaults.AuditWarn,
+		AuditCritical:  defaults.AuditCritical,
+		OnlineWarn:     defaults.OnlineWarn,
+		OnlineCritical: defaults.OnlineCritical,
+		MinAudits:      defaults.MinAudits,
+		MinWindows:     defaults.MinWindows,
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&document); err != nil {
+		return Thresholds{}, ErrInvalidThresholds.Wrap(err)
+	}
+
+	thresholds := Thresholds(document)
+	return thresholds, thresholds.Validate()
+}
+
+// LoadThresholdsFile parses thresholds from the JSON file at path with LoadThresholds,
+// DefaultThresholds are returned when path is empty.
+func LoadThresholdsFile(path string) (_ Thresholds, err error) {
+	if path == "" {
+		return DefaultThresholds(), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return Thresholds{}, ErrInvalidThresholds.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	return LoadThresholds(file)
+}
+
+// Validate checks that scores are in [0, 1] and warning thresholds are above critical ones.
+func (t Thresholds) Validate() error {
+	for _, score := range []float64{t.AuditWarn, t.AuditCritical, t.OnlineWarn, t.OnlineCritical} {
+		if !(score >= 0 && score <= 1) {
+			return ErrInvalidThresholds.New("score %v must be in [0, 1]", score)
+		}
+	}
+	if !(t.AuditWarn > t.AuditCritical) {
+		return ErrInvalidThresholds.New("audit warn %v must be above audit critical %v", t.AuditWarn, t.AuditCritical)
+	}
+	if !(t.OnlineWarn > t.OnlineCritical) {
+		return ErrInvalidThresholds.New("online warn %v must be above online critical %v", t.OnlineWarn, t.OnlineCritical)
+	}
+	if t.MinAudits < 0 || t.MinWindows < 0 {
+		return ErrInvalidThresholds.New("min audits %v and min windows %v must not be negative", t.MinAudits, t.MinWindows)
+	}
+	return nil
+}
+
+// StatusReport contains the severity of each reputation metric.
+type StatusReport struct {
+	Audit  RiskLevel
+	Online RiskLevel
+	// Overall is the worst severity of all metrics.
+	Overall RiskLevel
+	// NotEnoughData is set when the satellite didn't audit the node enough to
+	// classify the stats yet, all severities are RiskSafe then.
+	NotEnoughData bool
+}
+
+// Classify evaluates the stats against the thresholds.
+func (t Thresholds) Classify(stats Stats) StatusReport {
+	if t.notEnoughData(stats) {
+		return StatusReport{NotEnoughData: true}
+	}
+
+	var report StatusReport
+	// freshly joined satellites don't have any audits yet.
+	if stats.Audit.Alpha+stats.Audit.Beta > 0 {
+		switch score := stats.Audit.ComputedScore(); {
+		case score < t.AuditCritical:
+			report.Audit = RiskCritical
+		case score < t.AuditWarn:
+			report.Audit = RiskWarning
+		}
+	}
+
+	switch {
+	case stats.OnlineScore < t.OnlineCritical:
+		report.Online = RiskCritical
+	case stats.OnlineScore < t.OnlineWarn:
+		report.Online = RiskWarning
+	default:
+		report.Online = RiskSafe
+	}
+
+	report.Overall = report.Audit
+	if report.Online > report.Overall {
+		report.Overall = report.Online
+	}
+
+	return report
+}
+
+// notEnoughData returns whether stats don't exceed MinAudits or MinWindows.
+func (t Thresholds) notEnoughData(stats Stats) bool {
+	if t.MinAudits > 0 && stats.Audit.TotalCount <= t.MinAudits {
+		return true
+	}
+	if t.MinWindows > 0 && len(stats.AuditHistory.GetWindows()) <= t.MinWindows {
+		return true
+	}
+	return false
+}
+
+// classifyAll classifies stats returned by All against t. Those stats don't carry the audit
+// history, so the reports are read with Statuses when t.MinWindows needs it.
+func (t Thresholds) classifyAll(ctx context.Context, db DB, all []Stats) (map[storj.NodeID]StatusReport, error) {
+	if t.MinWindows > 0 {
+		return db.Statuses(ctx, t)
+	}
+
+	reports := make(map[storj.NodeID]StatusReport, len(all))
+	for _, stats := range all {
+		reports[stats.SatelliteID] = t.Classify(stats)
+	}
+	return reports, nil
+}
+
+// Classify evaluates the stats against DefaultThresholds.
+func Classify(stats Stats) StatusReport {
+	return DefaultThresholds().Classify(stats)
+}
+
+// IsHealthy returns whether the node is neither disqualified nor suspended by the
+// satellite and both the computed audit score and the online score are at or
+// above the warning thresholds. Satellites without audits yet are healthy.
+func (s Stats) IsHealthy(t Thresholds) bool {
+	if s.DisqualifiedAt != nil || s.SuspendedAt != nil || s.OfflineSuspendedAt != nil {
+		return false
+	}
+	return t.Classify(s).Overall == RiskSafe
+}
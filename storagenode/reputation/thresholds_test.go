@@ -0,0 +1,248 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestNewThresholds(t *testing.T) {
+	require.NoError(t, reputation.DefaultThresholds().Validate())
+
+	_, err := reputation.NewThresholds(0.9, 0.8, 0.9, 0.8)
+	require.NoError(t, err)
+
+	_, err = reputation.NewThresholds(0.8, 0.8, 0.9, 0.8)
+	require.True(t, reputation.ErrInvalidThresholds.Has(err))
+
+	_, err = reputation.NewThresholds(0.9, 0.8, 0.7, 0.8)
+	require.True(t, reputation.ErrInvalidThresholds.Has(err))
+
+	_, err = reputation.NewThresholds(1.5, 0.8, 0.9, 0.8)
+	require.True(t, reputation.ErrInvalidThresholds.Has(err))
+
+	_, err = reputation.NewThresholds(0.9, 0.8, 0.9, -0.1)
+	require.True(t, reputation.ErrInvalidThresholds.Has(err))
+}
+
+func TestLoadThresholds(t *testing.T) {
+	thresholds, err := reputation.LoadThresholds(strings.NewReader(`{}`))
+	require.NoError(t, err)
+	require.Equal(t, reputation.DefaultThresholds(), thresholds)
+
+	// omitted fields are defaults.
+	thresholds, err = reputation.LoadThresholds(strings.NewReader(`{"onlineWarn": 0.95, "minAudits": 10}`))
+	require.NoError(t, err)
+	expected := reputation.DefaultThresholds()
+	expected.OnlineWarn = 0.95
+	expected.MinAudits = 10
+	require.Equal(t, expected, thresholds)
+
+	thresholds, err = reputation.LoadThresholds(strings.NewReader(`{
+		"auditWarn": 0.98, "auditCritical": 0.96,
+		"onlineWarn": 0.8, "onlineCritical": 0.65,
+		"minAudits": 5, "minWindows": 2
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, reputation.Thresholds{
+		AuditWarn: 0.98, AuditCritical: 0.96,
+		OnlineWarn: 0.8, OnlineCritical: 0.65,
+		MinAudits: 5, MinWindows: 2,
+	}, thresholds)
+
+	for _, document := range []string{
+		``,
+		`not json`,
+		`{"onlineWarn": "high"}`,
+		// typos are rejected.
+		`{"onlineWran": 0.95}`,
+		// warn must be above critical, also when the other one is a default.
+		`{"onlineWarn": 0.5}`,
+		`{"auditWarn": 0.9, "auditCritical": 0.95}`,
+		`{"onlineCritical": -0.5}`,
+		`{"auditWarn": 1.5}`,
+		`{"minWindows": -1}`,
+	} {
+		_, err := reputation.LoadThresholds(strings.NewReader(document))
+		require.True(t, reputation.ErrInvalidThresholds.Has(err), document)
+	}
+}
+
+func TestLoadThresholdsFile(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	thresholds, err := reputation.LoadThresholdsFile("")
+	require.NoError(t, err)
+	require.Equal(t, reputation.DefaultThresholds(), thresholds)
+
+	path := ctx.File("thresholds.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"onlineCritical": 0.5}`), 0644))
+	thresholds, err = reputation.LoadThresholdsFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 0.5, thresholds.OnlineCritical)
+
+	_, err = reputation.LoadThresholdsFile(ctx.File("missing.json"))
+	require.True(t, reputation.ErrInvalidThresholds.Has(err), err)
+}
+
+func TestClassify(t *testing.T) {
+	thresholds, err := reputation.NewThresholds(0.9, 0.75, 0.9, 0.7)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name   string
+		stats  reputation.Stats
+		report reputation.StatusReport
+	}{
+		{
+			name:   "new satellite",
+			stats:  reputation.Stats{OnlineScore: 1},
+			report: reputation.StatusReport{},
+		},
+		{
+			name: "healthy",
+			stats: reputation.Stats{
+				Audit:       reputation.Metric{Alpha: 19, Beta: 1},
+				OnlineScore: 0.95,
+			},
+			report: reputation.StatusReport{},
+		},
+		{
+			name: "online warning",
+			stats: reputation.Stats{
+				Audit:       reputation.Metric{Alpha: 20, Beta: 0},
+				OnlineScore: 0.8,
+			},
+			report: reputation.StatusReport{
+				Online:  reputation.RiskWarning,
+				Overall: reputation.RiskWarning,
+			},
+		},
+		{
+			name: "audit critical",
+			stats: reputation.Stats{
+				Audit:       reputation.Metric{Alpha: 7, Beta: 3},
+				OnlineScore: 0.8,
+			},
+			report: reputation.StatusReport{
+				Audit:   reputation.RiskCritical,
+				Online:  reputation.RiskWarning,
+				Overall: reputation.RiskCritical,
+			},
+		},
+		{
+			name: "online critical",
+			stats: reputation.Stats{
+				Audit:       reputation.Metric{Alpha: 17, Beta: 3},
+				OnlineScore: 0.5,
+			},
+			report: reputation.StatusReport{
+				Audit:   reputation.RiskWarning,
+				Online:  reputation.RiskCritical,
+				Overall: reputation.RiskCritical,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.report, thresholds.Classify(tt.stats))
+		})
+	}
+}
+
+func TestClassifyAudit(t *testing.T) {
+	thresholds, err := reputation.NewThresholds(0.9, 0.7, 0.9, 0.7)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		metric   reputation.Metric
+		expected reputation.RiskLevel
+	}{
+		{"freshly joined", reputation.Metric{}, reputation.RiskSafe},
+		{"perfect", reputation.Metric{Alpha: 20, Beta: 0}, reputation.RiskSafe},
+		{"on warning boundary", reputation.Metric{Alpha: 9, Beta: 1}, reputation.RiskSafe},
+		{"warning", reputation.Metric{Alpha: 8, Beta: 2}, reputation.RiskWarning},
+		{"on critical boundary", reputation.Metric{Alpha: 7, Beta: 3}, reputation.RiskWarning},
+		{"critical", reputation.Metric{Alpha: 6, Beta: 4}, reputation.RiskCritical},
+	} {
+		report := thresholds.Classify(reputation.Stats{Audit: tt.metric, OnlineScore: 1})
+		assert.Equal(t, tt.expected, report.Audit, tt.name)
+	}
+
+	report := reputation.Classify(reputation.Stats{Audit: reputation.Metric{Alpha: 1, Beta: 1}, OnlineScore: 1})
+	assert.Equal(t, reputation.RiskCritical, report.Audit)
+}
+
+func TestClassifyNotEnoughData(t *testing.T) {
+	thresholds := reputation.DefaultThresholds()
+	thresholds.MinAudits = 10
+	thresholds.MinWindows = 1
+	require.NoError(t, thresholds.Validate())
+
+	now := time.Now()
+	risky := reputation.Stats{
+		Audit:       reputation.Metric{TotalCount: 10, Alpha: 1, Beta: 1},
+		OnlineScore: 0.5,
+		AuditHistory: &pb.AuditHistory{
+			Windows: []*pb.AuditWindow{{WindowStart: now.Add(-time.Hour)}, {WindowStart: now}},
+		},
+	}
+
+	// stats are classified only once the total count exceeds the minimum.
+	assert.Equal(t, reputation.StatusReport{NotEnoughData: true}, thresholds.Classify(risky))
+
+	risky.Audit.TotalCount++
+	assert.Equal(t, reputation.StatusReport{
+		Audit:   reputation.RiskCritical,
+		Online:  reputation.RiskCritical,
+		Overall: reputation.RiskCritical,
+	}, thresholds.Classify(risky))
+
+	risky.AuditHistory.Windows = risky.AuditHistory.Windows[:1]
+	assert.Equal(t, reputation.StatusReport{NotEnoughData: true}, thresholds.Classify(risky))
+
+	risky.AuditHistory = nil
+	assert.Equal(t, reputation.StatusReport{NotEnoughData: true}, thresholds.Classify(risky))
+
+	thresholds.MinAudits = -1
+	require.True(t, reputation.ErrInvalidThresholds.Has(thresholds.Validate()))
+}
+
+func TestIsHealthy(t *testing.T) {
+	thresholds := reputation.DefaultThresholds()
+	now := time.Now()
+
+	healthy := reputation.Stats{
+		Audit:       reputation.Metric{Alpha: 20, Beta: 0},
+		OnlineScore: 1,
+	}
+	assert.True(t, healthy.IsHealthy(thresholds))
+	assert.True(t, reputation.Stats{OnlineScore: 1}.IsHealthy(thresholds), "new satellite")
+
+	for _, tt := range []struct {
+		name   string
+		modify func(*reputation.Stats)
+	}{
+		{"disqualified", func(s *reputation.Stats) { s.DisqualifiedAt = &now }},
+		{"suspended", func(s *reputation.Stats) { s.SuspendedAt = &now }},
+		{"offline suspended", func(s *reputation.Stats) { s.OfflineSuspendedAt = &now }},
+		{"audit score below warn", func(s *reputation.Stats) { s.Audit = reputation.Metric{Alpha: 8, Beta: 2} }},
+		{"online score below warn", func(s *reputation.Stats) { s.OnlineScore = thresholds.OnlineWarn - 0.01 }},
+	} {
+		stats := healthy
+		tt.modify(&stats)
+		assert.False(t, stats.IsHealthy(thresholds), tt.name)
+	}
+}
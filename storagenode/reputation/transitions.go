@@ -0,0 +1,103 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+)
+
+// lastSeenState returns the part of stats which is kept by DB.StoreLastSeenStates.
+// Alpha and beta aren't kept, so transitions are logged with the reported audit score.
+func lastSeenState(s Stats) Stats {
+	return Stats{
+		SatelliteID: s.SatelliteID,
+		Audit: Metric{
+			Score:        s.Audit.Score,
+			UnknownScore: s.Audit.UnknownScore,
+		},
+		OnlineScore:        s.OnlineScore,
+		SuspendedAt:        s.SuspendedAt,
+		OfflineSuspendedAt: s.OfflineSuspendedAt,
+		DisqualifiedAt:     s.DisqualifiedAt,
+	}
+}
+
+// TransitionLogChore periodically compares stats with the stats seen in the previous
+// check and logs every suspension, recovery and disqualification. The last seen
+// stats are stored in the DB, so transitions aren't logged again after a restart.
+//
+// architecture: Chore
+type TransitionLogChore struct {
+	log  *zap.Logger
+	db   DB
+	Loop *sync2.Cycle
+}
+
+// NewTransitionLogChore creates a new reputation transition log chore.
+func NewTransitionLogChore(log *zap.Logger, db DB, config Config) *TransitionLogChore {
+	return &TransitionLogChore{
+		log:  log,
+		db:   db,
+		Loop: sync2.NewCycle(config.TransitionInterval),
+	}
+}
+
+// Run starts the background process which logs reputation transitions.
+func (chore *TransitionLogChore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.Check(ctx); err != nil {
+			chore.log.Error("Could not check reputation transitions", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Check logs transitions since the last check and stores the current stats as last seen.
+// Satellites seen for the first time are only stored.
+func (chore *TransitionLogChore) Check(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	lastSeen, err := chore.db.LastSeenStates(ctx)
+	if err != nil {
+		return err
+	}
+
+	statsList, err := chore.db.All(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, stats := range statsList {
+		prev, ok := lastSeen[stats.SatelliteID]
+		if !ok {
+			continue
+		}
+
+		change := stats.Diff(prev)
+		for _, transition := range change.Transitions() {
+			chore.log.Info("Reputation transition",
+				zap.Stringer("Satellite ID", stats.SatelliteID),
+				zap.String("Transition", string(transition)),
+				zap.Float64("Previous Audit Score", prev.Audit.Score),
+				zap.Float64("Audit Score", stats.Audit.Score),
+				zap.Float64("Previous Suspension Score", prev.Audit.UnknownScore),
+				zap.Float64("Suspension Score", stats.Audit.UnknownScore),
+				zap.Float64("Previous Online Score", prev.OnlineScore),
+				zap.Float64("Online Score", stats.OnlineScore))
+		}
+	}
+
+	return chore.db.StoreLastSeenStates(ctx, statsList)
+}
+
+// Close stops the background process.
+func (chore *TransitionLogChore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestTransitionLogChore(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		testTransitionLogChore(ctx, t, db.Reputation())
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testTransitionLogChore(ctx, t, reputation.NewMemory())
+	})
+}
+
+func testTransitionLogChore(ctx *testcontext.Context, t *testing.T, db reputation.DB) {
+	core, logs := observer.New(zap.InfoLevel)
+	newChore := func() *reputation.TransitionLogChore {
+		return reputation.NewTransitionLogChore(zap.New(core), db, reputation.Config{TransitionInterval: time.Hour})
+	}
+
+	transitions := func() []string {
+		var result []string
+		for _, entry := range logs.TakeAll() {
+			if entry.Message == "Reputation transition" {
+				result = append(result, entry.ContextMap()["Transition"].(string))
+			}
+		}
+		return result
+	}
+
+	now := time.Now()
+	stats := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{Score: 1, UnknownScore: 1},
+		OnlineScore: 1,
+	}
+	require.NoError(t, db.Store(ctx, stats))
+
+	chore := newChore()
+	defer ctx.Check(chore.Close)
+
+	// satellites seen for the first time aren't transitions.
+	require.NoError(t, chore.Check(ctx))
+	assert.Empty(t, transitions())
+
+	stats.OfflineSuspendedAt = &now
+	stats.OnlineScore = 0.5
+	require.NoError(t, db.Store(ctx, stats))
+	require.NoError(t, chore.Check(ctx))
+	assert.Equal(t, []string{string(reputation.TransitionSuspended)}, transitions())
+
+	// the last seen states are kept in the db, so a restart doesn't log the suspension again.
+	chore = newChore()
+	defer ctx.Check(chore.Close)
+	require.NoError(t, chore.Check(ctx))
+	assert.Empty(t, transitions())
+
+	stats.OfflineSuspendedAt = nil
+	stats.OnlineScore = 0.9
+	require.NoError(t, db.Store(ctx, stats))
+	require.NoError(t, chore.Check(ctx))
+	assert.Equal(t, []string{string(reputation.TransitionRecovered)}, transitions())
+
+	stats.DisqualifiedAt = &now
+	stats.Audit.Score = 0.5
+	require.NoError(t, db.Store(ctx, stats))
+	require.NoError(t, chore.Check(ctx))
+
+	entries := logs.FilterMessage("Reputation transition").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, string(reputation.TransitionDisqualified), fields["Transition"])
+	assert.Equal(t, stats.SatelliteID.String(), fields["Satellite ID"])
+	assert.Equal(t, 1.0, fields["Previous Audit Score"])
+	assert.Equal(t, 0.5, fields["Audit Score"])
+
+	// score changes alone aren't transitions.
+	logs.TakeAll()
+	stats.Audit.Score = 0.4
+	require.NoError(t, db.Store(ctx, stats))
+	require.NoError(t, chore.Check(ctx))
+	assert.Empty(t, transitions())
+}
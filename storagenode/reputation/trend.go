@@ -0,0 +1,90 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"storj.io/common/pb"
+)
+
+// TrendStableSlope is the absolute slope below which the online trend is
+// considered stable. The slope is the change of online fraction per window.
+const TrendStableSlope = 0.01
+
+// TrendDirection describes whether a metric is getting better or worse.
+type TrendDirection int
+
+const (
+	// TrendStable indicates that the metric is not changing noticeably.
+	TrendStable TrendDirection = iota
+	// TrendImproving indicates that the metric is getting better.
+	TrendImproving
+	// TrendDeclining indicates that the metric is getting worse.
+	TrendDeclining
+)
+
+// String returns a string representation of the trend direction.
+func (direction TrendDirection) String() string {
+	switch direction {
+	case TrendStable:
+		return "stable"
+	case TrendImproving:
+		return "improving"
+	case TrendDeclining:
+		return "declining"
+	default:
+		return "unknown"
+	}
+}
+
+// Trend describes how a metric changes over time.
+type Trend struct {
+	Direction TrendDirection
+	// Slope is the change of the metric per audit window.
+	Slope float64
+}
+
+// OnlineTrend fits a line through the online fraction of audit windows
+// and returns its slope. Windows without audits are skipped, with fewer than
+// two audited windows the trend is stable.
+func OnlineTrend(h *pb.AuditHistory) Trend {
+	if h == nil {
+		return Trend{Direction: TrendStable}
+	}
+
+	var xs, ys []float64
+	for i, window := range h.Windows {
+		if window.TotalCount == 0 {
+			continue
+		}
+		xs = append(xs, float64(i))
+		ys = append(ys, float64(window.OnlineCount)/float64(window.TotalCount))
+	}
+	if len(xs) < 2 {
+		return Trend{Direction: TrendStable}
+	}
+
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= float64(len(xs))
+	meanY /= float64(len(ys))
+
+	var covariance, variance float64
+	for i := range xs {
+		covariance += (xs[i] - meanX) * (ys[i] - meanY)
+		variance += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+
+	slope := covariance / variance
+	switch {
+	case slope >= TrendStableSlope:
+		return Trend{Direction: TrendImproving, Slope: slope}
+	case slope <= -TrendStableSlope:
+		return Trend{Direction: TrendDeclining, Slope: slope}
+	default:
+		return Trend{Direction: TrendStable, Slope: slope}
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/common/pb"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestOnlineTrend(t *testing.T) {
+	now := time.Now()
+	history := func(counts ...int32) *pb.AuditHistory {
+		h := &pb.AuditHistory{}
+		for i := 0; i < len(counts); i += 2 {
+			h.Windows = append(h.Windows, &pb.AuditWindow{
+				WindowStart: now.Add(time.Duration(i) * time.Hour),
+				OnlineCount: counts[i],
+				TotalCount:  counts[i+1],
+			})
+		}
+		return h
+	}
+
+	assert.Equal(t, reputation.Trend{Direction: reputation.TrendStable}, reputation.OnlineTrend(nil))
+	assert.Equal(t, reputation.Trend{Direction: reputation.TrendStable}, reputation.OnlineTrend(history(1, 2)))
+	assert.Equal(t, reputation.Trend{Direction: reputation.TrendStable}, reputation.OnlineTrend(history(1, 2, 0, 0)))
+
+	stable := reputation.OnlineTrend(history(4, 4, 4, 4, 4, 4))
+	assert.Equal(t, reputation.TrendStable, stable.Direction)
+	assert.Zero(t, stable.Slope)
+
+	improving := reputation.OnlineTrend(history(0, 4, 2, 4, 4, 4))
+	assert.Equal(t, reputation.TrendImproving, improving.Direction)
+	assert.InDelta(t, 0.5, improving.Slope, 1e-9)
+
+	// windows without audits are skipped, but keep their position.
+	declining := reputation.OnlineTrend(history(4, 4, 0, 0, 0, 4))
+	assert.Equal(t, reputation.TrendDeclining, declining.Direction)
+	assert.InDelta(t, -0.5, declining.Slope, 1e-9)
+
+	assert.Equal(t, "improving", reputation.TrendImproving.String())
+}
@@ -0,0 +1,162 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"math"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+)
+
+// ErrInvalidScore is returned when a score is outside of the [0, 1] range.
+var ErrInvalidScore = errs.Class("invalid reputation score")
+
+// ErrInvalidStats is returned by Stats.Validate for violations other than invalid scores.
+var ErrInvalidStats = errs.Class("invalid reputation stats")
+
+// ClampScores makes CheckScores clamp out of range scores into [0, 1]
+// instead of returning an error. It's intended only for tests which use
+// placeholder values in their fixtures.
+var ClampScores = false
+
+// validationClockSkew is how much JoinedAt can be after UpdatedAt, JoinedAt is reported
+// by the satellite while UpdatedAt comes from the clock of the node.
+const validationClockSkew = 10 * time.Minute
+
+// CheckStats verifies stats before they are stored. Scores are clamped first when
+// ClampScores is set, then all violations found by Validate are returned.
+func CheckStats(stats *Stats) error {
+	if ClampScores {
+		if err := CheckScores(stats); err != nil {
+			return err
+		}
+	}
+	return stats.Validate()
+}
+
+// ValidStats returns copies of stats which pass CheckStats, invalid stats are logged and
+// skipped, so a single satellite reporting invalid stats doesn't block storing the others.
+func ValidStats(log *zap.Logger, stats []Stats) []Stats {
+	valid := make([]Stats, 0, len(stats))
+	for _, s := range stats {
+		if err := CheckStats(&s); err != nil {
+			log.Warn("skipping invalid reputation stats",
+				zap.Stringer("Satellite ID", s.SatelliteID),
+				zap.Error(err))
+			continue
+		}
+		valid = append(valid, s)
+	}
+	return valid
+}
+
+// Validate returns all violations of the stats invariants combined into a single error:
+// the satellite ID must be set, scores must be in the [0, 1] range, success counts of
+// metrics can't be negative or exceed their total counts and when both JoinedAt and
+// UpdatedAt are set, JoinedAt can't be after UpdatedAt by more than a small clock skew.
+func (s Stats) Validate() error {
+	var group errs.Group
+
+	if s.SatelliteID.IsZero() {
+		group.Add(ErrInvalidStats.New("missing satellite id"))
+	}
+
+	for _, score := range []struct {
+		name  string
+		value float64
+	}{
+		{"Uptime.Score", s.Uptime.Score},
+		{"Uptime.UnknownScore", s.Uptime.UnknownScore},
+		{"Audit.Score", s.Audit.Score},
+		{"Audit.UnknownScore", s.Audit.UnknownScore},
+		{"OnlineScore", s.OnlineScore},
+	} {
+		// the negated comparison also catches NaN.
+		if !(0 <= score.value && score.value <= 1) {
+			group.Add(ErrInvalidScore.New("%s is %v, expected value in [0, 1]", score.name, score.value))
+		}
+	}
+
+	for _, metric := range []struct {
+		name   string
+		metric Metric
+	}{
+		{"Uptime", s.Uptime},
+		{"Audit", s.Audit},
+	} {
+		if metric.metric.SuccessCount < 0 || metric.metric.TotalCount < 0 {
+			group.Add(ErrInvalidStats.New("%s counts are negative", metric.name))
+		}
+		if metric.metric.SuccessCount > metric.metric.TotalCount {
+			group.Add(ErrInvalidStats.New("%s.SuccessCount %d exceeds %s.TotalCount %d",
+				metric.name, metric.metric.SuccessCount, metric.name, metric.metric.TotalCount))
+		}
+	}
+
+	if !s.JoinedAt.IsZero() && !s.UpdatedAt.IsZero() && s.JoinedAt.Sub(s.UpdatedAt) > validationClockSkew {
+		group.Add(ErrInvalidStats.New("JoinedAt %v is after UpdatedAt %v", s.JoinedAt, s.UpdatedAt))
+	}
+
+	return group.Err()
+}
+
+// CheckScores verifies that all scores of the stats are in the [0, 1] range.
+func CheckScores(stats *Stats) error {
+	scores := []struct {
+		name  string
+		value *float64
+	}{
+		{"Uptime.Score", &stats.Uptime.Score},
+		{"Uptime.UnknownScore", &stats.Uptime.UnknownScore},
+		{"Audit.Score", &stats.Audit.Score},
+		{"Audit.UnknownScore", &stats.Audit.UnknownScore},
+		{"OnlineScore", &stats.OnlineScore},
+	}
+
+	for _, score := range scores {
+		value := *score.value
+		if 0 <= value && value <= 1 {
+			continue
+		}
+
+		if !ClampScores {
+			return ErrInvalidScore.New("%s is %v, expected value in [0, 1]", score.name, value)
+		}
+
+		if value > 1 {
+			*score.value = 1
+		} else {
+			// also covers NaN.
+			*score.value = 0
+		}
+	}
+
+	return nil
+}
+
+// FindInconsistent returns satellites whose stored Audit.Score or Uptime.Score differs from
+// the score computed from alpha and beta by more than tolerance, e.g. to verify that a
+// migration normalized scores. Metrics without alpha and beta can't be checked and are skipped.
+func FindInconsistent(stats []Stats, tolerance float64) []storj.NodeID {
+	var inconsistent []storj.NodeID
+	for _, s := range stats {
+		if !consistentScore(s.Audit, tolerance) || !consistentScore(s.Uptime, tolerance) {
+			inconsistent = append(inconsistent, s.SatelliteID)
+		}
+	}
+	return inconsistent
+}
+
+// consistentScore returns whether the stored score is within tolerance of the computed score.
+func consistentScore(m Metric, tolerance float64) bool {
+	if m.Alpha+m.Beta == 0 {
+		return true
+	}
+	// NaN scores are inconsistent, because the comparison is false.
+	return math.Abs(m.Score-m.ComputedScore()) <= tolerance
+}
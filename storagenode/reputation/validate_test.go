@@ -0,0 +1,111 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestFindInconsistent(t *testing.T) {
+	consistent := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{Alpha: 3, Beta: 1, Score: 0.75},
+		Uptime:      reputation.Metric{Alpha: 1, Beta: 1, Score: 0.5},
+	}
+	withinTolerance := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{Alpha: 3, Beta: 1, Score: 0.7501},
+	}
+	// scores without alpha and beta can't be checked.
+	unknown := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{Score: 0.3},
+	}
+	badAudit := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{Alpha: 3, Beta: 1, Score: 1},
+	}
+	badUptime := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{Alpha: 3, Beta: 1, Score: 0.75},
+		Uptime:      reputation.Metric{Alpha: 1, Beta: 1, Score: 0.9},
+	}
+	nan := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Audit:       reputation.Metric{Alpha: 3, Beta: 1, Score: math.NaN()},
+	}
+
+	stats := []reputation.Stats{consistent, withinTolerance, unknown, badAudit, badUptime, nan}
+	assert.Equal(t,
+		[]storj.NodeID{badAudit.SatelliteID, badUptime.SatelliteID, nan.SatelliteID},
+		reputation.FindInconsistent(stats, 0.001))
+	assert.Equal(t,
+		[]storj.NodeID{withinTolerance.SatelliteID, badAudit.SatelliteID, badUptime.SatelliteID, nan.SatelliteID},
+		reputation.FindInconsistent(stats, 0))
+	assert.Empty(t, reputation.FindInconsistent(nil, 0))
+}
+
+func TestStatsValidate(t *testing.T) {
+	now := time.Now()
+	valid := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		Uptime:      reputation.Metric{TotalCount: 2, SuccessCount: 2, Score: 1},
+		Audit:       reputation.Metric{TotalCount: 10, SuccessCount: 9, Score: 0.9, UnknownScore: 1},
+		OnlineScore: 0.95,
+		UpdatedAt:   now,
+		JoinedAt:    now.Add(-time.Hour),
+	}
+	require.NoError(t, valid.Validate())
+
+	// missing timestamps aren't compared and small clock skew is tolerated.
+	require.NoError(t, reputation.Stats{SatelliteID: testrand.NodeID(), JoinedAt: now}.Validate())
+	skewed := valid
+	skewed.JoinedAt = now.Add(time.Minute)
+	require.NoError(t, skewed.Validate())
+
+	for _, test := range []struct {
+		name   string
+		modify func(s *reputation.Stats)
+	}{
+		{"missing satellite id", func(s *reputation.Stats) { s.SatelliteID = storj.NodeID{} }},
+		{"audit score", func(s *reputation.Stats) { s.Audit.Score = 1.5 }},
+		{"unknown uptime score", func(s *reputation.Stats) { s.Uptime.UnknownScore = -0.5 }},
+		{"online score", func(s *reputation.Stats) { s.OnlineScore = math.NaN() }},
+		{"audit counts", func(s *reputation.Stats) { s.Audit.SuccessCount = 11 }},
+		{"negative uptime counts", func(s *reputation.Stats) { s.Uptime.TotalCount, s.Uptime.SuccessCount = -1, -2 }},
+		{"joined after update", func(s *reputation.Stats) { s.JoinedAt = now.Add(24 * time.Hour) }},
+	} {
+		stats := valid
+		test.modify(&stats)
+		assert.Error(t, stats.Validate(), test.name)
+	}
+
+	// all violations are reported at once.
+	invalid := reputation.Stats{
+		Audit:       reputation.Metric{TotalCount: 1, SuccessCount: 2},
+		OnlineScore: 2,
+		UpdatedAt:   now,
+		JoinedAt:    now.Add(24 * time.Hour),
+	}
+	err := invalid.Validate()
+	require.Error(t, err)
+	for _, violation := range []string{"missing satellite id", "OnlineScore", "Audit.SuccessCount", "JoinedAt"} {
+		assert.Contains(t, err.Error(), violation)
+	}
+	group, ok := err.(interface{ Ungroup() []error })
+	require.True(t, ok)
+	require.Len(t, group.Ungroup(), 4)
+	assert.True(t, errs.IsFunc(err, reputation.ErrInvalidScore.Has))
+	assert.True(t, errs.IsFunc(err, reputation.ErrInvalidStats.Has))
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+)
+
+// ErrWebhook is returned when an alert couldn't be posted to the webhook.
+var ErrWebhook = errs.Class("reputation webhook")
+
+// WebhookConfig defines where and how alerts are posted.
+type WebhookConfig struct {
+	URL      string        `help:"url which reputation alerts are posted to as json, alerts aren't posted when empty" default:""`
+	Attempts int           `help:"how many times posting a reputation alert is attempted" default:"3"`
+	Backoff  time.Duration `help:"delay before retrying to post a reputation alert, doubled after every attempt" default:"1s"`
+	Timeout  time.Duration `help:"maximum time spent posting a reputation alert including retries" default:"10s"`
+}
+
+// WebhookPayload is the JSON body posted for an alert.
+type WebhookPayload struct {
+	Kind             string       `json:"kind"`
+	NodeID           storj.NodeID `json:"nodeId"`
+	SatelliteID      storj.NodeID `json:"satelliteId"`
+	Severity         string       `json:"severity"`
+	PreviousSeverity string       `json:"previousSeverity"`
+	Metric           string       `json:"metric"`
+	Score            float64      `json:"score"`
+	// PreviousScore and ElapsedSeconds are only set for rapid drops.
+	PreviousScore  *float64 `json:"previousScore,omitempty"`
+	ElapsedSeconds *float64 `json:"elapsedSeconds,omitempty"`
+}
+
+// WebhookNotifier posts alerts to a webhook.
+type WebhookNotifier struct {
+	log    *zap.Logger
+	nodeID storj.NodeID
+	config WebhookConfig
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new notifier which posts alerts of nodeID.
+func NewWebhookNotifier(log *zap.Logger, nodeID storj.NodeID, config WebhookConfig) *WebhookNotifier {
+	return &WebhookNotifier{
+		log:    log,
+		nodeID: nodeID,
+		config: config,
+		client: &http.Client{},
+	}
+}
+
+// Notify posts the alert to the webhook, it implements AlertHandler. Requests which
+// fail with a server error or don't reach the server are retried with backoff until
+// the attempts are used up or the timeout expires.
+func (notifier *WebhookNotifier) Notify(ctx context.Context, alert Alert) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	payload := WebhookPayload{
+		Kind:             alert.Kind.String(),
+		NodeID:           notifier.nodeID,
+		SatelliteID:      alert.SatelliteID,
+		Severity:         alert.Current.String(),
+		PreviousSeverity: alert.Previous.String(),
+		Metric:           MetricOnline.String(),
+		Score:            alert.Stats.OnlineScore,
+	}
+	if drop := alert.RapidDrop; drop != nil {
+		elapsed := drop.Elapsed.Seconds()
+		payload.Metric = drop.Metric.String()
+		payload.Score = drop.After
+		payload.PreviousScore = &drop.Before
+		payload.ElapsedSeconds = &elapsed
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return ErrWebhook.Wrap(err)
+	}
+
+	if notifier.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, notifier.config.Timeout)
+		defer cancel()
+	}
+
+	backoff := notifier.config.Backoff
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		retry, err = notifier.post(ctx, body)
+		if err == nil || !retry || attempt >= notifier.config.Attempts {
+			return err
+		}
+
+		notifier.log.Debug("posting reputation alert failed, retrying",
+			zap.Stringer("Satellite ID", alert.SatelliteID),
+			zap.Int("Attempt", attempt),
+			zap.Error(err))
+
+		if !sync2.Sleep(ctx, backoff) {
+			return ErrWebhook.Wrap(errs.Combine(err, ctx.Err()))
+		}
+		backoff *= 2
+	}
+}
+
+// post sends body to the webhook once and returns whether a failure can be retried.
+func (notifier *WebhookNotifier) post(ctx context.Context, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notifier.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, ErrWebhook.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := notifier.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, ErrWebhook.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, resp.Body.Close()) }()
+
+	switch {
+	case resp.StatusCode >= 500:
+		return true, ErrWebhook.New("server error: %s", resp.Status)
+	case resp.StatusCode >= 300:
+		return false, ErrWebhook.New("unexpected status: %s", resp.Status)
+	}
+	return false, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reputation_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/reputation"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodeID := testrand.NodeID()
+	alert := reputation.Alert{
+		SatelliteID: testrand.NodeID(),
+		Previous:    reputation.RiskSafe,
+		Current:     reputation.RiskWarning,
+		Stats:       reputation.Stats{OnlineScore: 0.8},
+	}
+	config := reputation.WebhookConfig{
+		Attempts: 3,
+		Backoff:  time.Millisecond,
+		Timeout:  time.Minute,
+	}
+
+	t.Run("payload", func(t *testing.T) {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the first attempt fails with a server error and is retried.
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+			var payload map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, map[string]interface{}{
+				"kind":             "severity",
+				"nodeId":           nodeID.String(),
+				"satelliteId":      alert.SatelliteID.String(),
+				"severity":         "warning",
+				"previousSeverity": "safe",
+				"metric":           "online",
+				"score":            0.8,
+			}, payload)
+		}))
+		defer server.Close()
+
+		config := config
+		config.URL = server.URL
+		notifier := reputation.NewWebhookNotifier(zaptest.NewLogger(t), nodeID, config)
+
+		require.NoError(t, notifier.Notify(ctx, alert))
+		require.EqualValues(t, 2, atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("rapid drop payload", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, map[string]interface{}{
+				"kind":             "rapidDrop",
+				"nodeId":           nodeID.String(),
+				"satelliteId":      alert.SatelliteID.String(),
+				"severity":         "warning",
+				"previousSeverity": "safe",
+				"metric":           "audit",
+				"score":            0.85,
+				"previousScore":    0.99,
+				"elapsedSeconds":   float64(1800),
+			}, payload)
+		}))
+		defer server.Close()
+
+		config := config
+		config.URL = server.URL
+		notifier := reputation.NewWebhookNotifier(zaptest.NewLogger(t), nodeID, config)
+
+		alert := alert
+		alert.Kind = reputation.AlertRapidDrop
+		alert.RapidDrop = &reputation.RapidDrop{
+			Metric:  reputation.MetricAuditKnown,
+			Before:  0.99,
+			After:   0.85,
+			Elapsed: 30 * time.Minute,
+		}
+		require.NoError(t, notifier.Notify(ctx, alert))
+	})
+
+	t.Run("gives up", func(t *testing.T) {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		config := config
+		config.URL = server.URL
+		notifier := reputation.NewWebhookNotifier(zaptest.NewLogger(t), nodeID, config)
+
+		err := notifier.Notify(ctx, alert)
+		require.True(t, reputation.ErrWebhook.Has(err), err)
+		require.EqualValues(t, 3, atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("client errors aren't retried", func(t *testing.T) {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		config := config
+		config.URL = server.URL
+		notifier := reputation.NewWebhookNotifier(zaptest.NewLogger(t), nodeID, config)
+
+		require.Error(t, notifier.Notify(ctx, alert))
+		require.EqualValues(t, 1, atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		config := config
+		config.URL = server.URL
+		config.Attempts = 100
+		config.Backoff = time.Hour
+		config.Timeout = 50 * time.Millisecond
+		notifier := reputation.NewWebhookNotifier(zaptest.NewLogger(t), nodeID, config)
+
+		start := time.Now()
+		require.Error(t, notifier.Notify(ctx, alert))
+		require.True(t, time.Since(start) < 10*time.Second, "notify must not outlast the timeout")
+	})
+}
@@ -436,6 +436,33 @@ func (db *bandwidthDB) getDailyUsageRollups(ctx context.Context, cond string, ar
 	return usageRollups, ErrBandwidth.Wrap(rows.Err())
 }
 
+// earliestUsage returns the time of the earliest bandwidth usage recorded for the satellite.
+// Returns false when there is no usage for the satellite.
+func (db *bandwidthDB) earliestUsage(ctx context.Context, satelliteID storj.NodeID) (_ time.Time, _ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var earliest time.Time
+	for _, query := range []string{
+		`SELECT created_at FROM bandwidth_usage WHERE satellite_id = ? ORDER BY created_at LIMIT 1`,
+		`SELECT interval_start FROM bandwidth_usage_rollups WHERE satellite_id = ? ORDER BY interval_start LIMIT 1`,
+	} {
+		var t time.Time
+		err := db.QueryRowContext(ctx, query, satelliteID).Scan(&t)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return time.Time{}, false, ErrBandwidth.Wrap(err)
+		}
+
+		if earliest.IsZero() || t.Before(earliest) {
+			earliest = t
+		}
+	}
+
+	return earliest, !earliest.IsZero(), nil
+}
+
 func getBeginningOfMonth(now time.Time) time.Time {
 	y, m, _ := now.UTC().Date()
 	return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
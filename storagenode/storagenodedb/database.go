@@ -18,6 +18,7 @@ import (
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/common/storj"
 	"storj.io/storj/private/dbutil"
 	"storj.io/storj/private/dbutil/dbschema"
 	"storj.io/storj/private/dbutil/sqliteutil"
@@ -58,7 +59,7 @@ type DBContainer interface {
 }
 
 // withTx is a helper method which executes callback in transaction scope.
-func withTx(ctx context.Context, db tagsql.DB, cb func(tx tagsql.Tx) error) error {
+func withTx(ctx context.Context, db tagsql.DB, cb func(tx tagsql.Tx) error) (err error) {
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -83,6 +84,18 @@ type Config struct {
 	Driver    string // if unset, uses sqlite3
 	Pieces    string
 	Filestore filestore.Config
+
+	// ReputationQueryTimeout is applied to reputation queries without a deadline.
+	ReputationQueryTimeout time.Duration
+	// ReputationStrictDecode fails reading reputation stats with undecodable audit history
+	// instead of returning them without the audit history.
+	ReputationStrictDecode bool
+	// ReputationScoreHistoryEpsilon is the minimal online score change recorded in the
+	// online score history, reputation.OnlineScoreHistoryEpsilon is used when it's nil.
+	ReputationScoreHistoryEpsilon *float64
+	// ReputationMaxAge is the age of LastContactAt after which read reputation stats
+	// are marked as stale, stats are never stale when it's 0.
+	ReputationMaxAge time.Duration
 }
 
 // DB contains access to different database tables.
@@ -127,7 +140,10 @@ func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 	ordersDB := &ordersDB{}
 	pieceExpirationDB := &pieceExpirationDB{}
 	pieceSpaceUsedDB := &pieceSpaceUsedDB{}
-	reputationDB := &reputationDB{}
+	reputationDB, err := newReputationDB(log.Named("reputation"), config)
+	if err != nil {
+		return nil, err
+	}
 	storageUsageDB := &storageUsageDB{}
 	usedSerialsDB := &usedSerialsDB{}
 	satellitesDB := &satellitesDB{}
@@ -195,7 +211,10 @@ func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, err
 	ordersDB := &ordersDB{}
 	pieceExpirationDB := &pieceExpirationDB{}
 	pieceSpaceUsedDB := &pieceSpaceUsedDB{}
-	reputationDB := &reputationDB{}
+	reputationDB, err := newReputationDB(log.Named("reputation"), config)
+	if err != nil {
+		return nil, err
+	}
 	storageUsageDB := &storageUsageDB{}
 	usedSerialsDB := &usedSerialsDB{}
 	satellitesDB := &satellitesDB{}
@@ -318,7 +337,16 @@ func (db *DB) openDatabase(ctx context.Context, dbName string) error {
 		return ErrDatabase.Wrap(err)
 	}
 
-	sqlDB, err := tagsql.Open(ctx, driver, "file:"+path+"?_journal=WAL&_busy_timeout=10000")
+	dsn := "file:" + path + "?_journal=WAL&_busy_timeout=10000"
+	if dbName == ReputationDBName {
+		// reputation transactions read before writing, a deferred transaction
+		// would fail to upgrade its lock while another writer holds it instead
+		// of waiting for the busy timeout. Reads outside of transactions
+		// don't block the writer in WAL mode.
+		dsn += "&_txlock=immediate"
+	}
+
+	sqlDB, err := tagsql.Open(ctx, driver, dsn)
 	if err != nil {
 		return ErrDatabase.Wrap(err)
 	}
@@ -1838,6 +1866,269 @@ func (db *DB) Migration(ctx context.Context) *migrate.Migration {
 					`ALTER TABLE reputation ADD COLUMN audit_history BLOB`,
 				},
 			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add online_score_history table to reputation db",
+				Version:     48,
+				Action: migrate.SQL{
+					`CREATE TABLE online_score_history (
+						satellite_id BLOB NOT NULL,
+						timestamp TIMESTAMP NOT NULL,
+						score REAL NOT NULL,
+						PRIMARY KEY (satellite_id, timestamp)
+					)`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add satellite_address column to reputation db",
+				Version:     49,
+				Action: migrate.SQL{
+					`ALTER TABLE reputation ADD COLUMN satellite_address TEXT`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add disqualified_observed_at column to reputation db",
+				Version:     50,
+				Action: migrate.SQL{
+					`ALTER TABLE reputation ADD COLUMN disqualified_observed_at TIMESTAMP`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add generation column to reputation db",
+				Version:     51,
+				Action: migrate.SQL{
+					`ALTER TABLE reputation ADD COLUMN generation INTEGER NOT NULL DEFAULT 0`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Backfill unset joined_at from the earliest bandwidth usage",
+				Version:     52,
+				Action: migrate.Func(func(ctx context.Context, _ *zap.Logger, rdb tagsql.DB, rtx tagsql.Tx) (err error) {
+					// joined_at was backfilled with the unix epoch or stored as zero time,
+					// when the satellite didn't report it.
+					unset := time.Unix(0, 0).UTC()
+
+					rows, err := rtx.Query(ctx, `SELECT satellite_id FROM reputation WHERE joined_at <= ?`, unset)
+					if err != nil {
+						return errs.Wrap(err)
+					}
+					var satelliteIDs []storj.NodeID
+					for rows.Next() {
+						var satelliteID storj.NodeID
+						if err := rows.Scan(&satelliteID); err != nil {
+							return errs.Combine(errs.Wrap(err), rows.Close())
+						}
+						satelliteIDs = append(satelliteIDs, satelliteID)
+					}
+					if err := errs.Combine(rows.Err(), rows.Close()); err != nil {
+						return errs.Wrap(err)
+					}
+
+					for _, satelliteID := range satelliteIDs {
+						joinedAt, ok, err := db.bandwidthDB.earliestUsage(ctx, satelliteID)
+						if err != nil {
+							return errs.Wrap(err)
+						}
+						if !ok {
+							continue
+						}
+
+						_, err = rtx.Exec(ctx, `UPDATE reputation SET joined_at = ? WHERE satellite_id = ? AND joined_at <= ?`,
+							joinedAt.UTC(), satelliteID, unset)
+						if err != nil {
+							return errs.Wrap(err)
+						}
+					}
+
+					return nil
+				}),
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add audit_activity_history table to reputation db",
+				Version:     53,
+				Action: migrate.SQL{
+					`CREATE TABLE audit_activity_history (
+						satellite_id BLOB NOT NULL,
+						timestamp TIMESTAMP NOT NULL,
+						total_count INTEGER NOT NULL,
+						success_count INTEGER NOT NULL,
+						PRIMARY KEY (satellite_id, timestamp)
+					)`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add reputation_snapshots table to reputation db",
+				Version:     54,
+				Action: migrate.SQL{
+					`CREATE TABLE reputation_snapshots (
+						snapshot_at TIMESTAMP NOT NULL,
+						satellite_id BLOB NOT NULL,
+						uptime_success_count INTEGER NOT NULL,
+						uptime_total_count INTEGER NOT NULL,
+						uptime_reputation_alpha REAL NOT NULL,
+						uptime_reputation_beta REAL NOT NULL,
+						uptime_reputation_score REAL NOT NULL,
+						audit_success_count INTEGER NOT NULL,
+						audit_total_count INTEGER NOT NULL,
+						audit_reputation_alpha REAL NOT NULL,
+						audit_reputation_beta REAL NOT NULL,
+						audit_reputation_score REAL NOT NULL,
+						audit_unknown_reputation_alpha REAL NOT NULL,
+						audit_unknown_reputation_beta REAL NOT NULL,
+						audit_unknown_reputation_score REAL NOT NULL,
+						online_score REAL NOT NULL,
+						disqualified_at TIMESTAMP,
+						suspended_at TIMESTAMP,
+						offline_suspended_at TIMESTAMP,
+						offline_under_review_at TIMESTAMP,
+						updated_at TIMESTAMP NOT NULL,
+						joined_at TIMESTAMP NOT NULL,
+						satellite_address TEXT,
+						disqualified_observed_at TIMESTAMP,
+						generation INTEGER NOT NULL DEFAULT 0,
+						PRIMARY KEY (satellite_id, snapshot_at)
+					)`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add disqualification_reason column to reputation db",
+				Version:     55,
+				Action: migrate.SQL{
+					`ALTER TABLE reputation ADD COLUMN disqualification_reason TEXT NOT NULL DEFAULT ''`,
+					`ALTER TABLE reputation_snapshots ADD COLUMN disqualification_reason TEXT NOT NULL DEFAULT ''`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add last_contact_at column to reputation db",
+				Version:     56,
+				Action: migrate.SQL{
+					`ALTER TABLE reputation ADD COLUMN last_contact_at TIMESTAMP`,
+					`ALTER TABLE reputation_snapshots ADD COLUMN last_contact_at TIMESTAMP`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add muted column to reputation db",
+				Version:     57,
+				Action: migrate.SQL{
+					`ALTER TABLE reputation ADD COLUMN muted INTEGER NOT NULL DEFAULT 0`,
+					`ALTER TABLE reputation_snapshots ADD COLUMN muted INTEGER NOT NULL DEFAULT 0`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add reputation_last_seen table to reputation db",
+				Version:     58,
+				Action: migrate.SQL{
+					`CREATE TABLE reputation_last_seen (
+						satellite_id BLOB NOT NULL,
+						audit_score REAL NOT NULL,
+						unknown_audit_score REAL NOT NULL,
+						online_score REAL NOT NULL,
+						suspended_at TIMESTAMP,
+						offline_suspended_at TIMESTAMP,
+						disqualified_at TIMESTAMP,
+						PRIMARY KEY (satellite_id)
+					)`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add reputation_summary table to reputation db",
+				Version:     59,
+				Action: migrate.SQL{
+					`CREATE TABLE reputation_summary (
+						id INTEGER NOT NULL,
+						total_satellites INTEGER NOT NULL,
+						suspended_count INTEGER NOT NULL,
+						disqualified_count INTEGER NOT NULL,
+						min_online_score REAL NOT NULL,
+						PRIMARY KEY (id)
+					)`,
+					`INSERT INTO reputation_summary
+						SELECT 0, COUNT(*), COUNT(suspended_at), COUNT(disqualified_at), COALESCE(MIN(online_score), 0)
+						FROM reputation`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add index on updated_at to reputation table",
+				Version:     60,
+				Action: migrate.SQL{
+					// used by UpdatedSince and DeleteBefore, lookups by satellite_id use the primary key.
+					`CREATE INDEX IF NOT EXISTS idx_reputation_updated_at ON reputation(updated_at)`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add status_history table to reputation db",
+				Version:     61,
+				Action: migrate.SQL{
+					`CREATE TABLE status_history (
+						satellite_id BLOB NOT NULL,
+						timestamp TIMESTAMP NOT NULL,
+						available INTEGER NOT NULL,
+						PRIMARY KEY (satellite_id, timestamp)
+					)`,
+					// nodes which are currently unavailable became unavailable at the earliest of the
+					// disqualification and suspension times, the earliest non-null one is picked by
+					// rotating the arguments of COALESCE.
+					`INSERT INTO status_history (satellite_id, timestamp, available)
+						SELECT satellite_id, MIN(
+							COALESCE(disqualified_at, suspended_at, offline_suspended_at),
+							COALESCE(suspended_at, offline_suspended_at, disqualified_at),
+							COALESCE(offline_suspended_at, disqualified_at, suspended_at)
+						), 0
+						FROM reputation
+						WHERE COALESCE(disqualified_at, suspended_at, offline_suspended_at) IS NOT NULL`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add last_audit_at column to reputation db",
+				Version:     62,
+				Action: migrate.SQL{
+					`ALTER TABLE reputation ADD COLUMN last_audit_at TIMESTAMP`,
+					`ALTER TABLE reputation_snapshots ADD COLUMN last_audit_at TIMESTAMP`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add reputation_changelog table to reputation db",
+				Version:     63,
+				Action: migrate.SQL{
+					// the changelog is append-only, rows are only deleted by the retention pruner.
+					// AUTOINCREMENT keeps ids increasing even when the latest records are pruned,
+					// so they can be used as a cursor of changes.
+					`CREATE TABLE reputation_changelog (
+						id INTEGER PRIMARY KEY AUTOINCREMENT,
+						satellite_id BLOB NOT NULL,
+						timestamp TIMESTAMP NOT NULL,
+						field TEXT NOT NULL,
+						previous_value TEXT NOT NULL,
+						current_value TEXT NOT NULL
+					)`,
+					`CREATE INDEX idx_reputation_changelog_satellite_timestamp ON reputation_changelog(satellite_id, timestamp)`,
+				},
+			},
+			{
+				DB:          &db.reputationDB.DB,
+				Description: "Add history_updated_at column to reputation db",
+				Version:     64,
+				Action: migrate.SQL{
+					`ALTER TABLE reputation ADD COLUMN history_updated_at TIMESTAMP`,
+					// the audit history was last written together with the rest of the stats.
+					`UPDATE reputation SET history_updated_at = updated_at WHERE audit_history IS NOT NULL`,
+				},
+			},
 		},
 	}
 }
@@ -7,11 +7,18 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/zeebo/errs"
+	"go.uber.org/zap"
 
 	"storj.io/common/pb"
 	"storj.io/common/storj"
+	"storj.io/storj/private/tagsql"
 	"storj.io/storj/storagenode/reputation"
 )
 
@@ -21,13 +28,211 @@ var ErrReputation = errs.Class("reputation error")
 // ReputationDBName represents the database name.
 const ReputationDBName = "reputation"
 
+// defaultReputationQueryTimeout is used when the query timeout isn't configured.
+const defaultReputationQueryTimeout = 5 * time.Second
+
 // reputation works with node reputation DB.
 type reputationDB struct {
 	dbContainerImpl
+
+	log       *zap.Logger
+	broadcast *reputation.Broadcaster
+	// queryTimeout is applied to queries when the context has no deadline,
+	// so a wedged database surfaces as an error instead of a stuck request.
+	queryTimeout time.Duration
+	// strictDecode fails reads of stats with undecodable audit history,
+	// otherwise they are returned without the audit history.
+	strictDecode bool
+	// scoreHistoryEpsilon is the minimal online score change recorded in the history.
+	scoreHistoryEpsilon float64
+	// maxAge is the age of LastContactAt after which read stats are stale, 0 means never.
+	maxAge time.Duration
+}
+
+// newReputationDB creates the reputation DB with the reputation settings of config.
+func newReputationDB(log *zap.Logger, config Config) (*reputationDB, error) {
+	scoreHistoryEpsilon := reputation.OnlineScoreHistoryEpsilon
+	if config.ReputationScoreHistoryEpsilon != nil {
+		scoreHistoryEpsilon = *config.ReputationScoreHistoryEpsilon
+	}
+	if err := reputation.ValidateScoreHistoryEpsilon(scoreHistoryEpsilon); err != nil {
+		return nil, ErrReputation.Wrap(err)
+	}
+
+	return &reputationDB{
+		log:                 log,
+		broadcast:           reputation.NewBroadcaster(log),
+		queryTimeout:        config.ReputationQueryTimeout,
+		strictDecode:        config.ReputationStrictDecode,
+		scoreHistoryEpsilon: scoreHistoryEpsilon,
+		maxAge:              config.ReputationMaxAge,
+	}, nil
+}
+
+// withQueryTimeout applies the query timeout to ctx when it has no deadline. Every method
+// which queries the database applies it, except for ForEach, Backup and Compact, whose
+// duration depends on the callback, the writer and the size of the database.
+func (db *reputationDB) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+
+	timeout := db.queryTimeout
+	if timeout <= 0 {
+		timeout = defaultReputationQueryTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// annotateSpan annotates the current span of ctx, so traces show the amount of data
+// a reputation query worked with.
+func annotateSpan(ctx context.Context, name string, value int) {
+	if span := monkit.SpanFromCtx(ctx); span != nil {
+		span.Annotate(name, strconv.Itoa(value))
+	}
 }
 
 // Store inserts or updates reputation stats into the db.
-func (db *reputationDB) Store(ctx context.Context, stats reputation.Stats) (err error) {
+func (db *reputationDB) Store(ctx context.Context, stats reputation.Stats, opts ...reputation.StoreOption) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	annotateSpan(ctx, "satellites", 1)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	err = withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		validate := !reputation.NewStoreOptions(opts).WithoutValidation
+		if _, err := db.storeTx(ctx, tx, &stats, false, validate); err != nil {
+			return err
+		}
+		return db.updateSummaryTx(ctx, tx)
+	})
+	if err != nil {
+		return ErrReputation.Wrap(err)
+	}
+
+	db.broadcast.Publish(stats)
+	return nil
+}
+
+// StoreAll inserts or updates all reputation stats in a single transaction. Stats which
+// don't pass CheckStats are logged and skipped, either all other stats are stored or none of them.
+func (db *reputationDB) StoreAll(ctx context.Context, stats []reputation.Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	annotateSpan(ctx, "satellites", len(stats))
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	stored := reputation.ValidStats(db.log, stats)
+	err = withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		for i := range stored {
+			if _, err := db.storeTx(ctx, tx, &stored[i], false, false); err != nil {
+				return err
+			}
+		}
+		return db.updateSummaryTx(ctx, tx)
+	})
+	if err != nil {
+		return ErrReputation.Wrap(err)
+	}
+
+	db.broadcast.Publish(stored...)
+	return nil
+}
+
+// ReplaceAll replaces all reputation stats in the db in a single transaction,
+// stats of satellites not present in the input are deleted.
+// An empty input is refused with ErrEmptyReplace so the table isn't wiped by accident.
+func (db *reputationDB) ReplaceAll(ctx context.Context, stats []reputation.Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(stats) == 0 {
+		return ErrReputation.Wrap(reputation.ErrEmptyReplace)
+	}
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	args := make([]interface{}, len(stats))
+	for i := range stats {
+		args[i] = stats[i].SatelliteID
+	}
+
+	// stats of satellites in the input are replaced by storeTx, so values which
+	// are kept between syncs, like the muted flag, aren't lost.
+	stored := append([]reputation.Stats(nil), stats...)
+	err = withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			`DELETE FROM reputation WHERE satellite_id NOT IN (?`+strings.Repeat(",?", len(args)-1)+`)`,
+			args...,
+		)
+		if err != nil {
+			return err
+		}
+		for i := range stored {
+			if _, err := db.storeTx(ctx, tx, &stored[i], false, true); err != nil {
+				return err
+			}
+		}
+		return db.updateSummaryTx(ctx, tx)
+	})
+	if err != nil {
+		return ErrReputation.Wrap(err)
+	}
+
+	db.broadcast.Publish(stored...)
+	return nil
+}
+
+// StoreIfNewer inserts reputation stats into the db or updates them when
+// stats.UpdatedAt is after the stored UpdatedAt and they changed besides timestamps.
+// Returns whether stats were written.
+func (db *reputationDB) StoreIfNewer(ctx context.Context, stats reputation.Stats) (written bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	err = withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		// stats which didn't change except for timestamps aren't written again.
+		stored, err := db.get(ctx, tx, stats.SatelliteID)
+		switch {
+		case errors.Is(err, reputation.ErrNoStats):
+		case err != nil:
+			return err
+		case stored.EqualIgnoringTimestamps(stats):
+			return nil
+		}
+
+		written, err = db.storeTx(ctx, tx, &stats, true, true)
+		if err != nil || !written {
+			return err
+		}
+		return db.updateSummaryTx(ctx, tx)
+	})
+	if err != nil {
+		return false, ErrReputation.Wrap(err)
+	}
+
+	if written {
+		db.broadcast.Publish(stats)
+	}
+	return written, nil
+}
+
+// Subscribe returns a channel which receives stats whenever they are written,
+// the channel is closed when ctx is canceled.
+func (db *reputationDB) Subscribe(ctx context.Context) (_ <-chan reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.broadcast.Subscribe(ctx)
+}
+
+// storeTx inserts or updates reputation stats within tx, when onlyIfNewer is set existing
+// stats are only replaced by stats with a later UpdatedAt. Stats are checked by CheckStats
+// when validate is set and updated to the stored values.
+func (db *reputationDB) storeTx(ctx context.Context, tx tagsql.Tx, stats *reputation.Stats, onlyIfNewer, validate bool) (written bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	query := `INSERT OR REPLACE INTO reputation (
@@ -52,8 +257,57 @@ func (db *reputationDB) Store(ctx context.Context, stats reputation.Stats) (err
 			offline_suspended_at,
 			offline_under_review_at,
 			updated_at,
-			joined_at
-		) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
+			joined_at,
+			satellite_address,
+			disqualified_observed_at,
+			generation,
+			disqualification_reason,
+			last_contact_at,
+			muted,
+			last_audit_at,
+			history_updated_at
+		) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
+
+	if onlyIfNewer {
+		query = strings.Replace(query, "INSERT OR REPLACE", "INSERT", 1) + `
+		ON CONFLICT(satellite_id) DO UPDATE SET
+			uptime_success_count = excluded.uptime_success_count,
+			uptime_total_count = excluded.uptime_total_count,
+			uptime_reputation_alpha = excluded.uptime_reputation_alpha,
+			uptime_reputation_beta = excluded.uptime_reputation_beta,
+			uptime_reputation_score = excluded.uptime_reputation_score,
+			audit_success_count = excluded.audit_success_count,
+			audit_total_count = excluded.audit_total_count,
+			audit_reputation_alpha = excluded.audit_reputation_alpha,
+			audit_reputation_beta = excluded.audit_reputation_beta,
+			audit_reputation_score = excluded.audit_reputation_score,
+			audit_unknown_reputation_alpha = excluded.audit_unknown_reputation_alpha,
+			audit_unknown_reputation_beta = excluded.audit_unknown_reputation_beta,
+			audit_unknown_reputation_score = excluded.audit_unknown_reputation_score,
+			online_score = excluded.online_score,
+			audit_history = excluded.audit_history,
+			disqualified_at = excluded.disqualified_at,
+			suspended_at = excluded.suspended_at,
+			offline_suspended_at = excluded.offline_suspended_at,
+			offline_under_review_at = excluded.offline_under_review_at,
+			updated_at = excluded.updated_at,
+			joined_at = excluded.joined_at,
+			satellite_address = excluded.satellite_address,
+			disqualified_observed_at = excluded.disqualified_observed_at,
+			generation = excluded.generation,
+			disqualification_reason = excluded.disqualification_reason,
+			last_contact_at = excluded.last_contact_at,
+			muted = excluded.muted,
+			last_audit_at = excluded.last_audit_at,
+			history_updated_at = excluded.history_updated_at
+		WHERE excluded.updated_at > reputation.updated_at`
+	}
+
+	if validate {
+		if err := reputation.CheckStats(stats); err != nil {
+			return false, err
+		}
+	}
 
 	// ensure we insert utc
 	if stats.DisqualifiedAt != nil {
@@ -72,16 +326,79 @@ func (db *reputationDB) Store(ctx context.Context, stats reputation.Stats) (err
 		utc := stats.OfflineUnderReviewAt.UTC()
 		stats.OfflineUnderReviewAt = &utc
 	}
+	if stats.LastContactAt != nil {
+		utc := stats.LastContactAt.UTC()
+		stats.LastContactAt = &utc
+	}
+	if stats.LastAuditAt != nil {
+		utc := stats.LastAuditAt.UTC()
+		stats.LastAuditAt = &utc
+	}
+	stats.UpdatedAt = stats.UpdatedAt.UTC()
+	stats.JoinedAt = stats.JoinedAt.UTC()
+
+	// previously stored values are needed to keep the time when the disqualification
+	// was observed for the first time, to keep the last contact time when the stats
+	// weren't fetched from the satellite, to keep the muted flag, which is only
+	// changed by Mute and Unmute, to keep or derive the last audit time, to
+	// compute the audit activity and to record the changed fields in the changelog.
+	var observedAt, lastContactAt, lastAuditAt *time.Time
+	var muted bool
+	var previous *reputation.Metric
+	var previousStats *reputation.Stats
+	var stored reputation.Stats
+	err = tx.QueryRowContext(ctx,
+		`SELECT disqualified_observed_at, last_contact_at, muted, last_audit_at,
+			audit_total_count, audit_success_count, audit_reputation_score, audit_unknown_reputation_score,
+			online_score, disqualified_at, suspended_at, offline_suspended_at, offline_under_review_at
+		FROM reputation WHERE satellite_id = ?`,
+		stats.SatelliteID,
+	).Scan(&observedAt, &lastContactAt, &muted, &lastAuditAt,
+		&stored.Audit.TotalCount, &stored.Audit.SuccessCount, &stored.Audit.Score, &stored.Audit.UnknownScore,
+		&stored.OnlineScore, &stored.DisqualifiedAt, &stored.SuspendedAt, &stored.OfflineSuspendedAt, &stored.OfflineUnderReviewAt)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return false, err
+	default:
+		previous = &stored.Audit
+		previousStats = &stored
+		stats.Muted = muted
+	}
+	switch {
+	case observedAt != nil:
+		stats.DisqualifiedObservedAt = observedAt
+	case stats.DisqualifiedAt != nil:
+		now := time.Now().UTC()
+		stats.DisqualifiedObservedAt = &now
+	default:
+		stats.DisqualifiedObservedAt = nil
+	}
+	if stats.LastContactAt == nil {
+		stats.LastContactAt = lastContactAt
+	}
+	if stats.LastAuditAt == nil {
+		stats.LastAuditAt = lastAuditAt
+		if previous != nil && stats.Audit.TotalCount > previous.TotalCount {
+			auditedAt := stats.UpdatedAt
+			stats.LastAuditAt = &auditedAt
+		}
+	}
 
+	// the audit history is written together with the rest of the stats.
 	var auditHistoryBytes []byte
+	stats.HistoryUpdatedAt = nil
 	if stats.AuditHistory != nil {
 		auditHistoryBytes, err = pb.Marshal(stats.AuditHistory)
 		if err != nil {
-			return ErrReputation.Wrap(err)
+			return false, err
 		}
+		historyUpdatedAt := stats.UpdatedAt
+		stats.HistoryUpdatedAt = &historyUpdatedAt
 	}
+	annotateSpan(ctx, "audit_history_bytes", len(auditHistoryBytes))
 
-	_, err = db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		stats.SatelliteID,
 		stats.Uptime.SuccessCount,
 		stats.Uptime.TotalCount,
@@ -102,22 +419,188 @@ func (db *reputationDB) Store(ctx context.Context, stats reputation.Stats) (err
 		stats.SuspendedAt,
 		stats.OfflineSuspendedAt,
 		stats.OfflineUnderReviewAt,
-		stats.UpdatedAt.UTC(),
-		stats.JoinedAt.UTC(),
+		stats.UpdatedAt,
+		stats.JoinedAt,
+		sql.NullString{String: stats.SatelliteAddress, Valid: stats.SatelliteAddress != ""},
+		stats.DisqualifiedObservedAt,
+		stats.Generation,
+		stats.DisqualificationReason,
+		stats.LastContactAt,
+		stats.Muted,
+		stats.LastAuditAt,
+		stats.HistoryUpdatedAt,
 	)
+	if err != nil {
+		return false, err
+	}
 
-	return ErrReputation.Wrap(err)
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	if err := db.storeChangelog(ctx, tx, previousStats, *stats); err != nil {
+		return false, err
+	}
+	if err := db.storeOnlineScoreSample(ctx, tx, *stats); err != nil {
+		return false, err
+	}
+	if err := db.storeStatusTransition(ctx, tx, *stats); err != nil {
+		return false, err
+	}
+	return true, db.storeAuditActivitySample(ctx, tx, previous, *stats)
+}
+
+// storeChangelog appends records of the fields which changed since previous stats.
+func (db *reputationDB) storeChangelog(ctx context.Context, tx tagsql.Tx, previous *reputation.Stats, stats reputation.Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	timestamp := stats.UpdatedAt.UTC()
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+
+	for _, record := range reputation.NewChangeRecords(previous, stats, timestamp) {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO reputation_changelog (satellite_id, timestamp, field, previous_value, current_value)
+				VALUES (?, ?, ?, ?, ?)`,
+			record.SatelliteID, record.Timestamp, string(record.Field), record.Before, record.After,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeOnlineScoreSample appends an online score sample when the online score
+// changed since the last sample by at least the epsilon, the first sample of a
+// satellite is always appended as the baseline.
+func (db *reputationDB) storeOnlineScoreSample(ctx context.Context, tx tagsql.Tx, stats reputation.Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	timestamp := stats.UpdatedAt.UTC()
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+
+	var lastScore float64
+	err = tx.QueryRowContext(ctx,
+		`SELECT score FROM online_score_history WHERE satellite_id = ? ORDER BY timestamp DESC LIMIT 1`,
+		stats.SatelliteID,
+	).Scan(&lastScore)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return err
+	case !reputation.ScoreSampleChanged(lastScore, stats.OnlineScore, db.scoreHistoryEpsilon):
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT OR REPLACE INTO online_score_history (satellite_id, timestamp, score) VALUES (?, ?, ?)`,
+		stats.SatelliteID, timestamp, stats.OnlineScore,
+	)
+	return err
+}
+
+// storeStatusTransition appends a status transition when the availability changed
+// since the last transition.
+func (db *reputationDB) storeStatusTransition(ctx context.Context, tx tagsql.Tx, stats reputation.Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var last *reputation.StatusTransition
+	var lastTransition reputation.StatusTransition
+	err = tx.QueryRowContext(ctx,
+		`SELECT timestamp, available FROM status_history WHERE satellite_id = ? ORDER BY timestamp DESC LIMIT 1`,
+		stats.SatelliteID,
+	).Scan(&lastTransition.Timestamp, &lastTransition.Available)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return err
+	default:
+		last = &lastTransition
+	}
+
+	transition, ok := reputation.NextStatusTransition(last, stats)
+	if !ok {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO status_history (satellite_id, timestamp, available) VALUES (?, ?, ?)
+			ON CONFLICT(satellite_id, timestamp) DO UPDATE SET available = excluded.available`,
+		stats.SatelliteID, transition.Timestamp.UTC(), transition.Available,
+	)
+	return err
+}
+
+// storeAuditActivitySample appends the change of audit counts since the previously
+// stored stats.
+// Nothing is appended for the first stats of a satellite or when counts decreased.
+func (db *reputationDB) storeAuditActivitySample(ctx context.Context, tx tagsql.Tx, previous *reputation.Metric, stats reputation.Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if previous == nil {
+		return nil
+	}
+
+	totalDelta := stats.Audit.TotalCount - previous.TotalCount
+	successDelta := stats.Audit.SuccessCount - previous.SuccessCount
+	if totalDelta < 0 || successDelta < 0 || (totalDelta == 0 && successDelta == 0) {
+		return nil
+	}
+
+	timestamp := stats.UpdatedAt.UTC()
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO audit_activity_history (satellite_id, timestamp, total_count, success_count) VALUES (?, ?, ?, ?)
+			ON CONFLICT(satellite_id, timestamp) DO UPDATE SET
+				total_count = total_count + excluded.total_count,
+				success_count = success_count + excluded.success_count`,
+		stats.SatelliteID, timestamp, totalDelta, successDelta,
+	)
+	return err
 }
 
 // Get retrieves stats for specific satellite.
-func (db *reputationDB) Get(ctx context.Context, satelliteID storj.NodeID) (_ *reputation.Stats, err error) {
+func (db *reputationDB) Get(ctx context.Context, satelliteID storj.NodeID, opts ...reputation.GetOption) (_ *reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	stats, err := db.get(ctx, db.GetDB(), satelliteID, opts...)
+	return stats, ErrReputation.Wrap(err)
+}
+
+// rowQuerier is implemented by both the database and its transactions.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// get retrieves stats for specific satellite using q, returns ErrNoStats when there are no stats for the satellite.
+func (db *reputationDB) get(ctx context.Context, q rowQuerier, satelliteID storj.NodeID, opts ...reputation.GetOption) (_ *reputation.Stats, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	// the audit history blob can be large, so it isn't even read when it's not needed.
+	auditHistoryColumns := "audit_history, history_updated_at"
+	if reputation.NewGetOptions(opts).WithoutAuditHistory {
+		auditHistoryColumns = "NULL, NULL"
+	}
+
 	stats := reputation.Stats{
 		SatelliteID: satelliteID,
 	}
 
-	row := db.QueryRowContext(ctx,
+	row := q.QueryRowContext(ctx,
 		`SELECT uptime_success_count,
 			uptime_total_count,
 			uptime_reputation_alpha,
@@ -132,18 +615,26 @@ func (db *reputationDB) Get(ctx context.Context, satelliteID storj.NodeID) (_ *r
 			audit_unknown_reputation_beta,
 			audit_unknown_reputation_score,
 			online_score,
-			audit_history,
+			`+auditHistoryColumns+`,
 			disqualified_at,
 			suspended_at,
 			offline_suspended_at,
 			offline_under_review_at,
 			updated_at,
-			joined_at
+			joined_at,
+			satellite_address,
+			disqualified_observed_at,
+			generation,
+			disqualification_reason,
+			last_contact_at,
+			muted,
+			last_audit_at
 		FROM reputation WHERE satellite_id = ?`,
 		satelliteID,
 	)
 
 	var auditHistoryBytes []byte
+	var satelliteAddress sql.NullString
 	err = row.Scan(
 		&stats.Uptime.SuccessCount,
 		&stats.Uptime.TotalCount,
@@ -160,33 +651,187 @@ func (db *reputationDB) Get(ctx context.Context, satelliteID storj.NodeID) (_ *r
 		&stats.Audit.UnknownScore,
 		&stats.OnlineScore,
 		&auditHistoryBytes,
+		&stats.HistoryUpdatedAt,
 		&stats.DisqualifiedAt,
 		&stats.SuspendedAt,
 		&stats.OfflineSuspendedAt,
 		&stats.OfflineUnderReviewAt,
 		&stats.UpdatedAt,
 		&stats.JoinedAt,
+		&satelliteAddress,
+		&stats.DisqualifiedObservedAt,
+		&stats.Generation,
+		&stats.DisqualificationReason,
+		&stats.LastContactAt,
+		&stats.Muted,
+		&stats.LastAuditAt,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		err = nil
-		return &stats, nil
+		return nil, reputation.ErrNoStats
 	}
 	if err != nil {
-		return &stats, ErrReputation.Wrap(err)
+		return nil, err
 	}
+	stats.SatelliteAddress = satelliteAddress.String
+	stats.Stale = stats.IsStale(db.maxAge, time.Now())
+	annotateSpan(ctx, "audit_history_bytes", len(auditHistoryBytes))
 
 	if auditHistoryBytes != nil {
-		stats.AuditHistory = &pb.AuditHistory{}
-		err = pb.Unmarshal(auditHistoryBytes, stats.AuditHistory)
+		stats.AuditHistory, err = db.readAuditHistory(satelliteID, auditHistoryBytes)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return &stats, ErrReputation.Wrap(err)
+	return &stats, nil
 }
 
-// All retrieves all stats from DB.
-func (db *reputationDB) All(ctx context.Context) (_ []reputation.Stats, err error) {
+// readAuditHistory decodes the stored audit history of specific satellite. A corrupted
+// audit history is logged and omitted, so the rest of the stats can still be read,
+// unless strict decoding is enabled.
+func (db *reputationDB) readAuditHistory(satelliteID storj.NodeID, data []byte) (*pb.AuditHistory, error) {
+	auditHistory, err := decodeAuditHistory(data)
+	if err != nil && !db.strictDecode {
+		db.log.Warn("unable to decode audit history, omitting it",
+			zap.Stringer("Satellite ID", satelliteID),
+			zap.Error(err))
+		return nil, nil
+	}
+	return auditHistory, err
+}
+
+// decodeAuditHistory unmarshals the stored audit history and verifies its windows.
+func decodeAuditHistory(data []byte) (*pb.AuditHistory, error) {
+	auditHistory := &pb.AuditHistory{}
+	if err := pb.Unmarshal(data, auditHistory); err != nil {
+		return nil, err
+	}
+	if err := reputation.ValidateAuditHistory(auditHistory); err != nil {
+		return nil, err
+	}
+	return auditHistory, nil
+}
+
+// SchemaVersion returns the version of the latest migration applied to the reputation database,
+// 0 when no migration was applied.
+func (db *reputationDB) SchemaVersion(ctx context.Context) (_ int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var version sql.NullInt64
+	err = db.QueryRowContext(ctx, `SELECT MAX(version) FROM `+VersionTable).Scan(&version)
+	return int(version.Int64), ErrReputation.Wrap(err)
+}
+
+// Exists returns whether stats are stored for specific satellite without decoding them.
+func (db *reputationDB) Exists(ctx context.Context, satelliteID storj.NodeID) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var exists bool
+	err = db.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM reputation WHERE satellite_id = ?)`,
+		satelliteID,
+	).Scan(&exists)
+	return exists, ErrReputation.Wrap(err)
+}
+
+// SatelliteIDs retrieves IDs of all satellites with stored stats ordered by satellite ID,
+// only the satellite_id column is read.
+func (db *reputationDB) SatelliteIDs(ctx context.Context) (_ []storj.NodeID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, `SELECT satellite_id FROM reputation ORDER BY satellite_id ASC`)
+	if err != nil {
+		return nil, ErrReputation.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var satelliteIDs []storj.NodeID
+	for rows.Next() {
+		var satelliteID storj.NodeID
+		if err := rows.Scan(&satelliteID); err != nil {
+			return nil, ErrReputation.Wrap(err)
+		}
+		satelliteIDs = append(satelliteIDs, satelliteID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrReputation.Wrap(err)
+	}
+	annotateSpan(ctx, "satellites", len(satelliteIDs))
+
+	return satelliteIDs, nil
+}
+
+// GetOnlineScore retrieves only the online score of specific satellite without reading the
+// audit history. Returns false when there are no stats for the satellite.
+func (db *reputationDB) GetOnlineScore(ctx context.Context, satelliteID storj.NodeID) (_ float64, _ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var score float64
+	err = db.QueryRowContext(ctx,
+		`SELECT online_score FROM reputation WHERE satellite_id = ?`,
+		satelliteID,
+	).Scan(&score)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, ErrReputation.Wrap(err)
+	}
+	return score, true, nil
+}
+
+// GetAuditScore retrieves only the audit score of specific satellite computed from alpha and
+// beta, without reading the audit history. Returns false when there are no stats for the satellite.
+func (db *reputationDB) GetAuditScore(ctx context.Context, satelliteID storj.NodeID) (_ float64, _ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var audit reputation.Metric
+	err = db.QueryRowContext(ctx,
+		`SELECT audit_reputation_alpha, audit_reputation_beta FROM reputation WHERE satellite_id = ?`,
+		satelliteID,
+	).Scan(&audit.Alpha, &audit.Beta)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, ErrReputation.Wrap(err)
+	}
+	return audit.ComputedScore(), true, nil
+}
+
+// GetBySatellites retrieves stats for the specified satellites with a single query.
+// Satellites which don't have stats stored are omitted from the result.
+func (db *reputationDB) GetBySatellites(ctx context.Context, satelliteIDs []storj.NodeID) (_ map[storj.NodeID]reputation.Stats, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	result := make(map[storj.NodeID]reputation.Stats, len(satelliteIDs))
+	if len(satelliteIDs) == 0 {
+		return result, nil
+	}
+
+	args := make([]interface{}, len(satelliteIDs))
+	for i, satelliteID := range satelliteIDs {
+		args[i] = satelliteID
+	}
+
 	query := `SELECT satellite_id,
 			uptime_success_count,
 			uptime_total_count,
@@ -202,24 +847,36 @@ func (db *reputationDB) All(ctx context.Context) (_ []reputation.Stats, err erro
 			audit_unknown_reputation_beta,
 			audit_unknown_reputation_score,
 			online_score,
+			audit_history,
+			history_updated_at,
 			disqualified_at,
 			suspended_at,
 			offline_suspended_at,
 			offline_under_review_at,
 			updated_at,
-			joined_at
-		FROM reputation`
+			joined_at,
+			satellite_address,
+			disqualified_observed_at,
+			generation,
+			disqualification_reason,
+			last_contact_at,
+			muted,
+			last_audit_at
+		FROM reputation WHERE satellite_id IN (?` + strings.Repeat(",?", len(satelliteIDs)-1) + `)`
 
-	rows, err := db.QueryContext(ctx, query)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, ErrReputation.Wrap(err)
 	}
 
 	defer func() { err = errs.Combine(err, rows.Close()) }()
 
-	var statsList []reputation.Stats
+	now := time.Now()
+
 	for rows.Next() {
 		var stats reputation.Stats
+		var auditHistoryBytes []byte
+		var satelliteAddress sql.NullString
 
 		err := rows.Scan(&stats.SatelliteID,
 			&stats.Uptime.SuccessCount,
@@ -236,20 +893,1291 @@ func (db *reputationDB) All(ctx context.Context) (_ []reputation.Stats, err erro
 			&stats.Audit.UnknownBeta,
 			&stats.Audit.UnknownScore,
 			&stats.OnlineScore,
+			&auditHistoryBytes,
+			&stats.HistoryUpdatedAt,
 			&stats.DisqualifiedAt,
 			&stats.SuspendedAt,
 			&stats.OfflineSuspendedAt,
 			&stats.OfflineUnderReviewAt,
 			&stats.UpdatedAt,
 			&stats.JoinedAt,
+			&satelliteAddress,
+			&stats.DisqualifiedObservedAt,
+			&stats.Generation,
+			&stats.DisqualificationReason,
+			&stats.LastContactAt,
+			&stats.Muted,
+			&stats.LastAuditAt,
 		)
-
 		if err != nil {
 			return nil, ErrReputation.Wrap(err)
 		}
+		stats.SatelliteAddress = satelliteAddress.String
+		stats.Stale = stats.IsStale(db.maxAge, now)
+
+		if auditHistoryBytes != nil {
+			stats.AuditHistory, err = db.readAuditHistory(stats.SatelliteID, auditHistoryBytes)
+			if err != nil {
+				return nil, ErrReputation.Wrap(err)
+			}
+		}
+
+		result[stats.SatelliteID] = stats
+	}
+
+	return result, ErrReputation.Wrap(rows.Err())
+}
+
+// All retrieves all stats from DB ordered by satellite ID.
+func (db *reputationDB) All(ctx context.Context) (_ []reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
 
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var statsList []reputation.Stats
+	err = db.ForEach(ctx, func(stats reputation.Stats) error {
 		statsList = append(statsList, stats)
+		return nil
+	})
+	annotateSpan(ctx, "satellites", len(statsList))
+	return statsList, err
+}
+
+// ForEach calls fn for all stats ordered by satellite ID as they are read from the database, so
+// they don't have to be held in memory at once. The query timeout isn't applied, because the
+// duration depends on fn.
+func (db *reputationDB) ForEach(ctx context.Context, fn func(reputation.Stats) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = db.forEachStatsFrom(ctx, "reputation", ` ORDER BY satellite_id ASC`, fn)
+	if errors.Is(err, reputation.ErrStopIteration) {
+		return nil
+	}
+	return err
+}
+
+// Filter retrieves stats matching all of the provided options ordered by opts.SortBy.
+func (db *reputationDB) Filter(ctx context.Context, opts reputation.FilterOpts) (_ []reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var conditions []string
+	var args []interface{}
+	if opts.OnlySuspended {
+		conditions = append(conditions, `(suspended_at IS NOT NULL OR offline_suspended_at IS NOT NULL)`)
+	}
+	if opts.OnlyDisqualified {
+		conditions = append(conditions, `disqualified_at IS NOT NULL`)
+	}
+	if opts.MinOnlineScore != nil {
+		conditions = append(conditions, `online_score >= ?`)
+		args = append(args, *opts.MinOnlineScore)
+	}
+	if opts.UpdatedAfter != nil {
+		conditions = append(conditions, `updated_at > ?`)
+		args = append(args, opts.UpdatedAfter.UTC())
+	}
+
+	query := ``
+	if len(conditions) > 0 {
+		query = ` WHERE ` + strings.Join(conditions, ` AND `)
+	}
+
+	// satellite_id is a blob, which is compared like storj.NodeID.Less.
+	switch opts.SortBy {
+	case reputation.SortByOnlineScore:
+		query += ` ORDER BY online_score ASC, satellite_id ASC`
+	case reputation.SortByJoinedAt:
+		query += ` ORDER BY joined_at ASC, satellite_id ASC`
+	default:
+		query += ` ORDER BY satellite_id ASC`
+	}
+
+	return db.selectStats(ctx, query, args...)
+}
+
+// GetByOnlineScoreRange retrieves stats with online score within the inclusive range,
+// the lowest online score first. Returns ErrInvalidScoreRange when min is above max.
+func (db *reputationDB) GetByOnlineScoreRange(ctx context.Context, min, max float64) (_ []reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !(min <= max) {
+		return nil, ErrReputation.Wrap(reputation.ErrInvalidScoreRange.New("min %v is above max %v", min, max))
+	}
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	return db.selectStats(ctx, ` WHERE online_score BETWEEN ? AND ? ORDER BY online_score ASC, satellite_id ASC`, min, max)
+}
+
+// snapshotColumns are the columns of the reputation table which are copied into snapshots.
+const snapshotColumns = `satellite_id,
+	uptime_success_count,
+	uptime_total_count,
+	uptime_reputation_alpha,
+	uptime_reputation_beta,
+	uptime_reputation_score,
+	audit_success_count,
+	audit_total_count,
+	audit_reputation_alpha,
+	audit_reputation_beta,
+	audit_reputation_score,
+	audit_unknown_reputation_alpha,
+	audit_unknown_reputation_beta,
+	audit_unknown_reputation_score,
+	online_score,
+	disqualified_at,
+	suspended_at,
+	offline_suspended_at,
+	offline_under_review_at,
+	updated_at,
+	joined_at,
+	satellite_address,
+	disqualified_observed_at,
+	generation,
+	disqualification_reason,
+	last_contact_at,
+	muted,
+	last_audit_at`
+
+// backupColumns are all columns of the reputation table, they are written to backups as they are.
+const backupColumns = `satellite_id,
+	uptime_success_count,
+	uptime_total_count,
+	uptime_reputation_alpha,
+	uptime_reputation_beta,
+	uptime_reputation_score,
+	audit_success_count,
+	audit_total_count,
+	audit_reputation_alpha,
+	audit_reputation_beta,
+	audit_reputation_score,
+	audit_unknown_reputation_alpha,
+	audit_unknown_reputation_beta,
+	audit_unknown_reputation_score,
+	online_score,
+	audit_history,
+	history_updated_at,
+	disqualified_at,
+	suspended_at,
+	offline_suspended_at,
+	offline_under_review_at,
+	updated_at,
+	joined_at,
+	satellite_address,
+	disqualified_observed_at,
+	generation,
+	disqualification_reason,
+	last_contact_at,
+	muted,
+	last_audit_at`
+
+// Backup writes all rows of the reputation table including the audit history blobs to w
+// ordered by satellite ID. The query timeout isn't applied, because the duration depends on w.
+func (db *reputationDB) Backup(ctx context.Context, w io.Writer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	writer, err := reputation.NewBackupWriter(w)
+	if err != nil {
+		return err
 	}
 
-	return statsList, rows.Err()
+	rows, err := db.QueryContext(ctx, `SELECT `+backupColumns+` FROM reputation ORDER BY satellite_id ASC`)
+	if err != nil {
+		return ErrReputation.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	satellites := 0
+	for rows.Next() {
+		var record reputation.BackupRecord
+		s := &record.Stats
+		var satelliteAddress sql.NullString
+		err := rows.Scan(
+			&s.SatelliteID,
+			&s.Uptime.SuccessCount,
+			&s.Uptime.TotalCount,
+			&s.Uptime.Alpha,
+			&s.Uptime.Beta,
+			&s.Uptime.Score,
+			&s.Audit.SuccessCount,
+			&s.Audit.TotalCount,
+			&s.Audit.Alpha,
+			&s.Audit.Beta,
+			&s.Audit.Score,
+			&s.Audit.UnknownAlpha,
+			&s.Audit.UnknownBeta,
+			&s.Audit.UnknownScore,
+			&s.OnlineScore,
+			&record.AuditHistory,
+			&s.HistoryUpdatedAt,
+			&s.DisqualifiedAt,
+			&s.SuspendedAt,
+			&s.OfflineSuspendedAt,
+			&s.OfflineUnderReviewAt,
+			&s.UpdatedAt,
+			&s.JoinedAt,
+			&satelliteAddress,
+			&s.DisqualifiedObservedAt,
+			&s.Generation,
+			&s.DisqualificationReason,
+			&s.LastContactAt,
+			&s.Muted,
+			&s.LastAuditAt,
+		)
+		if err != nil {
+			return ErrReputation.Wrap(err)
+		}
+		s.SatelliteAddress = satelliteAddress.String
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		satellites++
+	}
+	if err := rows.Err(); err != nil {
+		return ErrReputation.Wrap(err)
+	}
+	annotateSpan(ctx, "satellites", satellites)
+
+	return writer.Close()
+}
+
+// Restore replaces all rows of the reputation table with rows of a backup as they are in a single
+// transaction. The changelog and other history isn't written and stats aren't published to subscribers.
+func (db *reputationDB) Restore(ctx context.Context, r io.Reader, opts ...reputation.RestoreOption) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the whole backup is read first, so a malformed backup doesn't leave the table half restored.
+	records, err := reputation.ReadBackup(r)
+	if err != nil {
+		return err
+	}
+	annotateSpan(ctx, "satellites", len(records))
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	return ErrReputation.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		var stored bool
+		err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM reputation)`).Scan(&stored)
+		if err != nil {
+			return err
+		}
+		if stored && !reputation.NewRestoreOptions(opts).Force {
+			return reputation.ErrRestoreNotEmpty
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM reputation`); err != nil {
+			return err
+		}
+		for _, record := range records {
+			s := record.Stats
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO reputation (`+backupColumns+`)
+				VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+				s.SatelliteID,
+				s.Uptime.SuccessCount,
+				s.Uptime.TotalCount,
+				s.Uptime.Alpha,
+				s.Uptime.Beta,
+				s.Uptime.Score,
+				s.Audit.SuccessCount,
+				s.Audit.TotalCount,
+				s.Audit.Alpha,
+				s.Audit.Beta,
+				s.Audit.Score,
+				s.Audit.UnknownAlpha,
+				s.Audit.UnknownBeta,
+				s.Audit.UnknownScore,
+				s.OnlineScore,
+				record.AuditHistory,
+				utcTime(s.HistoryUpdatedAt),
+				utcTime(s.DisqualifiedAt),
+				utcTime(s.SuspendedAt),
+				utcTime(s.OfflineSuspendedAt),
+				utcTime(s.OfflineUnderReviewAt),
+				s.UpdatedAt.UTC(),
+				s.JoinedAt.UTC(),
+				sql.NullString{String: s.SatelliteAddress, Valid: s.SatelliteAddress != ""},
+				utcTime(s.DisqualifiedObservedAt),
+				s.Generation,
+				s.DisqualificationReason,
+				utcTime(s.LastContactAt),
+				s.Muted,
+				utcTime(s.LastAuditAt),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return db.updateSummaryTx(ctx, tx)
+	}))
+}
+
+// Snapshot stores a copy of all current stats and removes snapshots outside of the retention period.
+// Audit history is not included in snapshots.
+func (db *reputationDB) Snapshot(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	now := time.Now().UTC()
+	return ErrReputation.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			`INSERT OR REPLACE INTO reputation_snapshots (snapshot_at, `+snapshotColumns+`)
+				SELECT ?, `+snapshotColumns+` FROM reputation`,
+			now,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM reputation_snapshots WHERE snapshot_at < ?`,
+			now.Add(-reputation.SnapshotRetention),
+		)
+		return err
+	}))
+}
+
+// SnapshotAt retrieves the latest snapshot of satellite stats taken at or before t,
+// audit history is not included. Returns ErrNoStats when there is no such snapshot.
+func (db *reputationDB) SnapshotAt(ctx context.Context, satelliteID storj.NodeID, t time.Time) (_ reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	statsList, err := db.selectStatsFrom(ctx, `reputation_snapshots`,
+		` WHERE satellite_id = ? AND snapshot_at <= ? ORDER BY snapshot_at DESC LIMIT 1`,
+		satelliteID, t.UTC(),
+	)
+	if err != nil {
+		return reputation.Stats{}, err
+	}
+	if len(statsList) == 0 {
+		return reputation.Stats{}, ErrReputation.Wrap(reputation.ErrNoStats)
+	}
+	return statsList[0], nil
+}
+
+// UpdatedSince retrieves stats updated after t ordered by updated_at, audit history is not included.
+// Stats updated exactly at t are excluded, so t can be the latest UpdatedAt seen by the caller.
+// Timestamps are stored with nanosecond precision, so updates within the same second are distinguished.
+func (db *reputationDB) UpdatedSince(ctx context.Context, t time.Time) (_ []reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	// the range scan and the order are served by idx_reputation_updated_at.
+	return db.selectStats(ctx, ` WHERE updated_at > ? ORDER BY updated_at ASC, satellite_id ASC`, t.UTC())
+}
+
+// GetRecentlyJoined retrieves stats of satellites which the node joined at or after since,
+// the most recently joined first. Stats stored before joined at was tracked have a zero
+// joined at and are excluded.
+func (db *reputationDB) GetRecentlyJoined(ctx context.Context, since time.Time) (_ []reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	return db.selectStats(ctx, ` WHERE joined_at >= ? AND joined_at > ? ORDER BY joined_at DESC, satellite_id ASC`,
+		since.UTC(), time.Time{})
+}
+
+// GetWorst retrieves stats of the satellite with the lowest score of the metric.
+// Returns false when there are no stats.
+func (db *reputationDB) GetWorst(ctx context.Context, metric reputation.MetricKind) (_ reputation.Stats, _ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var column string
+	switch metric {
+	case reputation.MetricOnline:
+		column = `online_score`
+	case reputation.MetricAuditKnown:
+		// matches reputation.Metric.ComputedScore, the stored score is what the satellite reported.
+		column = `(CASE WHEN audit_reputation_alpha + audit_reputation_beta = 0 THEN 0
+			ELSE audit_reputation_alpha / (audit_reputation_alpha + audit_reputation_beta) END)`
+	case reputation.MetricAuditUnknown:
+		column = `audit_unknown_reputation_score`
+	default:
+		return reputation.Stats{}, false, ErrReputation.New("unknown metric %v", metric)
+	}
+
+	statsList, err := db.selectStats(ctx, ` ORDER BY `+column+` ASC, satellite_id ASC LIMIT 1`)
+	if err != nil || len(statsList) == 0 {
+		return reputation.Stats{}, false, err
+	}
+	return statsList[0], true, nil
+}
+
+// OldestContact retrieves stats of the satellite with the oldest LastContactAt, stats without
+// LastContactAt are the oldest.
+func (db *reputationDB) OldestContact(ctx context.Context) (_ reputation.Stats, _ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	statsList, err := db.selectStats(ctx,
+		` ORDER BY last_contact_at IS NOT NULL ASC, last_contact_at ASC, satellite_id ASC LIMIT 1`)
+	if err != nil || len(statsList) == 0 {
+		return reputation.Stats{}, false, err
+	}
+	return statsList[0], true, nil
+}
+
+// selectStats retrieves stats without audit history, suffix is appended to the query.
+func (db *reputationDB) selectStats(ctx context.Context, suffix string, args ...interface{}) (_ []reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return db.selectStatsFrom(ctx, `reputation`, suffix, args...)
+}
+
+// selectStatsFrom retrieves stats without audit history from the table, suffix is appended to the query.
+// The table must have the same columns as the reputation table.
+func (db *reputationDB) selectStatsFrom(ctx context.Context, table, suffix string, args ...interface{}) (_ []reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var statsList []reputation.Stats
+	err = db.forEachStatsFrom(ctx, table, suffix, func(stats reputation.Stats) error {
+		statsList = append(statsList, stats)
+		return nil
+	}, args...)
+	return statsList, err
+}
+
+// forEachStatsFrom calls fn for stats without audit history from the table while rows are read,
+// suffix is appended to the query. Errors returned by fn are returned as they are.
+func (db *reputationDB) forEachStatsFrom(ctx context.Context, table, suffix string, fn func(reputation.Stats) error, args ...interface{}) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `SELECT satellite_id,
+			uptime_success_count,
+			uptime_total_count,
+			uptime_reputation_alpha,
+			uptime_reputation_beta,
+			uptime_reputation_score,
+			audit_success_count,
+			audit_total_count,
+			audit_reputation_alpha,
+			audit_reputation_beta,
+			audit_reputation_score,
+			audit_unknown_reputation_alpha,
+			audit_unknown_reputation_beta,
+			audit_unknown_reputation_score,
+			online_score,
+			disqualified_at,
+			suspended_at,
+			offline_suspended_at,
+			offline_under_review_at,
+			updated_at,
+			joined_at,
+			satellite_address,
+			disqualified_observed_at,
+			generation,
+			disqualification_reason,
+			last_contact_at,
+			muted,
+			last_audit_at
+		FROM ` + table + suffix
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return ErrReputation.Wrap(err)
+	}
+
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	now := time.Now()
+
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var stats reputation.Stats
+		var satelliteAddress sql.NullString
+
+		err := rows.Scan(&stats.SatelliteID,
+			&stats.Uptime.SuccessCount,
+			&stats.Uptime.TotalCount,
+			&stats.Uptime.Alpha,
+			&stats.Uptime.Beta,
+			&stats.Uptime.Score,
+			&stats.Audit.SuccessCount,
+			&stats.Audit.TotalCount,
+			&stats.Audit.Alpha,
+			&stats.Audit.Beta,
+			&stats.Audit.Score,
+			&stats.Audit.UnknownAlpha,
+			&stats.Audit.UnknownBeta,
+			&stats.Audit.UnknownScore,
+			&stats.OnlineScore,
+			&stats.DisqualifiedAt,
+			&stats.SuspendedAt,
+			&stats.OfflineSuspendedAt,
+			&stats.OfflineUnderReviewAt,
+			&stats.UpdatedAt,
+			&stats.JoinedAt,
+			&satelliteAddress,
+			&stats.DisqualifiedObservedAt,
+			&stats.Generation,
+			&stats.DisqualificationReason,
+			&stats.LastContactAt,
+			&stats.Muted,
+			&stats.LastAuditAt,
+		)
+
+		if err != nil {
+			return ErrReputation.Wrap(err)
+		}
+		stats.SatelliteAddress = satelliteAddress.String
+		// stats in other tables, like snapshots, are past states, which aren't stale.
+		if table == "reputation" {
+			stats.Stale = stats.IsStale(db.maxAge, now)
+		}
+
+		if err := fn(stats); err != nil {
+			return err
+		}
+	}
+
+	return ErrReputation.Wrap(rows.Err())
+}
+
+// DeleteBefore deletes stats which were last updated before the provided time.
+// Stats of satellites which have disqualified the node are retained.
+func (db *reputationDB) DeleteBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var deleted int64
+	err = withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		// the rows are found with idx_reputation_updated_at.
+		result, err := tx.ExecContext(ctx,
+			`DELETE FROM reputation WHERE updated_at < ? AND disqualified_at IS NULL`,
+			before.UTC(),
+		)
+		if err != nil {
+			return err
+		}
+
+		deleted, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		return db.updateSummaryTx(ctx, tx)
+	})
+	if err != nil {
+		return 0, ErrReputation.Wrap(err)
+	}
+	return deleted, nil
+}
+
+// DeleteScoreHistoryBefore deletes online score samples recorded before the provided time.
+func (db *reputationDB) DeleteScoreHistoryBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := db.ExecContext(ctx,
+		`DELETE FROM online_score_history WHERE timestamp < ?`,
+		before.UTC(),
+	)
+	if err != nil {
+		return 0, ErrReputation.Wrap(err)
+	}
+
+	deleted, err := result.RowsAffected()
+	return deleted, ErrReputation.Wrap(err)
+}
+
+// DeleteChangelogBefore deletes changelog records recorded before the provided time.
+func (db *reputationDB) DeleteChangelogBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := db.ExecContext(ctx,
+		`DELETE FROM reputation_changelog WHERE timestamp < ?`,
+		before.UTC(),
+	)
+	if err != nil {
+		return 0, ErrReputation.Wrap(err)
+	}
+
+	deleted, err := result.RowsAffected()
+	return deleted, ErrReputation.Wrap(err)
+}
+
+// DeleteAuditActivityBefore deletes audit activity samples recorded before the provided time.
+func (db *reputationDB) DeleteAuditActivityBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := db.ExecContext(ctx,
+		`DELETE FROM audit_activity_history WHERE timestamp < ?`,
+		before.UTC(),
+	)
+	if err != nil {
+		return 0, ErrReputation.Wrap(err)
+	}
+
+	deleted, err := result.RowsAffected()
+	return deleted, ErrReputation.Wrap(err)
+}
+
+// Mute marks stats of specific satellite as muted, so they don't raise notifications.
+// Returns ErrNoStats when there are no stats for the satellite.
+func (db *reputationDB) Mute(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return db.setMuted(ctx, satelliteID, true)
+}
+
+// Unmute clears the muted mark of stats of specific satellite.
+// Returns ErrNoStats when there are no stats for the satellite.
+func (db *reputationDB) Unmute(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return db.setMuted(ctx, satelliteID, false)
+}
+
+// setMuted updates the muted flag of stats of specific satellite.
+func (db *reputationDB) setMuted(ctx context.Context, satelliteID storj.NodeID, muted bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := db.ExecContext(ctx, `UPDATE reputation SET muted = ? WHERE satellite_id = ?`, muted, satelliteID)
+	if err != nil {
+		return ErrReputation.Wrap(err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return ErrReputation.Wrap(err)
+	}
+	if updated == 0 {
+		return ErrReputation.Wrap(reputation.ErrNoStats)
+	}
+	return nil
+}
+
+// TouchContact updates only the last contact time of stats of specific satellite, the update
+// time is kept, so it keeps meaning that the stats changed.
+// Returns ErrNoStats when there are no stats for the satellite.
+func (db *reputationDB) TouchContact(ctx context.Context, satelliteID storj.NodeID, at time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := db.ExecContext(ctx, `UPDATE reputation SET last_contact_at = ? WHERE satellite_id = ?`, at.UTC(), satelliteID)
+	if err != nil {
+		return ErrReputation.Wrap(err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return ErrReputation.Wrap(err)
+	}
+	if updated == 0 {
+		return ErrReputation.Wrap(reputation.ErrNoStats)
+	}
+	return nil
+}
+
+// StoreAuditHistory replaces only the audit history of specific satellite, the other stats and
+// the update time are kept, so it keeps meaning that the scores changed.
+// Returns ErrNoStats when there are no stats for the satellite.
+func (db *reputationDB) StoreAuditHistory(ctx context.Context, satelliteID storj.NodeID, history *pb.AuditHistory) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var auditHistoryBytes []byte
+	var historyUpdatedAt *time.Time
+	if history != nil {
+		auditHistoryBytes, err = pb.Marshal(history)
+		if err != nil {
+			return ErrReputation.Wrap(err)
+		}
+		now := time.Now().UTC()
+		historyUpdatedAt = &now
+	}
+	annotateSpan(ctx, "audit_history_bytes", len(auditHistoryBytes))
+
+	result, err := db.ExecContext(ctx,
+		`UPDATE reputation SET audit_history = ?, history_updated_at = ? WHERE satellite_id = ?`,
+		auditHistoryBytes, historyUpdatedAt, satelliteID)
+	if err != nil {
+		return ErrReputation.Wrap(err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return ErrReputation.Wrap(err)
+	}
+	if updated == 0 {
+		return ErrReputation.Wrap(reputation.ErrNoStats)
+	}
+	return nil
+}
+
+// Compact rebuilds the reputation database file with VACUUM, so space freed by deleted rows
+// is returned to the file system. The reputation database is a separate file, so a full
+// VACUUM only rewrites reputation tables. It locks the database while it runs, so it
+// should be only called from a maintenance chore.
+func (db *reputationDB) Compact(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// VACUUM rewrites the whole file, so the query timeout isn't applied,
+	// it can't be run within a transaction either.
+	_, err = db.ExecContext(ctx, `VACUUM`)
+	return ErrReputation.Wrap(err)
+}
+
+// Reset deletes stats of specific satellite, so the next sync stores them from scratch.
+// Returns ErrNoStats when there are no stats for the satellite.
+func (db *reputationDB) Reset(ctx context.Context, satelliteID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	return ErrReputation.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM reputation WHERE satellite_id = ?`, satelliteID)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return reputation.ErrNoStats
+		}
+		return db.updateSummaryTx(ctx, tx)
+	}))
+}
+
+// renamedHistoryTables are the tables with history of a satellite which is moved by RenameSatellite.
+var renamedHistoryTables = []string{
+	"online_score_history",
+	"audit_activity_history",
+	"status_history",
+	"reputation_snapshots",
+	"reputation_last_seen",
+}
+
+// RenameSatellite moves stats and history of a satellite to its new ID in a single transaction.
+func (db *reputationDB) RenameSatellite(ctx context.Context, oldID, newID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	return ErrReputation.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		exists := func(satelliteID storj.NodeID) (exists bool, err error) {
+			err = tx.QueryRowContext(ctx,
+				`SELECT EXISTS(SELECT 1 FROM reputation WHERE satellite_id = ?)`,
+				satelliteID,
+			).Scan(&exists)
+			return exists, err
+		}
+
+		switch ok, err := exists(oldID); {
+		case err != nil:
+			return err
+		case !ok:
+			return reputation.ErrNoStats
+		}
+		switch ok, err := exists(newID); {
+		case err != nil:
+			return err
+		case ok:
+			return reputation.ErrSatelliteExists
+		}
+
+		_, err := tx.ExecContext(ctx, `UPDATE reputation SET satellite_id = ? WHERE satellite_id = ?`, newID, oldID)
+		if err != nil {
+			return err
+		}
+
+		for _, table := range renamedHistoryTables {
+			// history of newID can be left after it was reset, it would conflict with the moved history.
+			_, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE satellite_id = ?`, newID)
+			if err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx, `UPDATE `+table+` SET satellite_id = ? WHERE satellite_id = ?`, newID, oldID)
+			if err != nil {
+				return err
+			}
+		}
+		return db.updateSummaryTx(ctx, tx)
+	}))
+}
+
+// CountDisqualified returns the number of satellites which disqualified the node.
+func (db *reputationDB) CountDisqualified(ctx context.Context) (_ int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var count int
+	err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM reputation WHERE disqualified_at IS NOT NULL`).Scan(&count)
+	return count, ErrReputation.Wrap(err)
+}
+
+// CountSuspended returns the number of satellites which suspended the node for unknown audit errors.
+func (db *reputationDB) CountSuspended(ctx context.Context) (_ int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var count int
+	err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM reputation WHERE suspended_at IS NOT NULL`).Scan(&count)
+	return count, ErrReputation.Wrap(err)
+}
+
+// CountOfflineSuspended returns the number of satellites which suspended the node for being offline.
+func (db *reputationDB) CountOfflineSuspended(ctx context.Context) (_ int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var count int
+	err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM reputation WHERE offline_suspended_at IS NOT NULL`).Scan(&count)
+	return count, ErrReputation.Wrap(err)
+}
+
+// Summary returns counts of stored stats from the summary table, which is updated on every write.
+func (db *reputationDB) Summary(ctx context.Context) (_ reputation.Summary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var summary reputation.Summary
+	err = db.QueryRowContext(ctx,
+		`SELECT total_satellites, suspended_count, disqualified_count, min_online_score FROM reputation_summary`,
+	).Scan(&summary.TotalSatellites, &summary.Suspended, &summary.Disqualified, &summary.MinOnlineScore)
+	if errors.Is(err, sql.ErrNoRows) {
+		return reputation.Summary{}, nil
+	}
+	return summary, ErrReputation.Wrap(err)
+}
+
+// Statuses classifies stats of all satellites against t. It's cheaper than classifying stats
+// returned by All, because only audit counts and scores are read, the audit history blob is
+// only read and decoded when t.MinWindows needs the number of windows.
+func (db *reputationDB) Statuses(ctx context.Context, t reputation.Thresholds) (_ map[storj.NodeID]reputation.StatusReport, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	auditHistoryColumn := "NULL"
+	if t.MinWindows > 0 {
+		auditHistoryColumn = "audit_history"
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT
+			satellite_id,
+			audit_success_count,
+			audit_total_count,
+			audit_reputation_alpha,
+			audit_reputation_beta,
+			audit_reputation_score,
+			audit_unknown_reputation_alpha,
+			audit_unknown_reputation_beta,
+			audit_unknown_reputation_score,
+			online_score,
+			`+auditHistoryColumn+`
+		FROM reputation`)
+	if err != nil {
+		return nil, ErrReputation.Wrap(err)
+	}
+
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	statuses := make(map[storj.NodeID]reputation.StatusReport)
+	for rows.Next() {
+		var stats reputation.Stats
+		var auditHistoryBytes []byte
+		err := rows.Scan(
+			&stats.SatelliteID,
+			&stats.Audit.SuccessCount,
+			&stats.Audit.TotalCount,
+			&stats.Audit.Alpha,
+			&stats.Audit.Beta,
+			&stats.Audit.Score,
+			&stats.Audit.UnknownAlpha,
+			&stats.Audit.UnknownBeta,
+			&stats.Audit.UnknownScore,
+			&stats.OnlineScore,
+			&auditHistoryBytes,
+		)
+		if err != nil {
+			return nil, ErrReputation.Wrap(err)
+		}
+
+		if auditHistoryBytes != nil {
+			stats.AuditHistory, err = db.readAuditHistory(stats.SatelliteID, auditHistoryBytes)
+			if err != nil {
+				return nil, ErrReputation.Wrap(err)
+			}
+		}
+
+		statuses[stats.SatelliteID] = t.Classify(stats)
+	}
+
+	return statuses, ErrReputation.Wrap(rows.Err())
+}
+
+// updateSummaryTx recomputes the summary table within tx, it has to be called
+// whenever rows of the reputation table are inserted, updated or deleted.
+func (db *reputationDB) updateSummaryTx(ctx context.Context, tx tagsql.Tx) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// SQLite needs the WHERE clause to tell the upsert clause apart from a join constraint.
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO reputation_summary (id, total_satellites, suspended_count, disqualified_count, min_online_score)
+		SELECT 0, COUNT(*), COUNT(suspended_at), COUNT(disqualified_at), COALESCE(MIN(online_score), 0)
+		FROM reputation WHERE true
+		ON CONFLICT(id) DO UPDATE SET
+			total_satellites = excluded.total_satellites,
+			suspended_count = excluded.suspended_count,
+			disqualified_count = excluded.disqualified_count,
+			min_online_score = excluded.min_online_score
+	`)
+	return err
+}
+
+// OnlineScoreHistory retrieves online score samples of a specific satellite recorded in the provided time range.
+func (db *reputationDB) OnlineScoreHistory(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ []reputation.ScoreSample, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx,
+		`SELECT timestamp, score
+			FROM online_score_history
+			WHERE satellite_id = ?
+			AND ? <= timestamp AND timestamp <= ?
+			ORDER BY timestamp`,
+		satelliteID, from.UTC(), to.UTC(),
+	)
+	if err != nil {
+		return nil, ErrReputation.Wrap(err)
+	}
+
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var samples []reputation.ScoreSample
+	for rows.Next() {
+		var sample reputation.ScoreSample
+		if err := rows.Scan(&sample.Timestamp, &sample.Score); err != nil {
+			return nil, ErrReputation.Wrap(err)
+		}
+
+		samples = append(samples, sample)
+	}
+
+	return samples, ErrReputation.Wrap(rows.Err())
+}
+
+// Sparkline retrieves the whole online score history of specific satellite downsampled into at most points
+// scores. The history is bounded by the retention, so it's read at once.
+func (db *reputationDB) Sparkline(ctx context.Context, satelliteID storj.NodeID, points int) (_ []float64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if points <= 0 {
+		return nil, ErrReputation.Wrap(reputation.ErrInvalidSparkline.New("points must be positive: %d", points))
+	}
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx,
+		`SELECT timestamp, score
+			FROM online_score_history
+			WHERE satellite_id = ?
+			ORDER BY timestamp`,
+		satelliteID,
+	)
+	if err != nil {
+		return nil, ErrReputation.Wrap(err)
+	}
+
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var samples []reputation.ScoreSample
+	for rows.Next() {
+		var sample reputation.ScoreSample
+		if err := rows.Scan(&sample.Timestamp, &sample.Score); err != nil {
+			return nil, ErrReputation.Wrap(err)
+		}
+
+		samples = append(samples, sample)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrReputation.Wrap(err)
+	}
+	annotateSpan(ctx, "samples", len(samples))
+
+	return reputation.DownsampleScores(samples, points), nil
+}
+
+// Changelog retrieves changes of scores and flags of specific satellite in the provided time range.
+func (db *reputationDB) Changelog(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ []reputation.ChangeRecord, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx,
+		`SELECT id, timestamp, field, previous_value, current_value
+			FROM reputation_changelog
+			WHERE satellite_id = ?
+			AND ? <= timestamp AND timestamp <= ?
+			ORDER BY timestamp, id`,
+		satelliteID, from.UTC(), to.UTC(),
+	)
+	if err != nil {
+		return nil, ErrReputation.Wrap(err)
+	}
+
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var records []reputation.ChangeRecord
+	for rows.Next() {
+		record := reputation.ChangeRecord{SatelliteID: satelliteID}
+		var field string
+		if err := rows.Scan(&record.ID, &record.Timestamp, &field, &record.Before, &record.After); err != nil {
+			return nil, ErrReputation.Wrap(err)
+		}
+		record.Field = reputation.ChangeField(field)
+
+		records = append(records, record)
+	}
+
+	return records, ErrReputation.Wrap(rows.Err())
+}
+
+// GetChanges retrieves changelog records of all satellites stored after the record with sinceID. The ids
+// are AUTOINCREMENT, so ids of deleted records aren't reused by newer records and the cursor never skips them.
+func (db *reputationDB) GetChanges(ctx context.Context, sinceID int64) (_ []reputation.ChangeRecord, cursor int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx,
+		`SELECT id, satellite_id, timestamp, field, previous_value, current_value
+			FROM reputation_changelog
+			WHERE id > ?
+			ORDER BY id
+			LIMIT ?`,
+		sinceID, reputation.MaxChanges,
+	)
+	if err != nil {
+		return nil, sinceID, ErrReputation.Wrap(err)
+	}
+
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	cursor = sinceID
+	var records []reputation.ChangeRecord
+	for rows.Next() {
+		var record reputation.ChangeRecord
+		var field string
+		if err := rows.Scan(&record.ID, &record.SatelliteID, &record.Timestamp, &field, &record.Before, &record.After); err != nil {
+			return nil, sinceID, ErrReputation.Wrap(err)
+		}
+		record.Field = reputation.ChangeField(field)
+		cursor = record.ID
+
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sinceID, ErrReputation.Wrap(err)
+	}
+
+	return records, cursor, nil
+}
+
+// Availability returns the time-weighted fraction of [from, to) the node was available on specific satellite.
+func (db *reputationDB) Availability(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ float64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !to.After(from) {
+		return 0, reputation.ErrInvalidTimeRange.New("%s is not after %s", to, from)
+	}
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	// the last transition before the range determines the status at its start.
+	rows, err := db.QueryContext(ctx,
+		`SELECT timestamp, available
+			FROM status_history
+			WHERE satellite_id = ?
+			AND timestamp >= COALESCE(
+				(SELECT MAX(timestamp) FROM status_history WHERE satellite_id = ? AND timestamp <= ?),
+				timestamp
+			)
+			AND timestamp < ?
+			ORDER BY timestamp`,
+		satelliteID, satelliteID, from.UTC(), to.UTC(),
+	)
+	if err != nil {
+		return 0, ErrReputation.Wrap(err)
+	}
+
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var transitions []reputation.StatusTransition
+	for rows.Next() {
+		var transition reputation.StatusTransition
+		if err := rows.Scan(&transition.Timestamp, &transition.Available); err != nil {
+			return 0, ErrReputation.Wrap(err)
+		}
+
+		transitions = append(transitions, transition)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, ErrReputation.Wrap(err)
+	}
+
+	return reputation.ComputeAvailability(transitions, from, to)
+}
+
+// AuditActivity retrieves audit count changes of specific satellite recorded in the provided time range.
+func (db *reputationDB) AuditActivity(ctx context.Context, satelliteID storj.NodeID, from, to time.Time) (_ []reputation.ActivitySample, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx,
+		`SELECT timestamp, total_count, success_count
+			FROM audit_activity_history
+			WHERE satellite_id = ?
+			AND ? <= timestamp AND timestamp <= ?
+			ORDER BY timestamp`,
+		satelliteID, from.UTC(), to.UTC(),
+	)
+	if err != nil {
+		return nil, ErrReputation.Wrap(err)
+	}
+
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var samples []reputation.ActivitySample
+	for rows.Next() {
+		var sample reputation.ActivitySample
+		if err := rows.Scan(&sample.Timestamp, &sample.TotalCount, &sample.SuccessCount); err != nil {
+			return nil, ErrReputation.Wrap(err)
+		}
+
+		samples = append(samples, sample)
+	}
+
+	return samples, ErrReputation.Wrap(rows.Err())
+}
+
+// LastSeenStates retrieves stats last seen by the transition log chore.
+func (db *reputationDB) LastSeenStates(ctx context.Context) (_ map[storj.NodeID]reputation.Stats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx,
+		`SELECT satellite_id, audit_score, unknown_audit_score, online_score,
+				suspended_at, offline_suspended_at, disqualified_at
+			FROM reputation_last_seen`,
+	)
+	if err != nil {
+		return nil, ErrReputation.Wrap(err)
+	}
+
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	states := make(map[storj.NodeID]reputation.Stats)
+	for rows.Next() {
+		var stats reputation.Stats
+		err := rows.Scan(&stats.SatelliteID, &stats.Audit.Score, &stats.Audit.UnknownScore, &stats.OnlineScore,
+			&stats.SuspendedAt, &stats.OfflineSuspendedAt, &stats.DisqualifiedAt)
+		if err != nil {
+			return nil, ErrReputation.Wrap(err)
+		}
+
+		states[stats.SatelliteID] = stats
+	}
+
+	return states, ErrReputation.Wrap(rows.Err())
+}
+
+// StoreLastSeenStates replaces stats last seen by the transition log chore in a single transaction.
+func (db *reputationDB) StoreLastSeenStates(ctx context.Context, stats []reputation.Stats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	return ErrReputation.Wrap(withTx(ctx, db.GetDB(), func(tx tagsql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM reputation_last_seen`); err != nil {
+			return err
+		}
+
+		for _, s := range stats {
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO reputation_last_seen (satellite_id, audit_score, unknown_audit_score, online_score,
+						suspended_at, offline_suspended_at, disqualified_at)
+					VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				s.SatelliteID, s.Audit.Score, s.Audit.UnknownScore, s.OnlineScore,
+				utcTime(s.SuspendedAt), utcTime(s.OfflineSuspendedAt), utcTime(s.DisqualifiedAt),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// utcTime converts t to UTC, nil is kept.
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
 }
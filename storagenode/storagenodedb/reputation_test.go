@@ -0,0 +1,401 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+func TestReputationQueryTimeout(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	storageDir := ctx.Dir("storage")
+	db, err := storagenodedb.OpenNew(ctx, zaptest.NewLogger(t), storagenodedb.Config{
+		Pieces:    storageDir,
+		Storage:   storageDir,
+		Info:      filepath.Join(storageDir, "piecestore.db"),
+		Info2:     filepath.Join(storageDir, "info.db"),
+		Filestore: filestore.DefaultConfig,
+
+		ReputationQueryTimeout: time.Nanosecond,
+	})
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+	require.NoError(t, db.MigrateToLatest(ctx))
+
+	stats := reputation.Stats{SatelliteID: testrand.NodeID()}
+
+	// the configured timeout applies to contexts without a deadline.
+	err = db.Reputation().Store(context.Background(), stats)
+	require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+
+	// the deadline of the context takes precedence.
+	expired, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+	_, err = db.Reputation().Get(expired, stats.SatelliteID)
+	require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+
+	withDeadline, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	require.NoError(t, db.Reputation().Store(withDeadline, stats))
+	_, err = db.Reputation().Get(withDeadline, stats.SatelliteID)
+	require.NoError(t, err)
+}
+
+func TestReputationMaxAge(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	storageDir := ctx.Dir("storage")
+	db, err := storagenodedb.OpenNew(ctx, zaptest.NewLogger(t), storagenodedb.Config{
+		Pieces:    storageDir,
+		Storage:   storageDir,
+		Info:      filepath.Join(storageDir, "piecestore.db"),
+		Info2:     filepath.Join(storageDir, "info.db"),
+		Filestore: filestore.DefaultConfig,
+
+		ReputationMaxAge: time.Hour,
+	})
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+	require.NoError(t, db.MigrateToLatest(ctx))
+
+	now := time.Now().UTC()
+	contactedAt, staleAt := now.Add(-time.Minute), now.Add(-2*time.Hour)
+	fresh := reputation.Stats{SatelliteID: testrand.NodeID(), LastContactAt: &contactedAt, UpdatedAt: now}
+	stale := reputation.Stats{SatelliteID: testrand.NodeID(), LastContactAt: &staleAt, UpdatedAt: now}
+	never := reputation.Stats{SatelliteID: testrand.NodeID(), UpdatedAt: now}
+	require.NoError(t, db.Reputation().StoreAll(ctx, []reputation.Stats{fresh, stale, never}))
+	require.NoError(t, db.Reputation().Snapshot(ctx))
+
+	expected := map[storj.NodeID]bool{
+		fresh.SatelliteID: false,
+		stale.SatelliteID: true,
+		never.SatelliteID: true,
+	}
+	for satelliteID, isStale := range expected {
+		stats, err := db.Reputation().Get(ctx, satelliteID)
+		require.NoError(t, err)
+		require.Equal(t, isStale, stats.Stale)
+
+		stats, err = db.Reputation().Get(ctx, satelliteID, reputation.WithoutAuditHistory())
+		require.NoError(t, err)
+		require.Equal(t, isStale, stats.Stale)
+
+		// snapshots are past states, which aren't stale.
+		snapshot, err := db.Reputation().SnapshotAt(ctx, satelliteID, time.Now())
+		require.NoError(t, err)
+		require.False(t, snapshot.Stale)
+	}
+
+	bySatellites, err := db.Reputation().GetBySatellites(ctx, []storj.NodeID{fresh.SatelliteID, stale.SatelliteID, never.SatelliteID})
+	require.NoError(t, err)
+	all, err := db.Reputation().All(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, len(expected))
+	for _, stats := range all {
+		require.Equal(t, expected[stats.SatelliteID], stats.Stale)
+		require.Equal(t, expected[stats.SatelliteID], bySatellites[stats.SatelliteID].Stale)
+	}
+}
+
+func TestReputationCorruptedAuditHistory(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		strict := strict
+		t.Run(fmt.Sprintf("strict=%v", strict), func(t *testing.T) {
+			ctx := testcontext.New(t)
+			defer ctx.Cleanup()
+
+			storageDir := ctx.Dir("storage")
+			db, err := storagenodedb.OpenNew(ctx, zaptest.NewLogger(t), storagenodedb.Config{
+				Pieces:    storageDir,
+				Storage:   storageDir,
+				Info:      filepath.Join(storageDir, "piecestore.db"),
+				Info2:     filepath.Join(storageDir, "info.db"),
+				Filestore: filestore.DefaultConfig,
+
+				ReputationStrictDecode: strict,
+			})
+			require.NoError(t, err)
+			defer ctx.Check(db.Close)
+			require.NoError(t, db.MigrateToLatest(ctx))
+
+			stats := reputation.Stats{
+				SatelliteID:  testrand.NodeID(),
+				OnlineScore:  0.5,
+				Audit:        reputation.Metric{TotalCount: 10, SuccessCount: 9},
+				AuditHistory: &pb.AuditHistory{Score: 0.5},
+			}
+			require.NoError(t, db.Reputation().Store(ctx, stats))
+
+			reputationDB := db.RawDatabases()[storagenodedb.ReputationDBName].GetDB()
+			_, err = reputationDB.ExecContext(ctx, `UPDATE reputation SET audit_history = ? WHERE satellite_id = ?`,
+				[]byte("not an audit history"), stats.SatelliteID)
+			require.NoError(t, err)
+
+			res, err := db.Reputation().Get(ctx, stats.SatelliteID)
+			if strict {
+				require.Error(t, err)
+				require.True(t, storagenodedb.ErrReputation.Has(err), err)
+
+				_, err = db.Reputation().GetBySatellites(ctx, []storj.NodeID{stats.SatelliteID})
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Nil(t, res.AuditHistory)
+			require.Equal(t, stats.OnlineScore, res.OnlineScore)
+			require.Equal(t, stats.Audit.TotalCount, res.Audit.TotalCount)
+			require.Equal(t, stats.Audit.SuccessCount, res.Audit.SuccessCount)
+
+			byID, err := db.Reputation().GetBySatellites(ctx, []storj.NodeID{stats.SatelliteID})
+			require.NoError(t, err)
+			require.Contains(t, byID, stats.SatelliteID)
+			require.Nil(t, byID[stats.SatelliteID].AuditHistory)
+		})
+	}
+}
+
+func TestReputationReadOnly(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	storageDir := ctx.Dir("storage")
+	config := storagenodedb.Config{
+		Pieces:    storageDir,
+		Storage:   storageDir,
+		Info:      filepath.Join(storageDir, "piecestore.db"),
+		Info2:     filepath.Join(storageDir, "info.db"),
+		Filestore: filestore.DefaultConfig,
+	}
+	db, err := storagenodedb.OpenNew(ctx, zaptest.NewLogger(t), config)
+	require.NoError(t, err)
+	require.NoError(t, db.MigrateToLatest(ctx))
+
+	stats := reputation.Stats{
+		SatelliteID: testrand.NodeID(),
+		OnlineScore: 0.5,
+		Audit:       reputation.Metric{TotalCount: 10, SuccessCount: 9},
+	}
+	require.NoError(t, db.Reputation().Store(ctx, stats))
+	require.NoError(t, db.Close())
+
+	readOnly, closeDB, err := storagenodedb.OpenReputationReadOnly(ctx, zaptest.NewLogger(t), storagenodedb.ReputationDBPath(config))
+	require.NoError(t, err)
+	defer ctx.Check(closeDB)
+
+	res, err := readOnly.Get(ctx, stats.SatelliteID)
+	require.NoError(t, err)
+	require.Equal(t, stats.OnlineScore, res.OnlineScore)
+
+	all, err := readOnly.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+
+	filtered, err := readOnly.Filter(ctx, reputation.FilterOpts{})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+
+	require.True(t, errors.Is(readOnly.Store(ctx, stats), reputation.ErrReadOnly))
+	require.True(t, errors.Is(readOnly.StoreAll(ctx, []reputation.Stats{stats}), reputation.ErrReadOnly))
+	require.True(t, errors.Is(readOnly.Reset(ctx, stats.SatelliteID), reputation.ErrReadOnly))
+
+	_, _, err = storagenodedb.OpenReputationReadOnly(ctx, zaptest.NewLogger(t), filepath.Join(storageDir, "missing.db"))
+	require.Error(t, err)
+}
+
+func TestReputationScoreHistoryEpsilon(t *testing.T) {
+	epsilon := func(v float64) *float64 { return &v }
+
+	for _, test := range []struct {
+		name    string
+		epsilon *float64
+		scores  []float64
+	}{
+		{name: "default", epsilon: nil, scores: []float64{0.5, 0.75, 0.875}},
+		{name: "zero", epsilon: epsilon(0), scores: []float64{0.5, 0.5, 0.75, 0.875, 0.8755}},
+		// a change of exactly epsilon is recorded, the first score is always the baseline.
+		{name: "boundary", epsilon: epsilon(0.25), scores: []float64{0.5, 0.75}},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ctx := testcontext.New(t)
+			defer ctx.Cleanup()
+
+			storageDir := ctx.Dir("storage")
+			db, err := storagenodedb.OpenNew(ctx, zaptest.NewLogger(t), storagenodedb.Config{
+				Pieces:    storageDir,
+				Storage:   storageDir,
+				Info:      filepath.Join(storageDir, "piecestore.db"),
+				Info2:     filepath.Join(storageDir, "info.db"),
+				Filestore: filestore.DefaultConfig,
+
+				ReputationScoreHistoryEpsilon: test.epsilon,
+			})
+			require.NoError(t, err)
+			defer ctx.Check(db.Close)
+			require.NoError(t, db.MigrateToLatest(ctx))
+
+			satelliteID := testrand.NodeID()
+			start := time.Now().UTC().Add(-time.Hour)
+			for i, score := range []float64{0.5, 0.5, 0.75, 0.875, 0.8755} {
+				require.NoError(t, db.Reputation().Store(ctx, reputation.Stats{
+					SatelliteID: satelliteID,
+					OnlineScore: score,
+					UpdatedAt:   start.Add(time.Duration(i) * time.Minute),
+				}))
+			}
+
+			samples, err := db.Reputation().OnlineScoreHistory(ctx, satelliteID, start, start.Add(time.Hour))
+			require.NoError(t, err)
+
+			var scores []float64
+			for _, sample := range samples {
+				scores = append(scores, sample.Score)
+			}
+			require.Equal(t, test.scores, scores)
+		})
+	}
+
+	t.Run("negative", func(t *testing.T) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		storageDir := ctx.Dir("storage")
+		_, err := storagenodedb.OpenNew(ctx, zaptest.NewLogger(t), storagenodedb.Config{
+			Pieces:    storageDir,
+			Storage:   storageDir,
+			Info:      filepath.Join(storageDir, "piecestore.db"),
+			Info2:     filepath.Join(storageDir, "info.db"),
+			Filestore: filestore.DefaultConfig,
+
+			ReputationScoreHistoryEpsilon: epsilon(-0.001),
+		})
+		require.True(t, reputation.ErrInvalidScoreHistoryEpsilon.Has(err), err)
+	})
+}
+
+func TestReputationTraceSpans(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	storageDir := ctx.Dir("storage")
+	db, err := storagenodedb.OpenNew(ctx, zaptest.NewLogger(t), storagenodedb.Config{
+		Pieces:    storageDir,
+		Storage:   storageDir,
+		Info:      filepath.Join(storageDir, "piecestore.db"),
+		Info2:     filepath.Join(storageDir, "info.db"),
+		Filestore: filestore.DefaultConfig,
+	})
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+	require.NoError(t, db.MigrateToLatest(ctx))
+
+	observer := &spanRecorder{}
+	trace := monkit.NewTrace(monkit.NewId())
+	defer trace.ObserveSpans(observer)()
+
+	var traceCtx context.Context = ctx
+	var traceErr error
+	finishTrace := monkit.Package().Func().RemoteTrace(&traceCtx, monkit.NewId(), trace)
+
+	stats := []reputation.Stats{
+		{SatelliteID: testrand.NodeID(), AuditHistory: &pb.AuditHistory{Score: 1}},
+		{SatelliteID: testrand.NodeID()},
+	}
+	require.NoError(t, db.Reputation().StoreAll(traceCtx, stats))
+	_, err = db.Reputation().Get(traceCtx, stats[0].SatelliteID)
+	require.NoError(t, err)
+	_, err = db.Reputation().All(traceCtx)
+	require.NoError(t, err)
+	_, err = db.Reputation().Get(traceCtx, testrand.NodeID())
+	require.True(t, errors.Is(err, reputation.ErrNoStats), err)
+
+	finishTrace(&traceErr)
+
+	storeAll := observer.finished("(*reputationDB).StoreAll")
+	require.Len(t, storeAll, 1)
+	require.Equal(t, "2", storeAll[0].annotations["satellites"])
+
+	all := observer.finished("(*reputationDB).All")
+	require.Len(t, all, 1)
+	require.Equal(t, "2", all[0].annotations["satellites"])
+
+	// the span of the failed Get is closed with the error.
+	get := observer.finished("(*reputationDB).Get")
+	require.Len(t, get, 2)
+	require.NoError(t, get[0].err)
+	require.NotEqual(t, "0", get[0].annotations["audit_history_bytes"])
+	require.True(t, errors.Is(get[1].err, reputation.ErrNoStats), get[1].err)
+
+	require.Zero(t, observer.running())
+}
+
+// finishedSpan is a span observed by spanRecorder.
+type finishedSpan struct {
+	err         error
+	annotations map[string]string
+}
+
+// spanRecorder records spans of a trace.
+type spanRecorder struct {
+	mu       sync.Mutex
+	started  int
+	finishes map[string][]finishedSpan
+}
+
+func (recorder *spanRecorder) Start(s *monkit.Span) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.started++
+}
+
+func (recorder *spanRecorder) Finish(s *monkit.Span, err error, panicked bool, finish time.Time) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.started--
+
+	annotations := map[string]string{}
+	for _, annotation := range s.Annotations() {
+		annotations[annotation.Name] = annotation.Value
+	}
+	if recorder.finishes == nil {
+		recorder.finishes = map[string][]finishedSpan{}
+	}
+	name := s.Func().ShortName()
+	recorder.finishes[name] = append(recorder.finishes[name], finishedSpan{err: err, annotations: annotations})
+}
+
+// finished returns the finished spans of the function with the short name.
+func (recorder *spanRecorder) finished(name string) []finishedSpan {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return recorder.finishes[name]
+}
+
+// running returns the number of started spans which didn't finish yet.
+func (recorder *spanRecorder) running() int {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return recorder.started
+}
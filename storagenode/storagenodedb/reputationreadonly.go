@@ -0,0 +1,49 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storagenodedb
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/private/tagsql"
+	"storj.io/storj/storagenode/reputation"
+)
+
+// OpenReputationReadOnly opens the reputation database file at path, e.g. a copy of the
+// database of another node, without migrating it. Writes are refused with
+// reputation.ErrReadOnly and SQLite opens the file in read-only mode. The returned
+// closeDB closes the database.
+//
+// It's here rather than in reputation, because the SQLite implementation of
+// reputation.DB is here and reputation can't import it.
+func OpenReputationReadOnly(ctx context.Context, log *zap.Logger, path string) (_ reputation.DB, closeDB func() error, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	sqlDB, err := tagsql.Open(ctx, "sqlite3", "file:"+path+"?mode=ro&_busy_timeout=10000")
+	if err != nil {
+		return nil, nil, ErrDatabase.Wrap(err)
+	}
+	// mode=ro doesn't fail for missing files until the first query.
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return nil, nil, ErrDatabase.Wrap(errs.Combine(err, sqlDB.Close()))
+	}
+
+	db := &reputationDB{
+		log:       log,
+		broadcast: reputation.NewBroadcaster(log),
+	}
+	db.Configure(sqlDB)
+
+	closeDB = func() error { return ErrDatabase.Wrap(sqlDB.Close()) }
+	return reputation.NewReadOnlyDB(db), closeDB, nil
+}
+
+// ReputationDBPath returns the path of the reputation database file of the databases described by config.
+func ReputationDBPath(config Config) string {
+	return filepath.Join(filepath.Dir(config.Info2), ReputationDBName+".db")
+}
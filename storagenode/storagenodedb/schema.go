@@ -517,6 +517,53 @@ func Schema() map[string]*dbschema.Schema {
 		},
 		"reputation": &dbschema.Schema{
 			Tables: []*dbschema.Table{
+				&dbschema.Table{
+					Name:       "audit_activity_history",
+					PrimaryKey: []string{"satellite_id", "timestamp"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "success_count",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "timestamp",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "total_count",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+					},
+				},
+				&dbschema.Table{
+					Name:       "online_score_history",
+					PrimaryKey: []string{"satellite_id", "timestamp"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "score",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "timestamp",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+					},
+				},
 				&dbschema.Table{
 					Name:       "reputation",
 					PrimaryKey: []string{"satellite_id"},
@@ -566,16 +613,51 @@ func Schema() map[string]*dbschema.Schema {
 							Type:       "REAL",
 							IsNullable: false,
 						},
+						&dbschema.Column{
+							Name:       "disqualification_reason",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
 						&dbschema.Column{
 							Name:       "disqualified_at",
 							Type:       "TIMESTAMP",
 							IsNullable: true,
 						},
+						&dbschema.Column{
+							Name:       "disqualified_observed_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "generation",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "history_updated_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
 						&dbschema.Column{
 							Name:       "joined_at",
 							Type:       "TIMESTAMP",
 							IsNullable: false,
 						},
+						&dbschema.Column{
+							Name:       "last_audit_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "last_contact_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "muted",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
 						&dbschema.Column{
 							Name:       "offline_suspended_at",
 							Type:       "TIMESTAMP",
@@ -591,6 +673,11 @@ func Schema() map[string]*dbschema.Schema {
 							Type:       "REAL",
 							IsNullable: false,
 						},
+						&dbschema.Column{
+							Name:       "satellite_address",
+							Type:       "TEXT",
+							IsNullable: true,
+						},
 						&dbschema.Column{
 							Name:       "satellite_id",
 							Type:       "BLOB",
@@ -633,6 +720,290 @@ func Schema() map[string]*dbschema.Schema {
 						},
 					},
 				},
+				&dbschema.Table{
+					Name:       "reputation_changelog",
+					PrimaryKey: []string{"id"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "current_value",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "field",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "id",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "previous_value",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "timestamp",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+					},
+				},
+				&dbschema.Table{
+					Name:       "reputation_last_seen",
+					PrimaryKey: []string{"satellite_id"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "audit_score",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "disqualified_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "offline_suspended_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "online_score",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "suspended_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "unknown_audit_score",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+					},
+				},
+				&dbschema.Table{
+					Name:       "reputation_snapshots",
+					PrimaryKey: []string{"satellite_id", "snapshot_at"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "audit_reputation_alpha",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "audit_reputation_beta",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "audit_reputation_score",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "audit_success_count",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "audit_total_count",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "audit_unknown_reputation_alpha",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "audit_unknown_reputation_beta",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "audit_unknown_reputation_score",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "disqualification_reason",
+							Type:       "TEXT",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "disqualified_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "disqualified_observed_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "generation",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "joined_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "last_audit_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "last_contact_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "muted",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "offline_suspended_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "offline_under_review_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "online_score",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "satellite_address",
+							Type:       "TEXT",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "snapshot_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "suspended_at",
+							Type:       "TIMESTAMP",
+							IsNullable: true,
+						},
+						&dbschema.Column{
+							Name:       "updated_at",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "uptime_reputation_alpha",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "uptime_reputation_beta",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "uptime_reputation_score",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "uptime_success_count",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "uptime_total_count",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+					},
+				},
+				&dbschema.Table{
+					Name:       "reputation_summary",
+					PrimaryKey: []string{"id"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "disqualified_count",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "id",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "min_online_score",
+							Type:       "REAL",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "suspended_count",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "total_satellites",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+					},
+				},
+				&dbschema.Table{
+					Name:       "status_history",
+					PrimaryKey: []string{"satellite_id", "timestamp"},
+					Columns: []*dbschema.Column{
+						&dbschema.Column{
+							Name:       "available",
+							Type:       "INTEGER",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "satellite_id",
+							Type:       "BLOB",
+							IsNullable: false,
+						},
+						&dbschema.Column{
+							Name:       "timestamp",
+							Type:       "TIMESTAMP",
+							IsNullable: false,
+						},
+					},
+				},
+			},
+			Indexes: []*dbschema.Index{
+				&dbschema.Index{Name: "idx_reputation_updated_at", Table: "reputation", Columns: []string{"updated_at"}, Unique: false, Partial: ""},
+				&dbschema.Index{Name: "idx_reputation_changelog_satellite_timestamp", Table: "reputation_changelog", Columns: []string{"satellite_id", "timestamp"}, Unique: false, Partial: ""},
 			},
 		},
 		"satellites": &dbschema.Schema{
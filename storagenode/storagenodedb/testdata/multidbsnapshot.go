@@ -62,6 +62,23 @@ var States = MultiDBStates{
 		&v45,
 		&v46,
 		&v47,
+		&v48,
+		&v49,
+		&v50,
+		&v51,
+		&v52,
+		&v53,
+		&v54,
+		&v55,
+		&v56,
+		&v57,
+		&v58,
+		&v59,
+		&v60,
+		&v61,
+		&v62,
+		&v63,
+		&v64,
 	},
 }
 
@@ -0,0 +1,66 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testdata
+
+import "storj.io/storj/storagenode/storagenodedb"
+
+var v49 = MultiDBState{
+	Version: 49,
+	DBStates: DBStates{
+		storagenodedb.UsedSerialsDBName:  v48.DBStates[storagenodedb.UsedSerialsDBName],
+		storagenodedb.StorageUsageDBName: v48.DBStates[storagenodedb.StorageUsageDBName],
+		storagenodedb.ReputationDBName: &DBState{
+			SQL: `
+				-- tables to store nodestats cache
+				CREATE TABLE reputation (
+					satellite_id BLOB NOT NULL,
+					uptime_success_count INTEGER NOT NULL,
+					uptime_total_count INTEGER NOT NULL,
+					uptime_reputation_alpha REAL NOT NULL,
+					uptime_reputation_beta REAL NOT NULL,
+					uptime_reputation_score REAL NOT NULL,
+					audit_success_count INTEGER NOT NULL,
+					audit_total_count INTEGER NOT NULL,
+					audit_reputation_alpha REAL NOT NULL,
+					audit_reputation_beta REAL NOT NULL,
+					audit_reputation_score REAL NOT NULL,
+					audit_unknown_reputation_alpha REAL NOT NULL,
+					audit_unknown_reputation_beta REAL NOT NULL,
+					audit_unknown_reputation_score REAL NOT NULL,
+					online_score REAL NOT NULL,
+					audit_history BLOB,
+					disqualified_at TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL,
+					suspended_at TIMESTAMP,
+					offline_suspended_at TIMESTAMP,
+					offline_under_review_at TIMESTAMP,
+					joined_at TIMESTAMP NOT NULL,
+					satellite_address TEXT,
+					PRIMARY KEY (satellite_id)
+				);
+				CREATE TABLE online_score_history (
+					satellite_id BLOB NOT NULL,
+					timestamp TIMESTAMP NOT NULL,
+					score REAL NOT NULL,
+					PRIMARY KEY (satellite_id, timestamp)
+				);
+				INSERT INTO reputation VALUES(X'0ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000',1,1,1.0,1.0,1.0,1,1,1.0,1.0,1.0,1.0,1.0,1.0,1.0,NULL,'2019-07-19 20:00:00+00:00','2019-08-23 20:00:00+00:00',NULL,NULL,NULL,'1970-01-01 00:00:00+00:00',NULL);
+			`,
+			NewData: `
+				INSERT INTO reputation (satellite_id, uptime_success_count, uptime_total_count, uptime_reputation_alpha, uptime_reputation_beta, uptime_reputation_score, audit_success_count, audit_total_count, audit_reputation_alpha, audit_reputation_beta, audit_reputation_score, audit_unknown_reputation_alpha, audit_unknown_reputation_beta, audit_unknown_reputation_score, online_score, updated_at, joined_at, satellite_address) VALUES(X'1ed28abb2813e184a1e98b0f6605c4911ea468c7e8433eb583e0fca7ceac3000',1,1,1.0,1.0,1.0,1,1,1.0,1.0,1.0,1.0,1.0,1.0,1.0,'2021-01-01 00:00:00+00:00','2020-01-01 00:00:00+00:00','us1.storj.io:7777');
+			`,
+		},
+		storagenodedb.PieceSpaceUsedDBName:  v48.DBStates[storagenodedb.PieceSpaceUsedDBName],
+		storagenodedb.PieceInfoDBName:       v48.DBStates[storagenodedb.PieceInfoDBName],
+		storagenodedb.PieceExpirationDBName: v48.DBStates[storagenodedb.PieceExpirationDBName],
+		storagenodedb.OrdersDBName:          v48.DBStates[storagenodedb.OrdersDBName],
+		storagenodedb.BandwidthDBName:       v48.DBStates[storagenodedb.BandwidthDBName],
+		storagenodedb.SatellitesDBName:      v48.DBStates[storagenodedb.SatellitesDBName],
+		storagenodedb.DeprecatedInfoDBName:  v48.DBStates[storagenodedb.DeprecatedInfoDBName],
+		storagenodedb.NotificationsDBName:   v48.DBStates[storagenodedb.NotificationsDBName],
+		storagenodedb.HeldAmountDBName:      v48.DBStates[storagenodedb.HeldAmountDBName],
+		storagenodedb.PricingDBName:         v48.DBStates[storagenodedb.PricingDBName],
+		storagenodedb.APIKeysDBName:         v48.DBStates[storagenodedb.APIKeysDBName],
+	},
+}